@@ -1,6 +1,6 @@
 // Package todo provides primitives to interact with the openapi HTTP API.
 //
-// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.5.0 DO NOT EDIT.
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.7.2 DO NOT EDIT.
 package todo
 
 import (
@@ -15,6 +15,7 @@ import (
 	"time"
 
 	googleuuid "github.com/google/uuid"
+	"github.com/oapi-codegen/nullable"
 	"github.com/oapi-codegen/runtime"
 )
 
@@ -26,25 +27,110 @@ const (
 	PriorityNone   Priority = "none"
 )
 
+// Valid indicates whether the value is a known member of the Priority enum.
+func (e Priority) Valid() bool {
+	switch e {
+	case PriorityHigh:
+		return true
+	case PriorityLow:
+		return true
+	case PriorityMedium:
+		return true
+	case PriorityNone:
+		return true
+	default:
+		return false
+	}
+}
+
+// Category Human readable value used to organize tasks, values are unique.
+type Category = string
+
 // Dates defines model for Dates.
 type Dates struct {
 	// Due When the task is expected to be due, seconds are dropped.
-	Due *time.Time `json:"due"`
+	Due *time.Time `json:"due,omitempty"`
+
+	// Start When the task is expected to begin, seconds are dropped.
+	Start *time.Time `json:"start,omitempty"`
+}
+
+// DatesPatch Dates to update, omitted values are kept and null values are cleared.
+type DatesPatch struct {
+	// Due When the task is expected to be due, seconds are dropped.
+	Due nullable.Nullable[time.Time] `json:"due,omitempty"`
 
 	// Start When the task is expected to begin, seconds are dropped.
-	Start *time.Time `json:"start"`
+	Start nullable.Nullable[time.Time] `json:"start,omitempty"`
+}
+
+// NewSubTask Values used for creating a sub-task.
+type NewSubTask struct {
+	Categories  *[]Category   `json:"categories,omitempty"`
+	Dates       *Dates        `json:"dates,omitempty"`
+	Description string        `json:"description"`
+	Priority    *Priority     `json:"priority,omitempty"`
+	SubTasks    *[]NewSubTask `json:"subTasks,omitempty"`
 }
 
 // Priority defines model for Priority.
 type Priority string
 
+// Problem Details about an error, see RFC 7807.
+type Problem struct {
+	// Detail Human-readable explanation specific to this occurrence of the problem.
+	Detail *string `json:"detail,omitempty"`
+
+	// Errors Invalid fields found in the request.
+	Errors *[]ProblemFieldError `json:"errors,omitempty"`
+
+	// Instance URI reference that identifies the specific occurrence of the problem.
+	Instance *string `json:"instance,omitempty"`
+
+	// Status HTTP status code generated for this occurrence of the problem.
+	Status int `json:"status"`
+
+	// Title Short, human-readable summary of the problem type.
+	Title string `json:"title"`
+
+	// Type URI reference that identifies the problem type.
+	Type string `json:"type"`
+}
+
+// ProblemFieldError Invalid field found in the request.
+type ProblemFieldError struct {
+	// Field Path to the field, for example "subTasks.0.description".
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
 // Task defines model for Task.
 type Task struct {
+	Categories  *[]Category     `json:"categories,omitempty"`
 	Dates       *Dates          `json:"dates,omitempty"`
 	Description string          `json:"description"`
 	ID          googleuuid.UUID `json:"id"`
 	IsDone      *bool           `json:"isDone,omitempty"`
 	Priority    *Priority       `json:"priority,omitempty"`
+	SubTasks    *[]Task         `json:"subTasks,omitempty"`
+
+	// Version Current version of the task, changes every time the task is updated.
+	Version *int64 `json:"version,omitempty"`
+}
+
+// TaskPatch Values used for partially updating a task, omitted values are kept.
+type TaskPatch struct {
+	// Categories When included, replaces all the existing categories.
+	Categories *[]Category `json:"categories,omitempty"`
+
+	// Dates When null, clears both dates.
+	Dates       nullable.Nullable[DatesPatch] `json:"dates,omitempty"`
+	Description *string                       `json:"description,omitempty"`
+	IsDone      *bool                         `json:"isDone,omitempty"`
+	Priority    *Priority                     `json:"priority,omitempty"`
+
+	// SubTasks When included, replaces all the existing sub-tasks.
+	SubTasks *[]NewSubTask `json:"subTasks,omitempty"`
 }
 
 // CreateTasksResponse defines model for CreateTasksResponse.
@@ -52,10 +138,8 @@ type CreateTasksResponse struct {
 	Task Task `json:"task"`
 }
 
-// ErrorResponse defines model for ErrorResponse.
-type ErrorResponse struct {
-	Error string `json:"error"`
-}
+// ErrorResponse Details about an error, see RFC 7807.
+type ErrorResponse = Problem
 
 // ReadTasksResponse defines model for ReadTasksResponse.
 type ReadTasksResponse struct {
@@ -70,50 +154,91 @@ type SearchTasksResponse struct {
 
 // CreateTasksRequest defines model for CreateTasksRequest.
 type CreateTasksRequest struct {
-	Dates       *Dates    `json:"dates,omitempty"`
-	Description string    `json:"description"`
-	Priority    *Priority `json:"priority,omitempty"`
+	Categories  *[]Category   `json:"categories,omitempty"`
+	Dates       *Dates        `json:"dates,omitempty"`
+	Description string        `json:"description"`
+	Priority    *Priority     `json:"priority,omitempty"`
+	SubTasks    *[]NewSubTask `json:"subTasks,omitempty"`
 }
 
+// PatchTasksRequest Values used for partially updating a task, omitted values are kept.
+type PatchTasksRequest = TaskPatch
+
 // SearchTasksRequest defines model for SearchTasksRequest.
 type SearchTasksRequest struct {
-	Description *string   `json:"description"`
+	Description *string   `json:"description,omitempty"`
 	From        int64     `json:"from"`
-	IsDone      *bool     `json:"isDone"`
+	IsDone      *bool     `json:"isDone,omitempty"`
 	Priority    *Priority `json:"priority,omitempty"`
 	Size        int64     `json:"size"`
 }
 
 // UpdateTasksRequest defines model for UpdateTasksRequest.
 type UpdateTasksRequest struct {
-	Dates       *Dates    `json:"dates,omitempty"`
-	Description *string   `json:"description,omitempty"`
-	IsDone      *bool     `json:"isDone,omitempty"`
-	Priority    *Priority `json:"priority,omitempty"`
+	// Categories When included, replaces all the existing categories.
+	Categories  *[]Category `json:"categories,omitempty"`
+	Dates       *Dates      `json:"dates,omitempty"`
+	Description *string     `json:"description,omitempty"`
+	IsDone      *bool       `json:"isDone,omitempty"`
+	Priority    *Priority   `json:"priority,omitempty"`
+
+	// SubTasks When included, replaces all the existing sub-tasks.
+	SubTasks *[]NewSubTask `json:"subTasks,omitempty"`
 }
 
 // CreateTaskJSONBody defines parameters for CreateTask.
 type CreateTaskJSONBody struct {
-	Dates       *Dates    `json:"dates,omitempty"`
-	Description string    `json:"description"`
-	Priority    *Priority `json:"priority,omitempty"`
+	Categories  *[]Category   `json:"categories,omitempty"`
+	Dates       *Dates        `json:"dates,omitempty"`
+	Description string        `json:"description"`
+	Priority    *Priority     `json:"priority,omitempty"`
+	SubTasks    *[]NewSubTask `json:"subTasks,omitempty"`
 }
 
 // SearchTaskJSONBody defines parameters for SearchTask.
 type SearchTaskJSONBody struct {
-	Description *string   `json:"description"`
+	Description *string   `json:"description,omitempty"`
 	From        int64     `json:"from"`
-	IsDone      *bool     `json:"isDone"`
+	IsDone      *bool     `json:"isDone,omitempty"`
 	Priority    *Priority `json:"priority,omitempty"`
 	Size        int64     `json:"size"`
 }
 
+// DeleteTaskParams defines parameters for DeleteTask.
+type DeleteTaskParams struct {
+	// IfMatch When included, the operation only happens when the ETag of the task matches.
+	IfMatch *string `json:"If-Match,omitempty"`
+}
+
+// ReadTaskParams defines parameters for ReadTask.
+type ReadTaskParams struct {
+	// IfNoneMatch When included and it matches the ETag of the task, the task is not returned.
+	IfNoneMatch *string `json:"If-None-Match,omitempty"`
+}
+
+// PatchTaskParams defines parameters for PatchTask.
+type PatchTaskParams struct {
+	// IfMatch When included, the operation only happens when the ETag of the task matches.
+	IfMatch *string `json:"If-Match,omitempty"`
+}
+
 // UpdateTaskJSONBody defines parameters for UpdateTask.
 type UpdateTaskJSONBody struct {
-	Dates       *Dates    `json:"dates,omitempty"`
-	Description *string   `json:"description,omitempty"`
-	IsDone      *bool     `json:"isDone,omitempty"`
-	Priority    *Priority `json:"priority,omitempty"`
+	// Categories When included, replaces all the existing categories.
+	Categories  *[]Category `json:"categories,omitempty"`
+	Dates       *Dates      `json:"dates,omitempty"`
+	Description *string     `json:"description,omitempty"`
+	IsDone      *bool       `json:"isDone,omitempty"`
+	Priority    *Priority   `json:"priority,omitempty"`
+
+	// SubTasks When included, replaces all the existing sub-tasks.
+	SubTasks *[]NewSubTask `json:"subTasks,omitempty"`
+}
+
+// UpdateTaskParams defines parameters for UpdateTask.
+type UpdateTaskParams struct {
+	// IfMatch When included, the operation only happens when the ETag of the task matches.
+	IfMatch *string `json:"If-Match,omitempty"`
 }
 
 // CreateTaskJSONRequestBody defines body for CreateTask for application/json ContentType.
@@ -122,6 +247,9 @@ type CreateTaskJSONRequestBody CreateTaskJSONBody
 // SearchTaskJSONRequestBody defines body for SearchTask for application/json ContentType.
 type SearchTaskJSONRequestBody SearchTaskJSONBody
 
+// PatchTaskApplicationMergePatchPlusJSONRequestBody defines body for PatchTask for application/merge-patch+json ContentType.
+type PatchTaskApplicationMergePatchPlusJSONRequestBody = TaskPatch
+
 // UpdateTaskJSONRequestBody defines body for UpdateTask for application/json ContentType.
 type UpdateTaskJSONRequestBody UpdateTaskJSONBody
 
@@ -209,15 +337,20 @@ type ClientInterface interface {
 	SearchTask(ctx context.Context, body SearchTaskJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// DeleteTask request
-	DeleteTask(ctx context.Context, id googleuuid.UUID, reqEditors ...RequestEditorFn) (*http.Response, error)
+	DeleteTask(ctx context.Context, id googleuuid.UUID, params *DeleteTaskParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// ReadTask request
-	ReadTask(ctx context.Context, id googleuuid.UUID, reqEditors ...RequestEditorFn) (*http.Response, error)
+	ReadTask(ctx context.Context, id googleuuid.UUID, params *ReadTaskParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PatchTaskWithBody request with any body
+	PatchTaskWithBody(ctx context.Context, id googleuuid.UUID, params *PatchTaskParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PatchTaskWithApplicationMergePatchPlusJSONBody(ctx context.Context, id googleuuid.UUID, params *PatchTaskParams, body PatchTaskApplicationMergePatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// UpdateTaskWithBody request with any body
-	UpdateTaskWithBody(ctx context.Context, id googleuuid.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+	UpdateTaskWithBody(ctx context.Context, id googleuuid.UUID, params *UpdateTaskParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
-	UpdateTask(ctx context.Context, id googleuuid.UUID, body UpdateTaskJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+	UpdateTask(ctx context.Context, id googleuuid.UUID, params *UpdateTaskParams, body UpdateTaskJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 }
 
 func (c *Client) CreateTaskWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -268,8 +401,32 @@ func (c *Client) SearchTask(ctx context.Context, body SearchTaskJSONRequestBody,
 	return c.Client.Do(req)
 }
 
-func (c *Client) DeleteTask(ctx context.Context, id googleuuid.UUID, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewDeleteTaskRequest(c.Server, id)
+func (c *Client) DeleteTask(ctx context.Context, id googleuuid.UUID, params *DeleteTaskParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteTaskRequest(c.Server, id, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ReadTask(ctx context.Context, id googleuuid.UUID, params *ReadTaskParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewReadTaskRequest(c.Server, id, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PatchTaskWithBody(ctx context.Context, id googleuuid.UUID, params *PatchTaskParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPatchTaskRequestWithBody(c.Server, id, params, contentType, body)
 	if err != nil {
 		return nil, err
 	}
@@ -280,8 +437,8 @@ func (c *Client) DeleteTask(ctx context.Context, id googleuuid.UUID, reqEditors
 	return c.Client.Do(req)
 }
 
-func (c *Client) ReadTask(ctx context.Context, id googleuuid.UUID, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewReadTaskRequest(c.Server, id)
+func (c *Client) PatchTaskWithApplicationMergePatchPlusJSONBody(ctx context.Context, id googleuuid.UUID, params *PatchTaskParams, body PatchTaskApplicationMergePatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPatchTaskRequestWithApplicationMergePatchPlusJSONBody(c.Server, id, params, body)
 	if err != nil {
 		return nil, err
 	}
@@ -292,8 +449,8 @@ func (c *Client) ReadTask(ctx context.Context, id googleuuid.UUID, reqEditors ..
 	return c.Client.Do(req)
 }
 
-func (c *Client) UpdateTaskWithBody(ctx context.Context, id googleuuid.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewUpdateTaskRequestWithBody(c.Server, id, contentType, body)
+func (c *Client) UpdateTaskWithBody(ctx context.Context, id googleuuid.UUID, params *UpdateTaskParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateTaskRequestWithBody(c.Server, id, params, contentType, body)
 	if err != nil {
 		return nil, err
 	}
@@ -304,8 +461,8 @@ func (c *Client) UpdateTaskWithBody(ctx context.Context, id googleuuid.UUID, con
 	return c.Client.Do(req)
 }
 
-func (c *Client) UpdateTask(ctx context.Context, id googleuuid.UUID, body UpdateTaskJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewUpdateTaskRequest(c.Server, id, body)
+func (c *Client) UpdateTask(ctx context.Context, id googleuuid.UUID, params *UpdateTaskParams, body UpdateTaskJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateTaskRequest(c.Server, id, params, body)
 	if err != nil {
 		return nil, err
 	}
@@ -346,7 +503,7 @@ func NewCreateTaskRequestWithBody(server string, contentType string, body io.Rea
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", queryURL.String(), body)
+	req, err := http.NewRequest(http.MethodPost, queryURL.String(), body)
 	if err != nil {
 		return nil, err
 	}
@@ -386,7 +543,7 @@ func NewSearchTaskRequestWithBody(server string, contentType string, body io.Rea
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", queryURL.String(), body)
+	req, err := http.NewRequest(http.MethodPost, queryURL.String(), body)
 	if err != nil {
 		return nil, err
 	}
@@ -397,12 +554,12 @@ func NewSearchTaskRequestWithBody(server string, contentType string, body io.Rea
 }
 
 // NewDeleteTaskRequest generates requests for DeleteTask
-func NewDeleteTaskRequest(server string, id googleuuid.UUID) (*http.Request, error) {
+func NewDeleteTaskRequest(server string, id googleuuid.UUID, params *DeleteTaskParams) (*http.Request, error) {
 	var err error
 
 	var pathParam0 string
 
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	pathParam0, err = runtime.StyleParamWithOptions("simple", false, "id", id, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: "uuid"})
 	if err != nil {
 		return nil, err
 	}
@@ -422,21 +579,36 @@ func NewDeleteTaskRequest(server string, id googleuuid.UUID) (*http.Request, err
 		return nil, err
 	}
 
-	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	req, err := http.NewRequest(http.MethodDelete, queryURL.String(), nil)
 	if err != nil {
 		return nil, err
 	}
 
+	if params != nil {
+
+		if params.IfMatch != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithOptions("simple", false, "If-Match", *params.IfMatch, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationHeader, Type: "string", Format: ""})
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("If-Match", headerParam0)
+		}
+
+	}
+
 	return req, nil
 }
 
 // NewReadTaskRequest generates requests for ReadTask
-func NewReadTaskRequest(server string, id googleuuid.UUID) (*http.Request, error) {
+func NewReadTaskRequest(server string, id googleuuid.UUID, params *ReadTaskParams) (*http.Request, error) {
 	var err error
 
 	var pathParam0 string
 
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	pathParam0, err = runtime.StyleParamWithOptions("simple", false, "id", id, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: "uuid"})
 	if err != nil {
 		return nil, err
 	}
@@ -456,32 +628,109 @@ func NewReadTaskRequest(server string, id googleuuid.UUID) (*http.Request, error
 		return nil, err
 	}
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	req, err := http.NewRequest(http.MethodGet, queryURL.String(), nil)
 	if err != nil {
 		return nil, err
 	}
 
+	if params != nil {
+
+		if params.IfNoneMatch != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithOptions("simple", false, "If-None-Match", *params.IfNoneMatch, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationHeader, Type: "string", Format: ""})
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("If-None-Match", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewPatchTaskRequestWithApplicationMergePatchPlusJSONBody calls the generic PatchTask builder with application/merge-patch+json body
+func NewPatchTaskRequestWithApplicationMergePatchPlusJSONBody(server string, id googleuuid.UUID, params *PatchTaskParams, body PatchTaskApplicationMergePatchPlusJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPatchTaskRequestWithBody(server, id, params, "application/merge-patch+json", bodyReader)
+}
+
+// NewPatchTaskRequestWithBody generates requests for PatchTask with any type of body
+func NewPatchTaskRequestWithBody(server string, id googleuuid.UUID, params *PatchTaskParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithOptions("simple", false, "id", id, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: "uuid"})
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/tasks/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodPatch, queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		if params.IfMatch != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithOptions("simple", false, "If-Match", *params.IfMatch, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationHeader, Type: "string", Format: ""})
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("If-Match", headerParam0)
+		}
+
+	}
+
 	return req, nil
 }
 
 // NewUpdateTaskRequest calls the generic UpdateTask builder with application/json body
-func NewUpdateTaskRequest(server string, id googleuuid.UUID, body UpdateTaskJSONRequestBody) (*http.Request, error) {
+func NewUpdateTaskRequest(server string, id googleuuid.UUID, params *UpdateTaskParams, body UpdateTaskJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
 	buf, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
 	bodyReader = bytes.NewReader(buf)
-	return NewUpdateTaskRequestWithBody(server, id, "application/json", bodyReader)
+	return NewUpdateTaskRequestWithBody(server, id, params, "application/json", bodyReader)
 }
 
 // NewUpdateTaskRequestWithBody generates requests for UpdateTask with any type of body
-func NewUpdateTaskRequestWithBody(server string, id googleuuid.UUID, contentType string, body io.Reader) (*http.Request, error) {
+func NewUpdateTaskRequestWithBody(server string, id googleuuid.UUID, params *UpdateTaskParams, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
 	var pathParam0 string
 
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	pathParam0, err = runtime.StyleParamWithOptions("simple", false, "id", id, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: "uuid"})
 	if err != nil {
 		return nil, err
 	}
@@ -501,13 +750,28 @@ func NewUpdateTaskRequestWithBody(server string, id googleuuid.UUID, contentType
 		return nil, err
 	}
 
-	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	req, err := http.NewRequest(http.MethodPut, queryURL.String(), body)
 	if err != nil {
 		return nil, err
 	}
 
 	req.Header.Add("Content-Type", contentType)
 
+	if params != nil {
+
+		if params.IfMatch != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithOptions("simple", false, "If-Match", *params.IfMatch, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationHeader, Type: "string", Format: ""})
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("If-Match", headerParam0)
+		}
+
+	}
+
 	return req, nil
 }
 
@@ -565,23 +829,29 @@ type ClientWithResponsesInterface interface {
 	SearchTaskWithResponse(ctx context.Context, body SearchTaskJSONRequestBody, reqEditors ...RequestEditorFn) (*SearchTaskResponse, error)
 
 	// DeleteTaskWithResponse request
-	DeleteTaskWithResponse(ctx context.Context, id googleuuid.UUID, reqEditors ...RequestEditorFn) (*DeleteTaskResponse, error)
+	DeleteTaskWithResponse(ctx context.Context, id googleuuid.UUID, params *DeleteTaskParams, reqEditors ...RequestEditorFn) (*DeleteTaskResponse, error)
 
 	// ReadTaskWithResponse request
-	ReadTaskWithResponse(ctx context.Context, id googleuuid.UUID, reqEditors ...RequestEditorFn) (*ReadTaskResponse, error)
+	ReadTaskWithResponse(ctx context.Context, id googleuuid.UUID, params *ReadTaskParams, reqEditors ...RequestEditorFn) (*ReadTaskResponse, error)
+
+	// PatchTaskWithBodyWithResponse request with any body
+	PatchTaskWithBodyWithResponse(ctx context.Context, id googleuuid.UUID, params *PatchTaskParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PatchTaskResponse, error)
+
+	PatchTaskWithApplicationMergePatchPlusJSONBodyWithResponse(ctx context.Context, id googleuuid.UUID, params *PatchTaskParams, body PatchTaskApplicationMergePatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchTaskResponse, error)
 
 	// UpdateTaskWithBodyWithResponse request with any body
-	UpdateTaskWithBodyWithResponse(ctx context.Context, id googleuuid.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateTaskResponse, error)
+	UpdateTaskWithBodyWithResponse(ctx context.Context, id googleuuid.UUID, params *UpdateTaskParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateTaskResponse, error)
 
-	UpdateTaskWithResponse(ctx context.Context, id googleuuid.UUID, body UpdateTaskJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateTaskResponse, error)
+	UpdateTaskWithResponse(ctx context.Context, id googleuuid.UUID, params *UpdateTaskParams, body UpdateTaskJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateTaskResponse, error)
 }
 
 type CreateTaskResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON201      *CreateTasksResponse
-	JSON400      *ErrorResponse
-	JSON500      *ErrorResponse
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	JSON201                       *CreateTasksResponse
+	ApplicationproblemJSON400     *ErrorResponse
+	ApplicationproblemJSON500     *ErrorResponse
+	ApplicationproblemJSONDefault *ErrorResponse
 }
 
 // Status returns HTTPResponse.Status
@@ -600,12 +870,21 @@ func (r CreateTaskResponse) StatusCode() int {
 	return 0
 }
 
+// ContentType is a convenience method to retrieve the Content-Type value from the HTTP response headers
+func (r CreateTaskResponse) ContentType() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Header.Get("Content-Type")
+	}
+	return ""
+}
+
 type SearchTaskResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *SearchTasksResponse
-	JSON400      *ErrorResponse
-	JSON500      *ErrorResponse
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	JSON200                       *SearchTasksResponse
+	ApplicationproblemJSON400     *ErrorResponse
+	ApplicationproblemJSON500     *ErrorResponse
+	ApplicationproblemJSONDefault *ErrorResponse
 }
 
 // Status returns HTTPResponse.Status
@@ -624,10 +903,21 @@ func (r SearchTaskResponse) StatusCode() int {
 	return 0
 }
 
+// ContentType is a convenience method to retrieve the Content-Type value from the HTTP response headers
+func (r SearchTaskResponse) ContentType() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Header.Get("Content-Type")
+	}
+	return ""
+}
+
 type DeleteTaskResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON500      *ErrorResponse
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	ApplicationproblemJSON404     *ErrorResponse
+	ApplicationproblemJSON412     *ErrorResponse
+	ApplicationproblemJSON500     *ErrorResponse
+	ApplicationproblemJSONDefault *ErrorResponse
 }
 
 // Status returns HTTPResponse.Status
@@ -646,11 +936,21 @@ func (r DeleteTaskResponse) StatusCode() int {
 	return 0
 }
 
+// ContentType is a convenience method to retrieve the Content-Type value from the HTTP response headers
+func (r DeleteTaskResponse) ContentType() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Header.Get("Content-Type")
+	}
+	return ""
+}
+
 type ReadTaskResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *ReadTasksResponse
-	JSON500      *ErrorResponse
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	JSON200                       *ReadTasksResponse
+	ApplicationproblemJSON404     *ErrorResponse
+	ApplicationproblemJSON500     *ErrorResponse
+	ApplicationproblemJSONDefault *ErrorResponse
 }
 
 // Status returns HTTPResponse.Status
@@ -669,11 +969,56 @@ func (r ReadTaskResponse) StatusCode() int {
 	return 0
 }
 
+// ContentType is a convenience method to retrieve the Content-Type value from the HTTP response headers
+func (r ReadTaskResponse) ContentType() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Header.Get("Content-Type")
+	}
+	return ""
+}
+
+type PatchTaskResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	ApplicationproblemJSON400     *ErrorResponse
+	ApplicationproblemJSON404     *ErrorResponse
+	ApplicationproblemJSON412     *ErrorResponse
+	ApplicationproblemJSON500     *ErrorResponse
+	ApplicationproblemJSONDefault *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PatchTaskResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PatchTaskResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// ContentType is a convenience method to retrieve the Content-Type value from the HTTP response headers
+func (r PatchTaskResponse) ContentType() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Header.Get("Content-Type")
+	}
+	return ""
+}
+
 type UpdateTaskResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON400      *ErrorResponse
-	JSON500      *ErrorResponse
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	ApplicationproblemJSON400     *ErrorResponse
+	ApplicationproblemJSON404     *ErrorResponse
+	ApplicationproblemJSON412     *ErrorResponse
+	ApplicationproblemJSON500     *ErrorResponse
+	ApplicationproblemJSONDefault *ErrorResponse
 }
 
 // Status returns HTTPResponse.Status
@@ -692,6 +1037,14 @@ func (r UpdateTaskResponse) StatusCode() int {
 	return 0
 }
 
+// ContentType is a convenience method to retrieve the Content-Type value from the HTTP response headers
+func (r UpdateTaskResponse) ContentType() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Header.Get("Content-Type")
+	}
+	return ""
+}
+
 // CreateTaskWithBodyWithResponse request with arbitrary body returning *CreateTaskResponse
 func (c *ClientWithResponses) CreateTaskWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateTaskResponse, error) {
 	rsp, err := c.CreateTaskWithBody(ctx, contentType, body, reqEditors...)
@@ -727,8 +1080,8 @@ func (c *ClientWithResponses) SearchTaskWithResponse(ctx context.Context, body S
 }
 
 // DeleteTaskWithResponse request returning *DeleteTaskResponse
-func (c *ClientWithResponses) DeleteTaskWithResponse(ctx context.Context, id googleuuid.UUID, reqEditors ...RequestEditorFn) (*DeleteTaskResponse, error) {
-	rsp, err := c.DeleteTask(ctx, id, reqEditors...)
+func (c *ClientWithResponses) DeleteTaskWithResponse(ctx context.Context, id googleuuid.UUID, params *DeleteTaskParams, reqEditors ...RequestEditorFn) (*DeleteTaskResponse, error) {
+	rsp, err := c.DeleteTask(ctx, id, params, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
@@ -736,25 +1089,42 @@ func (c *ClientWithResponses) DeleteTaskWithResponse(ctx context.Context, id goo
 }
 
 // ReadTaskWithResponse request returning *ReadTaskResponse
-func (c *ClientWithResponses) ReadTaskWithResponse(ctx context.Context, id googleuuid.UUID, reqEditors ...RequestEditorFn) (*ReadTaskResponse, error) {
-	rsp, err := c.ReadTask(ctx, id, reqEditors...)
+func (c *ClientWithResponses) ReadTaskWithResponse(ctx context.Context, id googleuuid.UUID, params *ReadTaskParams, reqEditors ...RequestEditorFn) (*ReadTaskResponse, error) {
+	rsp, err := c.ReadTask(ctx, id, params, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
 	return ParseReadTaskResponse(rsp)
 }
 
+// PatchTaskWithBodyWithResponse request with arbitrary body returning *PatchTaskResponse
+func (c *ClientWithResponses) PatchTaskWithBodyWithResponse(ctx context.Context, id googleuuid.UUID, params *PatchTaskParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PatchTaskResponse, error) {
+	rsp, err := c.PatchTaskWithBody(ctx, id, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatchTaskResponse(rsp)
+}
+
+func (c *ClientWithResponses) PatchTaskWithApplicationMergePatchPlusJSONBodyWithResponse(ctx context.Context, id googleuuid.UUID, params *PatchTaskParams, body PatchTaskApplicationMergePatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchTaskResponse, error) {
+	rsp, err := c.PatchTaskWithApplicationMergePatchPlusJSONBody(ctx, id, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatchTaskResponse(rsp)
+}
+
 // UpdateTaskWithBodyWithResponse request with arbitrary body returning *UpdateTaskResponse
-func (c *ClientWithResponses) UpdateTaskWithBodyWithResponse(ctx context.Context, id googleuuid.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateTaskResponse, error) {
-	rsp, err := c.UpdateTaskWithBody(ctx, id, contentType, body, reqEditors...)
+func (c *ClientWithResponses) UpdateTaskWithBodyWithResponse(ctx context.Context, id googleuuid.UUID, params *UpdateTaskParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateTaskResponse, error) {
+	rsp, err := c.UpdateTaskWithBody(ctx, id, params, contentType, body, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
 	return ParseUpdateTaskResponse(rsp)
 }
 
-func (c *ClientWithResponses) UpdateTaskWithResponse(ctx context.Context, id googleuuid.UUID, body UpdateTaskJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateTaskResponse, error) {
-	rsp, err := c.UpdateTask(ctx, id, body, reqEditors...)
+func (c *ClientWithResponses) UpdateTaskWithResponse(ctx context.Context, id googleuuid.UUID, params *UpdateTaskParams, body UpdateTaskJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateTaskResponse, error) {
+	rsp, err := c.UpdateTask(ctx, id, params, body, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
@@ -787,14 +1157,21 @@ func ParseCreateTaskResponse(rsp *http.Response) (*CreateTaskResponse, error) {
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON400 = &dest
+		response.ApplicationproblemJSON400 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
 		var dest ErrorResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON500 = &dest
+		response.ApplicationproblemJSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSONDefault = &dest
 
 	}
 
@@ -827,14 +1204,21 @@ func ParseSearchTaskResponse(rsp *http.Response) (*SearchTaskResponse, error) {
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON400 = &dest
+		response.ApplicationproblemJSON400 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
 		var dest ErrorResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON500 = &dest
+		response.ApplicationproblemJSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSONDefault = &dest
 
 	}
 
@@ -855,12 +1239,33 @@ func ParseDeleteTaskResponse(rsp *http.Response) (*DeleteTaskResponse, error) {
 	}
 
 	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 412:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON412 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
 		var dest ErrorResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON500 = &dest
+		response.ApplicationproblemJSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSONDefault = &dest
 
 	}
 
@@ -888,12 +1293,80 @@ func ParseReadTaskResponse(rsp *http.Response) (*ReadTaskResponse, error) {
 		}
 		response.JSON200 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON404 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
 		var dest ErrorResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON500 = &dest
+		response.ApplicationproblemJSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePatchTaskResponse parses an HTTP response from a PatchTaskWithResponse call
+func ParsePatchTaskResponse(rsp *http.Response) (*PatchTaskResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PatchTaskResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 412:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON412 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSONDefault = &dest
 
 	}
 
@@ -919,14 +1392,35 @@ func ParseUpdateTaskResponse(rsp *http.Response) (*UpdateTaskResponse, error) {
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON400 = &dest
+		response.ApplicationproblemJSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 412:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON412 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
 		var dest ErrorResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON500 = &dest
+		response.ApplicationproblemJSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSONDefault = &dest
 
 	}
 
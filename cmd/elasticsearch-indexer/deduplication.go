@@ -0,0 +1,48 @@
+package main
+
+import (
+	"time"
+
+	cmdinternal "github.com/MarioCarrion/todo-api-microservice-example/cmd/internal"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/consumer"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/envvar"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/postgresql"
+	internalredis "github.com/MarioCarrion/todo-api-microservice-example/internal/redis"
+)
+
+// deduplicationTTL indicates how long processed event IDs are kept, Task versions are kept indefinitely except when
+// using the memory store.
+const deduplicationTTL = 24 * time.Hour
+
+// NewDeduplicationStore initializes the store used for tracking processed events, using configuration defined in
+// environment variables. The returned function releases the store resources.
+//
+//nolint:nolintlint,ireturn
+func NewDeduplicationStore(conf *envvar.Configuration) (consumer.DeduplicationStore, func(), error) {
+	store, err := conf.Get("DEDUPLICATION_STORE")
+	if err != nil {
+		return nil, nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "conf.Get DEDUPLICATION_STORE")
+	}
+
+	switch store {
+	case "", "memory":
+		return consumer.NewMemoryDeduplicationStore(deduplicationTTL), func() {}, nil
+	case "redis":
+		client, err := cmdinternal.NewRedis(conf)
+		if err != nil {
+			return nil, nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "internal.NewRedis")
+		}
+
+		return internalredis.NewDeduplicationStore(client, consumerName, deduplicationTTL), func() { _ = client.Close() }, nil
+	case "postgresql":
+		pool, err := cmdinternal.NewPostgreSQL(conf)
+		if err != nil {
+			return nil, nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "internal.NewPostgreSQL")
+		}
+
+		return postgresql.NewDeduplicationStore(pool, consumerName, deduplicationTTL), pool.Close, nil
+	}
+
+	return nil, nil, internal.NewErrorf(internal.ErrorCodeInvalidArgument, "invalid DEDUPLICATION_STORE %q", store)
+}
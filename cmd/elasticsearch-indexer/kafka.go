@@ -0,0 +1,46 @@
+//go:build kafka
+
+package main
+
+import (
+	"go.uber.org/zap"
+
+	cmdinternal "github.com/MarioCarrion/todo-api-microservice-example/cmd/internal"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/consumer"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/envvar"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/kafka"
+)
+
+// KafkaMessageBroker represents Kafka as a Message Broker.
+type KafkaMessageBroker struct {
+	consumer *cmdinternal.KafkaConsumer
+	source   consumer.Source
+}
+
+// NewMessageBrokerConsumer initializes a new Kafka Broker.
+func NewMessageBrokerConsumer(_ *zap.Logger, conf *envvar.Configuration, _ int) (MessageBrokerConsumer, error) { //nolint: ireturn
+	client, err := cmdinternal.NewKafkaConsumer(conf, consumerName)
+	if err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "internal.NewKafkaConsumer")
+	}
+
+	return &KafkaMessageBroker{
+		consumer: client,
+		source:   kafka.NewTaskSource(client.Consumer),
+	}, nil
+}
+
+// Source returns the Kafka broker.
+func (m *KafkaMessageBroker) Source() consumer.Source { //nolint: ireturn
+	return m.source
+}
+
+// Close closes the broker.
+func (m *KafkaMessageBroker) Close() error {
+	if err := m.consumer.Consumer.Close(); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "consumer.Close")
+	}
+
+	return nil
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/MarioCarrion/todo-api-microservice-example/cmd/internal"
+	internaldomain "github.com/MarioCarrion/todo-api-microservice-example/internal"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/consumer"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/elasticsearch"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/envvar"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/memcached"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/service"
+)
+
+const consumerName = "elasticsearch-indexer"
+
+// MessageBrokerConsumer represents the type that indicates the different Message Brokers supported.
+type MessageBrokerConsumer interface {
+	Source() consumer.Source
+	Close() error
+}
+
+func main() {
+	var (
+		env     string
+		workers int
+	)
+
+	flag.StringVar(&env, "env", "", "Environment Variables filename")
+	flag.IntVar(&workers, "workers", 4, "Number of messages indexed concurrently")
+	flag.Parse()
+
+	errC, err := run(env, workers)
+	if err != nil {
+		log.Fatalf("Couldn't run: %s", err)
+	}
+
+	if err := <-errC; err != nil {
+		log.Fatalf("Error while running: %s", err)
+	}
+}
+
+func run(env string, workers int) (<-chan error, error) {
+	logger, err := zap.NewProduction()
+	if err != nil {
+		return nil, internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "zap.NewProduction")
+	}
+
+	if err := envvar.Load(env); err != nil {
+		return nil, internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "envvar.Load")
+	}
+
+	vault, err := internal.NewVaultProvider()
+	if err != nil {
+		return nil, internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "internal.NewVaultProvider")
+	}
+
+	conf := envvar.New(vault)
+
+	//-
+
+	esClient, err := internal.NewElasticSearch(conf)
+	if err != nil {
+		return nil, internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "internal.NewElasticSearch")
+	}
+
+	cache, err := internal.NewCache(conf)
+	if err != nil {
+		return nil, internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "internal.NewCache")
+	}
+
+	msgBroker, err := NewMessageBrokerConsumer(logger, conf, workers)
+	if err != nil {
+		return nil, internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "NewMessageBrokerConsumer")
+	}
+
+	dedupStore, closeDedupStore, err := NewDeduplicationStore(conf)
+	if err != nil {
+		return nil, internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "NewDeduplicationStore")
+	}
+
+	//-
+
+	// Indexing through the cache invalidates the search results cached by rest-server.
+	repo := memcached.NewSearchableTask(cache, elasticsearch.NewTask(esClient))
+	indexer := consumer.Deduplicate(logger, dedupStore, service.NewIndexer(repo))
+	cons := consumer.NewConsumer(logger, msgBroker.Source(), indexer, workers)
+
+	errC := make(chan error, 1)
+
+	ctx, stop := signal.NotifyContext(context.Background(),
+		os.Interrupt,
+		syscall.SIGTERM,
+		syscall.SIGQUIT)
+
+	consumerDone := make(chan struct{})
+
+	go func() {
+		logger.Info("Consuming", zap.Int("workers", workers))
+
+		if err := cons.Run(ctx); err != nil {
+			errC <- err
+
+			stop()
+		}
+
+		close(consumerDone)
+	}()
+
+	go func() {
+		<-ctx.Done()
+
+		logger.Info("Shutdown signal received")
+
+		ctxTimeout, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+
+		defer func() {
+			_ = logger.Sync()
+			_ = msgBroker.Close()
+
+			closeDedupStore()
+
+			stop()
+			cancel()
+			close(errC)
+		}()
+
+		select {
+		case <-ctxTimeout.Done():
+			errC <- internaldomain.WrapErrorf(ctxTimeout.Err(), internaldomain.ErrorCodeUnknown, "context.Done")
+		case <-consumerDone:
+			logger.Info("Shutdown completed")
+		}
+	}()
+
+	return errC, nil
+}
@@ -0,0 +1,62 @@
+//go:build rabbitmq
+
+package main
+
+import (
+	"go.uber.org/zap"
+
+	cmdinternal "github.com/MarioCarrion/todo-api-microservice-example/cmd/internal"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/consumer"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/envvar"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/rabbitmq"
+)
+
+// RabbitMQMessageBroker represents RabbitMQ as a Message Broker.
+type RabbitMQMessageBroker struct {
+	client *cmdinternal.RabbitMQ
+	source consumer.Source
+}
+
+// NewMessageBrokerConsumer initializes a new RabbitMQ Broker, workers is used as the prefetch count.
+//
+//nolint:ireturn
+func NewMessageBrokerConsumer(_ *zap.Logger, conf *envvar.Configuration, workers int) (MessageBrokerConsumer, error) {
+	client, err := cmdinternal.NewRabbitMQ(conf)
+	if err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "internal.NewRabbitMQ")
+	}
+
+	queue := rabbitmq.NewQueue(consumerName)
+
+	if err := client.DeclareQueue(queue); err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "client.DeclareQueue")
+	}
+
+	if err := client.Channel.Qos(
+		workers, // prefetch count
+		0,       // prefetch size
+		false,   // global
+	); err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "ch.Qos")
+	}
+
+	return &RabbitMQMessageBroker{
+		client: client,
+		source: rabbitmq.NewTaskSource(client.Channel, queue),
+	}, nil
+}
+
+// Source returns the RabbitMQ broker.
+func (m *RabbitMQMessageBroker) Source() consumer.Source { //nolint: ireturn
+	return m.source
+}
+
+// Close closes the broker.
+func (m *RabbitMQMessageBroker) Close() error {
+	if err := m.client.Close(); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "client.Close")
+	}
+
+	return nil
+}
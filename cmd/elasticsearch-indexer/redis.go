@@ -0,0 +1,70 @@
+//go:build !kafka && !rabbitmq
+
+package main
+
+import (
+	"os"
+
+	"github.com/go-redis/redis/v8"
+	"go.uber.org/zap"
+
+	cmdinternal "github.com/MarioCarrion/todo-api-microservice-example/cmd/internal"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/consumer"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/envvar"
+	internalredis "github.com/MarioCarrion/todo-api-microservice-example/internal/redis"
+)
+
+// RedisMessageBroker represents Redis as a Message Broker.
+type RedisMessageBroker struct {
+	client *redis.Client
+	source consumer.Source
+}
+
+// NewMessageBrokerConsumer initializes a new Redis Broker.
+//
+//nolint:nolintlint,ireturn
+func NewMessageBrokerConsumer(logger *zap.Logger, conf *envvar.Configuration, _ int) (MessageBrokerConsumer, error) {
+	transport, err := cmdinternal.NewRedisTransport(conf)
+	if err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "internal.NewRedisTransport")
+	}
+
+	client, err := cmdinternal.NewRedis(conf)
+	if err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "internal.NewRedis")
+	}
+
+	if transport == cmdinternal.RedisTransportPubSub {
+		return &RedisMessageBroker{
+			client: client,
+			source: internalredis.NewTaskSource(client),
+		}, nil
+	}
+
+	// The hostname identifies the consumer in the group, it must be stable across restarts to claim its own
+	// pending messages.
+	name, err := os.Hostname()
+	if err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "os.Hostname")
+	}
+
+	return &RedisMessageBroker{
+		client: client,
+		source: internalredis.NewStreamTaskSource(logger, client, internalredis.TaskStream, consumerName, name),
+	}, nil
+}
+
+// Source returns the Redis broker.
+func (m *RedisMessageBroker) Source() consumer.Source { //nolint: nolintlint,ireturn
+	return m.source
+}
+
+// Close closes the broker.
+func (m *RedisMessageBroker) Close() error {
+	if err := m.client.Close(); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "client.Close")
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+
+	"github.com/MarioCarrion/todo-api-microservice-example/cmd/internal"
+	internaldomain "github.com/MarioCarrion/todo-api-microservice-example/internal"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/elasticsearch"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/envvar"
+)
+
+func main() {
+	var (
+		env     string
+		version int
+	)
+
+	flag.StringVar(&env, "env", "", "Environment Variables filename")
+	flag.IntVar(&version, "version", elasticsearch.TaskIndexVersion, "Version of the index definition to migrate to")
+	flag.Parse()
+
+	if err := run(env, version); err != nil {
+		log.Fatalf("Couldn't run: %s", err)
+	}
+}
+
+// run creates the versioned index and points the Tasks alias to it, reindexing the Tasks from the previous index.
+func run(env string, version int) error {
+	if err := envvar.Load(env); err != nil {
+		return internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "envvar.Load")
+	}
+
+	vault, err := internal.NewVaultProvider()
+	if err != nil {
+		return internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "internal.NewVaultProvider")
+	}
+
+	esClient, err := internal.NewElasticSearch(envvar.New(vault))
+	if err != nil {
+		return internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "internal.NewElasticSearch")
+	}
+
+	target := elasticsearch.TaskIndexName(version)
+
+	previous, err := elasticsearch.NewMigrator(esClient).Migrate(context.Background(), version)
+	if err != nil {
+		return internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "migrator.Migrate")
+	}
+
+	switch previous {
+	case "":
+		log.Printf("Created %s, alias %s points to it", target, elasticsearch.TaskAlias)
+	case target:
+		log.Printf("Alias %s already points to %s", elasticsearch.TaskAlias, target)
+	default:
+		log.Printf("Migrated from %s to %s, alias %s points to it", previous, target, elasticsearch.TaskAlias)
+	}
+
+	return nil
+}
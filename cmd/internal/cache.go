@@ -0,0 +1,91 @@
+package internal
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/MarioCarrion/todo-api-microservice-example/internal"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/cache"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/envvar"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/memcached"
+	internalredis "github.com/MarioCarrion/todo-api-microservice-example/internal/redis"
+)
+
+const (
+	// defaultCacheMemorySize indicates the number of values kept in memory when CACHE_MEMORY_SIZE is not set.
+	defaultCacheMemorySize = 10_000
+
+	// cacheLocalExpiration indicates how long values are kept in the local cache, in front of the remote one.
+	cacheLocalExpiration = 5 * time.Second
+)
+
+// NewCache instantiates the cache used by the repositories using configuration defined in environment variables,
+// memcached is used by default. When CACHE_LOCAL is "true" an in-memory LRU is used in front of it.
+//
+//nolint:nolintlint,ireturn
+func NewCache(conf *envvar.Configuration) (cache.Cache, error) {
+	get := func(key string) (string, error) {
+		value, err := conf.Get(key)
+		if err != nil {
+			return "", internal.WrapErrorf(err, internal.ErrorCodeUnknown, "conf.Get %s", key)
+		}
+
+		return value, nil
+	}
+
+	store, err := get("CACHE_STORE")
+	if err != nil {
+		return nil, err
+	}
+
+	local, err := get("CACHE_LOCAL")
+	if err != nil {
+		return nil, err
+	}
+
+	size, err := get("CACHE_MEMORY_SIZE")
+	if err != nil {
+		return nil, err
+	}
+
+	memorySize := defaultCacheMemorySize
+
+	if size != "" {
+		if memorySize, err = strconv.Atoi(size); err != nil || memorySize < 1 {
+			return nil, internal.NewErrorf(internal.ErrorCodeInvalidArgument, "invalid CACHE_MEMORY_SIZE %q", size)
+		}
+	}
+
+	var res cache.Cache
+
+	switch store {
+	case "", "memcached":
+		client, err := NewMemcached(conf)
+		if err != nil {
+			return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "NewMemcached")
+		}
+
+		res = memcached.NewClient(client)
+	case "redis":
+		client, err := NewRedis(conf)
+		if err != nil {
+			return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "NewRedis")
+		}
+
+		res = internalredis.NewCache(client)
+	case "memory":
+		// Values are not shared between processes, tiering is not needed.
+		return cache.NewLRU(memorySize), nil
+	default:
+		return nil, internal.NewErrorf(internal.ErrorCodeInvalidArgument, "invalid CACHE_STORE %q", store)
+	}
+
+	switch local {
+	case "", "false":
+		return res, nil
+	case "true":
+		return cache.NewTiered(cache.NewLRU(memorySize), res, cacheLocalExpiration), nil
+	}
+
+	return nil, internal.NewErrorf(internal.ErrorCodeInvalidArgument, "invalid CACHE_LOCAL %q", local)
+}
@@ -0,0 +1,25 @@
+package internal
+
+import (
+	"github.com/MarioCarrion/todo-api-microservice-example/internal"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/cloudevents"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/envvar"
+)
+
+// NewEventsContentType returns the content type used for encoding the data of published events, using configuration
+// defined in environment variables. JSON is used by default.
+func NewEventsContentType(conf *envvar.Configuration) (string, error) {
+	contentType, err := conf.Get("EVENTS_CONTENT_TYPE")
+	if err != nil {
+		return "", internal.WrapErrorf(err, internal.ErrorCodeUnknown, "conf.Get EVENTS_CONTENT_TYPE")
+	}
+
+	switch contentType {
+	case "":
+		return cloudevents.ContentTypeJSON, nil
+	case cloudevents.ContentTypeJSON, cloudevents.ContentTypeProtobuf:
+		return contentType, nil
+	}
+
+	return "", internal.NewErrorf(internal.ErrorCodeInvalidArgument, "invalid EVENTS_CONTENT_TYPE %q", contentType)
+}
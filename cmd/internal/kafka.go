@@ -1,6 +1,8 @@
 package internal
 
 import (
+	"time"
+
 	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
 
 	"github.com/MarioCarrion/todo-api-microservice-example/internal"
@@ -35,6 +37,19 @@ func NewKafkaProducer(conf *envvar.Configuration) (*KafkaProducer, error) {
 	}, nil
 }
 
+// Close waits for the messages in flight to be delivered, up to timeout, and then closes the producer.
+func (k *KafkaProducer) Close(timeout time.Duration) error {
+	remaining := k.Producer.Flush(int(timeout.Milliseconds()))
+
+	k.Producer.Close()
+
+	if remaining > 0 {
+		return internal.NewErrorf(internal.ErrorCodeUnknown, "%d messages were not delivered", remaining)
+	}
+
+	return nil
+}
+
 // KafkaConsumer is the consumer implementation of Kafka.
 type KafkaConsumer struct {
 	Consumer *kafka.Consumer
@@ -55,14 +55,95 @@ func NewRabbitMQ(conf *envvar.Configuration) (*RabbitMQ, error) {
 		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "ch.Qos")
 	}
 
-	// XXX: Dead Letter Exchange will be implemented in future episodes
-
 	return &RabbitMQ{
 		Connection: conn,
 		Channel:    channel,
 	}, nil
 }
 
+// DeclareQueue declares the queue used for consuming Task events, including the exchanges and queues used for
+// retrying and dead lettering its messages.
+func (r *RabbitMQ) DeclareQueue(queue rabbitmqtask.Queue) error {
+	if err := r.declareExchange(queue.DeadLetterExchangeName(), amqp.ExchangeFanout); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "declareExchange dead letter")
+	}
+
+	if err := r.declareQueue(queue.DeadLetterQueueName(), nil); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "declareQueue dead letter")
+	}
+
+	if err := r.Channel.QueueBind(queue.DeadLetterQueueName(), "", queue.DeadLetterExchangeName(), false, nil); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "ch.QueueBind dead letter")
+	}
+
+	// Messages rejected without being requeued are dead lettered directly.
+	if err := r.declareQueue(queue.Name, amqp.Table{
+		"x-dead-letter-exchange": queue.DeadLetterExchangeName(),
+	}); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "declareQueue")
+	}
+
+	if err := r.Channel.QueueBind(queue.Name, rabbitmqtask.RoutingKeyWildcard, rabbitmqtask.ExchangeName, false, nil); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "ch.QueueBind")
+	}
+
+	if err := r.declareExchange(queue.RetryExchangeName(), amqp.ExchangeDirect); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "declareExchange retry")
+	}
+
+	// Expired messages are dead lettered back to the queue using the default exchange.
+	for _, delay := range queue.RetryDelays {
+		name := queue.RetryQueueName(delay)
+
+		if err := r.declareQueue(name, amqp.Table{
+			"x-message-ttl":             delay.Milliseconds(),
+			"x-dead-letter-exchange":    "",
+			"x-dead-letter-routing-key": queue.Name,
+		}); err != nil {
+			return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "declareQueue retry")
+		}
+
+		if err := r.Channel.QueueBind(name, name, queue.RetryExchangeName(), false, nil); err != nil {
+			return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "ch.QueueBind retry")
+		}
+	}
+
+	return nil
+}
+
+func (r *RabbitMQ) declareExchange(name, kind string) error {
+	err := r.Channel.ExchangeDeclare(
+		name,
+		kind,  // type
+		true,  // durable
+		false, // auto-deleted
+		false, // internal
+		false, // no-wait
+		nil,   // arguments
+	)
+	if err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "ch.ExchangeDeclare")
+	}
+
+	return nil
+}
+
+func (r *RabbitMQ) declareQueue(name string, args amqp.Table) error {
+	_, err := r.Channel.QueueDeclare(
+		name,
+		true,  // durable
+		false, // delete when unused
+		false, // exclusive
+		false, // no-wait
+		args,  // arguments
+	)
+	if err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "ch.QueueDeclare")
+	}
+
+	return nil
+}
+
 // Close ...
 func (r *RabbitMQ) Close() error {
 	if err := r.Connection.Close(); err != nil {
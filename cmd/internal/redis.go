@@ -35,3 +35,29 @@ func NewRedis(conf *envvar.Configuration) (*redis.Client, error) {
 
 	return rdb, nil
 }
+
+const (
+	// RedisTransportStreams indicates Redis Streams are used for publishing and consuming events.
+	RedisTransportStreams = "streams"
+
+	// RedisTransportPubSub indicates Redis Pub/Sub is used for publishing and consuming events.
+	RedisTransportPubSub = "pubsub"
+)
+
+// NewRedisTransport returns the transport used for publishing and consuming events, using configuration defined in
+// environment variables. Redis Streams are used by default.
+func NewRedisTransport(conf *envvar.Configuration) (string, error) {
+	transport, err := conf.Get("REDIS_TRANSPORT")
+	if err != nil {
+		return "", internal.WrapErrorf(err, internal.ErrorCodeUnknown, "conf.Get REDIS_TRANSPORT")
+	}
+
+	switch transport {
+	case "":
+		return RedisTransportStreams, nil
+	case RedisTransportStreams, RedisTransportPubSub:
+		return transport, nil
+	}
+
+	return "", internal.NewErrorf(internal.ErrorCodeInvalidArgument, "invalid REDIS_TRANSPORT %q", transport)
+}
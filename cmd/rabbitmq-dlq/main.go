@@ -0,0 +1,145 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"log"
+	"maps"
+	"os"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+
+	"github.com/MarioCarrion/todo-api-microservice-example/cmd/internal"
+	internaldomain "github.com/MarioCarrion/todo-api-microservice-example/internal"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/envvar"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/rabbitmq"
+)
+
+type message struct {
+	ID        string               `json:"id,omitempty"`
+	Type      string               `json:"type"`
+	Attempts  int                  `json:"attempts"`
+	Reason    string               `json:"reason,omitempty"`
+	Timestamp time.Time            `json:"timestamp"`
+	Task      *internaldomain.Task `json:"task,omitempty"`
+	Error     string               `json:"error,omitempty"`
+}
+
+func main() {
+	var (
+		env, queue string
+		replay     bool
+		limit      int
+	)
+
+	flag.StringVar(&env, "env", "", "Environment Variables filename")
+	flag.StringVar(&queue, "queue", "elasticsearch-indexer", "Queue with the dead letter queue to use")
+	flag.BoolVar(&replay, "replay", false, "Publish the messages back to the Tasks exchange, instead of only printing them")
+	flag.IntVar(&limit, "limit", 10, "Maximum number of messages to process")
+	flag.Parse()
+
+	if err := run(env, rabbitmq.NewQueue(queue), replay, limit); err != nil {
+		log.Fatalf("Couldn't run: %s", err)
+	}
+}
+
+// run prints the messages in the dead letter queue, as JSON, and optionally replays them. Messages that are not
+// replayed are requeued when the connection is closed.
+func run(env string, queue rabbitmq.Queue, replay bool, limit int) error {
+	if err := envvar.Load(env); err != nil {
+		return internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "envvar.Load")
+	}
+
+	vault, err := internal.NewVaultProvider()
+	if err != nil {
+		return internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "internal.NewVaultProvider")
+	}
+
+	rmq, err := internal.NewRabbitMQ(envvar.New(vault))
+	if err != nil {
+		return internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "internal.NewRabbitMQ")
+	}
+
+	defer func() {
+		_ = rmq.Close()
+	}()
+
+	enc := json.NewEncoder(os.Stdout)
+
+	for range limit {
+		msg, ok, err := rmq.Channel.Get(queue.DeadLetterQueueName(), false)
+		if err != nil {
+			return internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "ch.Get")
+		}
+
+		if !ok {
+			break
+		}
+
+		if err := enc.Encode(newMessage(msg)); err != nil {
+			return internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "json.Encode")
+		}
+
+		if !replay {
+			continue
+		}
+
+		if err := rmq.Channel.PublishWithContext(context.Background(),
+			rabbitmq.ExchangeName,     // exchange
+			rabbitmq.MessageType(msg), // routing key
+			false,                     // mandatory
+			false,                     // immediate
+			amqp.Publishing{
+				Headers:      replayHeaders(msg.Headers),
+				AppId:        msg.AppId,
+				ContentType:  msg.ContentType,
+				DeliveryMode: amqp.Persistent,
+				MessageId:    msg.MessageId,
+				Body:         msg.Body,
+				Timestamp:    msg.Timestamp,
+				Type:         rabbitmq.MessageType(msg),
+			}); err != nil {
+			return internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "ch.Publish")
+		}
+
+		if err := msg.Ack(false); err != nil {
+			return internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "msg.Ack")
+		}
+	}
+
+	return nil
+}
+
+func newMessage(msg amqp.Delivery) message {
+	res := message{
+		Type:      rabbitmq.MessageType(msg),
+		Attempts:  rabbitmq.Attempts(msg.Headers),
+		Timestamp: msg.Timestamp,
+	}
+
+	if reason, ok := msg.Headers[rabbitmq.HeaderFailureReason].(string); ok {
+		res.Reason = reason
+	}
+
+	event, err := rabbitmq.Decode(msg)
+	if err != nil {
+		res.Error = err.Error()
+	} else {
+		res.ID = event.ID
+		res.Task = &event.Task
+	}
+
+	return res
+}
+
+// replayHeaders returns the headers without the ones used for retrying, so replayed messages are retried again.
+func replayHeaders(headers amqp.Table) amqp.Table {
+	res := maps.Clone(headers)
+
+	delete(res, rabbitmq.HeaderAttempts)
+	delete(res, rabbitmq.HeaderFailureReason)
+
+	return res
+}
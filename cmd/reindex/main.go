@@ -0,0 +1,157 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"io/fs"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/MarioCarrion/todo-api-microservice-example/cmd/internal"
+	internaldomain "github.com/MarioCarrion/todo-api-microservice-example/internal"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/elasticsearch"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/envvar"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/postgresql"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/service"
+)
+
+type difference struct {
+	ID             string                     `json:"id"`
+	Missing        bool                       `json:"missing,omitempty"`
+	Orphaned       bool                       `json:"orphaned,omitempty"`
+	Version        int64                      `json:"version"`
+	IndexedVersion int64                      `json:"indexedVersion,omitempty"`
+	Fields         []internaldomain.TaskField `json:"fields,omitempty"`
+}
+
+func main() {
+	var (
+		env, checkpoint string
+		batchSize       int
+		dryRun          bool
+	)
+
+	flag.StringVar(&env, "env", "", "Environment Variables filename")
+	flag.StringVar(&checkpoint, "checkpoint", "reindex.checkpoint", "File used for resuming, it is deleted after completing")
+	flag.IntVar(&batchSize, "batch", 500, "Number of tasks indexed per bulk request")
+	flag.BoolVar(&dryRun, "dry-run", false, "Print the tasks that differ from the indexed ones, instead of indexing them")
+	flag.Parse()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := run(ctx, env, checkpoint, int32(batchSize), dryRun); err != nil { //nolint: gosec
+		log.Fatalf("Couldn't run: %s", err) //nolint: gocritic
+	}
+}
+
+// run indexes all the tasks stored in PostgreSQL into Elasticsearch. The ID of the last indexed task is saved in
+// the checkpoint file after each batch, running again resumes after it; indexed tasks not stored anymore are deleted.
+// In dry-run mode the tasks that differ from the indexed ones are printed as JSON, and the checkpoint is not used.
+func run(ctx context.Context, env, checkpoint string, batchSize int32, dryRun bool) error {
+	if err := envvar.Load(env); err != nil {
+		return internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "envvar.Load")
+	}
+
+	vault, err := internal.NewVaultProvider()
+	if err != nil {
+		return internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "internal.NewVaultProvider")
+	}
+
+	conf := envvar.New(vault)
+
+	pool, err := internal.NewPostgreSQL(conf)
+	if err != nil {
+		return internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "internal.NewPostgreSQL")
+	}
+	defer pool.Close()
+
+	esClient, err := internal.NewElasticSearch(conf)
+	if err != nil {
+		return internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "internal.NewElasticSearch")
+	}
+
+	reindexer := service.NewReindexer(postgresql.NewTask(pool), elasticsearch.NewTask(esClient))
+
+	total, err := reindexer.Count(ctx)
+	if err != nil {
+		return internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "reindexer.Count")
+	}
+
+	params := service.ReindexParams{
+		BatchSize: batchSize,
+		DryRun:    dryRun,
+	}
+
+	if !dryRun {
+		if params.After, err = readCheckpoint(checkpoint); err != nil {
+			return err
+		}
+
+		if params.After != "" {
+			log.Printf("Resuming after %s", params.After)
+		}
+	}
+
+	var processed, orphaned, differences int
+
+	enc := json.NewEncoder(os.Stdout)
+
+	if err := reindexer.Reindex(ctx, params, func(batch service.ReindexBatch) error {
+		processed += batch.Tasks
+		orphaned += batch.Orphaned
+		differences += len(batch.Differences)
+
+		for _, diff := range batch.Differences {
+			if err := enc.Encode(difference(diff)); err != nil {
+				return internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "json.Encode")
+			}
+		}
+
+		if !dryRun {
+			if err := os.WriteFile(checkpoint, []byte(batch.LastID), 0o600); err != nil {
+				return internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "os.WriteFile")
+			}
+		}
+
+		log.Printf("Processed %d/%d tasks, last %s", processed, total, batch.LastID)
+
+		return ctx.Err() //nolint: wrapcheck
+	}); err != nil {
+		return internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "reindexer.Reindex")
+	}
+
+	if dryRun {
+		log.Printf("Found %d different tasks", differences)
+
+		return nil
+	}
+
+	if err := os.Remove(checkpoint); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "os.Remove")
+	}
+
+	log.Printf("Completed, %d tasks indexed, %d orphaned tasks deleted", processed, orphaned)
+
+	return nil
+}
+
+// readCheckpoint returns the ID of the last indexed task, an empty value indicates reindexing starts from the
+// beginning.
+func readCheckpoint(name string) (string, error) {
+	res, err := os.ReadFile(name)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return "", nil
+		}
+
+		return "", internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "os.ReadFile")
+	}
+
+	return strings.TrimSpace(string(res)), nil
+}
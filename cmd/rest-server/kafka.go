@@ -3,6 +3,10 @@
 package main
 
 import (
+	"time"
+
+	"go.uber.org/zap"
+
 	cmdinternal "github.com/MarioCarrion/todo-api-microservice-example/cmd/internal"
 	"github.com/MarioCarrion/todo-api-microservice-example/internal"
 	"github.com/MarioCarrion/todo-api-microservice-example/internal/envvar"
@@ -10,22 +14,38 @@ import (
 	"github.com/MarioCarrion/todo-api-microservice-example/internal/service"
 )
 
+const kafkaFlushTimeout = 5 * time.Second
+
 // KafkaMessageBroker represents Kafka as a Message Broker.
 type KafkaMessageBroker struct {
-	producer  *cmdinternal.KafkaProducer
-	publisher service.TaskMessageBrokerPublisher
+	producer    *cmdinternal.KafkaProducer
+	publisher   service.TaskMessageBrokerPublisher
+	reportsDone chan struct{}
 }
 
-// NewMessageBrokerPublisher initializes a new Kafka Broker.
-func NewMessageBrokerPublisher(conf *envvar.Configuration) (MessageBrokerPublisher, error) { //nolint: ireturn
+// NewMessageBrokerPublisher initializes a new Kafka Broker, failed deliveries are logged.
+func NewMessageBrokerPublisher(logger *zap.Logger, conf *envvar.Configuration) (MessageBrokerPublisher, error) { //nolint: ireturn
+	contentType, err := cmdinternal.NewEventsContentType(conf)
+	if err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "internal.NewEventsContentType")
+	}
+
 	producer, err := cmdinternal.NewKafkaProducer(conf)
 	if err != nil {
 		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "internal.NewKafkaProducer")
 	}
 
+	reportsDone := make(chan struct{})
+
+	go func() {
+		kafka.NewDeliveryReports(logger).Run(producer.Producer.Events())
+		close(reportsDone)
+	}()
+
 	return &KafkaMessageBroker{
-		producer:  producer,
-		publisher: kafka.NewTask(producer.Producer, producer.Topic),
+		producer:    producer,
+		publisher:   kafka.NewTask(producer.Producer, producer.Topic, contentType),
+		reportsDone: reportsDone,
 	}, nil
 }
 
@@ -36,7 +56,13 @@ func (m *KafkaMessageBroker) Publisher() service.TaskMessageBrokerPublisher { //
 
 // Close closes the broker.
 func (m *KafkaMessageBroker) Close() error {
-	m.producer.Producer.Close()
+	err := m.producer.Close(kafkaFlushTimeout)
+
+	<-m.reportsDone
+
+	if err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "producer.Close")
+	}
 
 	return nil
 }
@@ -13,16 +13,14 @@ import (
 	"syscall"
 	"time"
 
-	"github.com/bradfitz/gomemcache/memcache"
 	"github.com/didip/tollbooth/v6"
 	"github.com/didip/tollbooth/v6/limiter"
-	esv7 "github.com/elastic/go-elasticsearch/v7"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"go.uber.org/zap"
 
 	"github.com/MarioCarrion/todo-api-microservice-example/cmd/internal"
 	internaldomain "github.com/MarioCarrion/todo-api-microservice-example/internal"
-	"github.com/MarioCarrion/todo-api-microservice-example/internal/elasticsearch"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/cache"
 	"github.com/MarioCarrion/todo-api-microservice-example/internal/envvar"
 	"github.com/MarioCarrion/todo-api-microservice-example/internal/memcached"
 	"github.com/MarioCarrion/todo-api-microservice-example/internal/postgresql"
@@ -80,17 +78,22 @@ func run(env, address string) (<-chan error, error) {
 		return nil, internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "internal.NewPostgreSQL")
 	}
 
-	esClient, err := internal.NewElasticSearch(conf)
+	cache, err := internal.NewCache(conf)
 	if err != nil {
-		return nil, internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "internal.NewElasticSearch")
+		return nil, internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "internal.NewCache")
 	}
 
-	memcached, err := internal.NewMemcached(conf)
+	search, err := NewTaskSearchRepositories(conf, pool, cache)
 	if err != nil {
-		return nil, internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "internal.NewMemcached")
+		return nil, internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "NewTaskSearchRepositories")
 	}
 
-	msgBroker, err := NewMessageBrokerPublisher(conf)
+	circuitBreaker, err := NewSearchCircuitBreakerConfig(conf)
+	if err != nil {
+		return nil, internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "NewSearchCircuitBreakerConfig")
+	}
+
+	msgBroker, err := NewMessageBrokerPublisher(logger, conf)
 	if err != nil {
 		return nil, internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "NewMessageBroker")
 	}
@@ -111,15 +114,17 @@ func run(env, address string) (<-chan error, error) {
 	//-
 
 	srv := newServer(serverConfig{
-		Address:       address,
-		DB:            pool,
-		ElasticSearch: esClient,
-		Middlewares:   []rest.MiddlewareFunc{logging},
-		Logger:        logger,
-		Memcached:     memcached,
-		MessageBroker: msgBroker,
+		Address:        address,
+		DB:             pool,
+		Search:         search,
+		CircuitBreaker: circuitBreaker,
+		Middlewares:    []rest.MiddlewareFunc{logging},
+		Logger:         logger,
+		Cache:          cache,
 	})
 
+	relay := service.NewOutboxRelay(logger, postgresql.NewOutbox(pool), msgBroker.Publisher())
+
 	errC := make(chan error, 1)
 
 	ctx, stop := signal.NotifyContext(context.Background(),
@@ -127,6 +132,15 @@ func run(env, address string) (<-chan error, error) {
 		syscall.SIGTERM,
 		syscall.SIGQUIT)
 
+	relayDone := make(chan struct{})
+
+	go func() {
+		logger.Info("Relaying outbox events")
+
+		relay.Run(ctx)
+		close(relayDone)
+	}()
+
 	go func() {
 		<-ctx.Done()
 
@@ -137,6 +151,8 @@ func run(env, address string) (<-chan error, error) {
 		defer func() {
 			_ = logger.Sync()
 
+			<-relayDone
+
 			pool.Close()
 			srv.Close()
 			_ = msgBroker.Close()
@@ -169,53 +185,66 @@ func run(env, address string) (<-chan error, error) {
 }
 
 type serverConfig struct {
-	Address       string
-	DB            *pgxpool.Pool
-	ElasticSearch *esv7.Client
-	Memcached     *memcache.Client
-	Middlewares   []rest.MiddlewareFunc
-	Logger        *zap.Logger
-	MessageBroker MessageBrokerPublisher
+	Address        string
+	DB             *pgxpool.Pool
+	Search         []service.TaskSearchRepository
+	CircuitBreaker service.CircuitBreakerConfig
+	Cache          cache.Cache
+	Middlewares    []rest.MiddlewareFunc
+	Logger         *zap.Logger
 }
 
 func newServer(conf serverConfig) *http.Server {
 	repo := postgresql.NewTask(conf.DB)
-	mrepo := memcached.NewTask(conf.Memcached, repo, conf.Logger)
+	mrepo := memcached.NewTask(conf.Cache, repo, conf.Logger)
 
-	search := elasticsearch.NewTask(conf.ElasticSearch)
-	msearch := memcached.NewSearchableTask(conf.Memcached, search)
+	svc := service.NewTask(conf.Logger, mrepo, conf.CircuitBreaker, conf.Search...)
 
-	svc := service.NewTask(conf.Logger, mrepo, msearch, conf.MessageBroker.Publisher())
-
-	taskHandler := rest.NewTaskHandler(svc)
+	taskHandler := rest.NewTaskHandler(svc, conf.Logger)
 
 	router := http.NewServeMux()
 
 	fsys, _ := fs.Sub(content, "static")
 	router.Handle("GET /static/", http.StripPrefix("/static/", http.FileServer(http.FS(fsys))))
 
-	strictHandler := rest.NewStrictHandler(taskHandler, nil)
-
-	options := rest.StdHTTPServerOptions{
-		BaseRouter:  router,
-		Middlewares: conf.Middlewares,
-		ErrorHandlerFunc: func(w http.ResponseWriter, _ *http.Request, err error) {
-			switch {
-			case errors.Is(err, context.Canceled):
-				// Client canceled the request; treat as a bad request from the client's perspective.
-				http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
-			case errors.Is(err, context.DeadlineExceeded):
-				// Request timed out; indicate a gateway timeout.
-				http.Error(w, http.StatusText(http.StatusGatewayTimeout), http.StatusGatewayTimeout)
-			default:
-				// Log internal error details but do not expose them to the client.
-				if conf.Logger != nil {
-					conf.Logger.Error("request failed", zap.Error(err))
-				}
-
-				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+	errorHandler := func(w http.ResponseWriter, r *http.Request, err error) {
+		var (
+			formatErr   *rest.InvalidParamFormatError
+			requiredErr *rest.RequiredParamError
+		)
+
+		switch {
+		case errors.As(err, &formatErr), errors.As(err, &requiredErr):
+			// Invalid parameters, for example a malformed UUID, are caused by the client.
+			rest.WriteProblem(w, r, http.StatusBadRequest, err.Error())
+		case errors.Is(err, context.Canceled):
+			// Client canceled the request; treat as a bad request from the client's perspective.
+			rest.WriteProblem(w, r, http.StatusBadRequest, "")
+		case errors.Is(err, context.DeadlineExceeded):
+			// Request timed out; indicate a gateway timeout.
+			rest.WriteProblem(w, r, http.StatusGatewayTimeout, "")
+		default:
+			// Log internal error details but do not expose them to the client.
+			if conf.Logger != nil {
+				conf.Logger.Error("request failed", zap.Error(err))
 			}
+
+			rest.WriteProblem(w, r, http.StatusInternalServerError, "")
+		}
+	}
+
+	strictHandler := rest.NewStrictHandlerWithOptions(taskHandler, nil, rest.StrictHTTPServerOptions{
+		RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			// Decoding errors are caused by the client, those details are safe to expose.
+			rest.WriteProblem(w, r, http.StatusBadRequest, err.Error())
 		},
+		ResponseErrorHandlerFunc: errorHandler,
+	})
+
+	options := rest.StdHTTPServerOptions{
+		BaseRouter:       router,
+		Middlewares:      conf.Middlewares,
+		ErrorHandlerFunc: errorHandler,
 	}
 
 	handler := rest.HandlerWithOptions(strictHandler, options)
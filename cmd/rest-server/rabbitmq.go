@@ -3,6 +3,8 @@
 package main
 
 import (
+	"go.uber.org/zap"
+
 	cmdinternal "github.com/MarioCarrion/todo-api-microservice-example/cmd/internal"
 	"github.com/MarioCarrion/todo-api-microservice-example/internal"
 	"github.com/MarioCarrion/todo-api-microservice-example/internal/envvar"
@@ -17,7 +19,12 @@ type RabbitMQMessageBroker struct {
 }
 
 // NewMessageBrokerPublisher initializes a new RabbitMQ Broker.
-func NewMessageBrokerPublisher(conf *envvar.Configuration) (MessageBrokerPublisher, error) { //nolint: ireturn
+func NewMessageBrokerPublisher(_ *zap.Logger, conf *envvar.Configuration) (MessageBrokerPublisher, error) { //nolint: ireturn
+	contentType, err := cmdinternal.NewEventsContentType(conf)
+	if err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "internal.NewEventsContentType")
+	}
+
 	client, err := cmdinternal.NewRabbitMQ(conf)
 	if err != nil {
 		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "internal.NewRabbitMQ")
@@ -25,7 +32,7 @@ func NewMessageBrokerPublisher(conf *envvar.Configuration) (MessageBrokerPublish
 
 	return &RabbitMQMessageBroker{
 		producer:  client,
-		publisher: rabbitmq.NewTask(client.Channel),
+		publisher: rabbitmq.NewTask(client.Channel, contentType),
 	}, nil
 }
 
@@ -4,6 +4,7 @@ package main
 
 import (
 	"github.com/go-redis/redis/v8"
+	"go.uber.org/zap"
 
 	cmdinternal "github.com/MarioCarrion/todo-api-microservice-example/cmd/internal"
 	"github.com/MarioCarrion/todo-api-microservice-example/internal"
@@ -19,15 +20,32 @@ type RedisMessageBroker struct {
 }
 
 // NewMessageBrokerPublisher initializes a new Redis Broker.
-func NewMessageBrokerPublisher(conf *envvar.Configuration) (MessageBrokerPublisher, error) { //nolint: nolintlint,ireturn
+//
+//nolint:nolintlint,ireturn
+func NewMessageBrokerPublisher(_ *zap.Logger, conf *envvar.Configuration) (MessageBrokerPublisher, error) {
+	transport, err := cmdinternal.NewRedisTransport(conf)
+	if err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "internal.NewRedisTransport")
+	}
+
+	contentType, err := cmdinternal.NewEventsContentType(conf)
+	if err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "internal.NewEventsContentType")
+	}
+
 	producer, err := cmdinternal.NewRedis(conf)
 	if err != nil {
 		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "internal.NewRedis")
 	}
 
+	var publisher service.TaskMessageBrokerPublisher = internalredis.NewStreamTask(producer, internalredis.TaskStream, contentType)
+	if transport == cmdinternal.RedisTransportPubSub {
+		publisher = internalredis.NewTask(producer, contentType)
+	}
+
 	return &RedisMessageBroker{
 		client:    producer,
-		publisher: internalredis.NewTask(producer),
+		publisher: publisher,
 	}, nil
 }
 
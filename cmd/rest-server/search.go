@@ -0,0 +1,104 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/MarioCarrion/todo-api-microservice-example/cmd/internal"
+	internaldomain "github.com/MarioCarrion/todo-api-microservice-example/internal"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/cache"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/elasticsearch"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/envvar"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/memcached"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/postgresql"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/service"
+)
+
+// NewTaskSearchRepositories initializes the repositories used for searching tasks, using configuration defined in
+// environment variables; the values are comma separated and used in order, the next one is used when the previous one
+// is unavailable. Elasticsearch is used by default.
+func NewTaskSearchRepositories(conf *envvar.Configuration,
+	pool *pgxpool.Pool,
+	cache cache.Cache,
+) ([]service.TaskSearchRepository, error) {
+	value, err := conf.Get("SEARCH_REPOSITORY")
+	if err != nil {
+		return nil, internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "conf.Get SEARCH_REPOSITORY")
+	}
+
+	if value == "" {
+		value = "elasticsearch"
+	}
+
+	var res []service.TaskSearchRepository
+
+	for repo := range strings.SplitSeq(value, ",") {
+		switch strings.TrimSpace(repo) {
+		case "elasticsearch":
+			esClient, err := internal.NewElasticSearch(conf)
+			if err != nil {
+				return nil, internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "internal.NewElasticSearch")
+			}
+
+			res = append(res, memcached.NewSearchableTask(cache, elasticsearch.NewTask(esClient)))
+		case "postgresql":
+			// Results are not cached, tasks are searched where they are stored so they are never stale.
+			res = append(res, postgresql.NewTask(pool))
+		default:
+			return nil, internaldomain.NewErrorf(internaldomain.ErrorCodeInvalidArgument, "invalid SEARCH_REPOSITORY %q", repo)
+		}
+	}
+
+	return res, nil
+}
+
+// NewSearchCircuitBreakerConfig returns the circuit breaker configuration used by each search repository, using
+// configuration defined in environment variables; missing values use the defaults.
+func NewSearchCircuitBreakerConfig(conf *envvar.Configuration) (service.CircuitBreakerConfig, error) {
+	res := service.DefaultCircuitBreakerConfig()
+
+	get := func(key string) (string, error) {
+		value, err := conf.Get(key)
+		if err != nil {
+			return "", internaldomain.WrapErrorf(err, internaldomain.ErrorCodeUnknown, "conf.Get %s", key)
+		}
+
+		return value, nil
+	}
+
+	timeout, err := get("SEARCH_CIRCUIT_BREAKER_OPEN_TIMEOUT")
+	if err != nil {
+		return service.CircuitBreakerConfig{}, err
+	}
+
+	if timeout != "" {
+		if res.OpenTimeout, err = time.ParseDuration(timeout); err != nil || res.OpenTimeout <= 0 {
+			return service.CircuitBreakerConfig{},
+				internaldomain.NewErrorf(internaldomain.ErrorCodeInvalidArgument, "invalid SEARCH_CIRCUIT_BREAKER_OPEN_TIMEOUT %q", timeout)
+		}
+	}
+
+	for key, dst := range map[string]*int64{
+		"SEARCH_CIRCUIT_BREAKER_FAILURES":            &res.ConsecutiveFailures,
+		"SEARCH_CIRCUIT_BREAKER_HALF_OPEN_SUCCESSES": &res.HalfOpenMaxSuccesses,
+	} {
+		value, err := get(key)
+		if err != nil {
+			return service.CircuitBreakerConfig{}, err
+		}
+
+		if value == "" {
+			continue
+		}
+
+		if *dst, err = strconv.ParseInt(value, 10, 64); err != nil || *dst < 1 {
+			return service.CircuitBreakerConfig{},
+				internaldomain.NewErrorf(internaldomain.ErrorCodeInvalidArgument, "invalid %s %q", key, value)
+		}
+	}
+
+	return res, nil
+}
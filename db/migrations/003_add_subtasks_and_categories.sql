@@ -0,0 +1,28 @@
+ALTER TABLE tasks
+    ADD COLUMN parent_id UUID REFERENCES tasks (id) ON DELETE CASCADE,
+    ADD COLUMN position  INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX tasks_parent_id_idx ON tasks (parent_id);
+
+CREATE TABLE categories (
+  id   UUID DEFAULT gen_random_uuid() PRIMARY KEY,
+  name VARCHAR NOT NULL UNIQUE
+);
+
+CREATE TABLE tasks_categories (
+  task_id     UUID NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+  category_id UUID NOT NULL REFERENCES categories (id) ON DELETE CASCADE,
+  PRIMARY KEY (task_id, category_id)
+);
+
+---- create above / drop below ----
+
+DROP TABLE tasks_categories;
+
+DROP TABLE categories;
+
+DROP INDEX tasks_parent_id_idx;
+
+ALTER TABLE tasks
+    DROP COLUMN position,
+    DROP COLUMN parent_id;
@@ -0,0 +1,7 @@
+ALTER TABLE tasks
+    ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
+
+---- create above / drop below ----
+
+ALTER TABLE tasks
+    DROP COLUMN version;
@@ -0,0 +1,27 @@
+CREATE TABLE outbox (
+  id              UUID DEFAULT gen_random_uuid() PRIMARY KEY,
+  event_type      VARCHAR NOT NULL,
+  task_id         UUID NOT NULL,
+  payload         JSONB NOT NULL,
+  attempts        INTEGER NOT NULL DEFAULT 0,
+  created_at      TIMESTAMP NOT NULL DEFAULT clock_timestamp(),
+  next_attempt_at TIMESTAMP NOT NULL DEFAULT NOW(),
+  delivered_at    TIMESTAMP,
+  dead_at         TIMESTAMP
+);
+
+CREATE INDEX outbox_pending_idx ON outbox (next_attempt_at) WHERE delivered_at IS NULL AND dead_at IS NULL;
+
+CREATE INDEX outbox_task_pending_idx ON outbox (task_id, created_at) WHERE delivered_at IS NULL AND dead_at IS NULL;
+
+CREATE INDEX outbox_delivered_idx ON outbox (delivered_at) WHERE delivered_at IS NOT NULL;
+
+---- create above / drop below ----
+
+DROP INDEX outbox_delivered_idx;
+
+DROP INDEX outbox_task_pending_idx;
+
+DROP INDEX outbox_pending_idx;
+
+DROP TABLE outbox;
@@ -0,0 +1,23 @@
+CREATE TABLE processed_events (
+  consumer     VARCHAR NOT NULL,
+  event_id     VARCHAR NOT NULL,
+  processed_at TIMESTAMP NOT NULL DEFAULT NOW(),
+  PRIMARY KEY (consumer, event_id)
+);
+
+CREATE INDEX processed_events_processed_at_idx ON processed_events (consumer, processed_at);
+
+CREATE TABLE processed_task_versions (
+  consumer VARCHAR NOT NULL,
+  task_id  VARCHAR NOT NULL,
+  version  BIGINT NOT NULL,
+  PRIMARY KEY (consumer, task_id)
+);
+
+---- create above / drop below ----
+
+DROP TABLE processed_task_versions;
+
+DROP INDEX processed_events_processed_at_idx;
+
+DROP TABLE processed_events;
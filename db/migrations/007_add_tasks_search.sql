@@ -0,0 +1,11 @@
+ALTER TABLE tasks
+    ADD COLUMN search TSVECTOR GENERATED ALWAYS AS (to_tsvector('english', description)) STORED;
+
+CREATE INDEX tasks_search_idx ON tasks USING GIN (search);
+
+---- create above / drop below ----
+
+DROP INDEX tasks_search_idx;
+
+ALTER TABLE tasks
+    DROP COLUMN search;
@@ -0,0 +1,25 @@
+// Package cache defines the datastore used for caching values, and implements it in memory.
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+//go:generate counterfeiter -generate
+
+//counterfeiter:generate -o cachetesting/cache.gen.go . Cache
+
+// Cache defines the datastore used for caching values, missing keys fail with the code internal.ErrorCodeNotFound.
+type Cache interface {
+	// Get returns the value stored in key.
+	Get(ctx context.Context, key string) ([]byte, error)
+	// Set stores the value in key, it expires after expiration.
+	Set(ctx context.Context, key string, value []byte, expiration time.Duration) error
+	// Add stores the value in key without expiring, existing keys fail with the code internal.ErrorCodeConflict.
+	Add(ctx context.Context, key string, value []byte) error
+	// Increment increases the number stored in key by delta, returning the new value.
+	Increment(ctx context.Context, key string, delta uint64) (uint64, error)
+	// Delete deletes the value stored in key, missing keys are not an error.
+	Delete(ctx context.Context, key string) error
+}
@@ -0,0 +1,148 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/MarioCarrion/todo-api-microservice-example/internal"
+)
+
+// LRU implements Cache in memory, keeping up to size values; the least recently used value is evicted when adding a
+// new one. Values are not shared between processes.
+type LRU struct {
+	mu     sync.Mutex
+	size   int
+	values map[string]*list.Element
+	order  *list.List
+}
+
+type lruValue struct {
+	key        string
+	value      []byte
+	expiration time.Time
+}
+
+// NewLRU instantiates the in-memory Cache keeping up to size values.
+func NewLRU(size int) *LRU {
+	return &LRU{
+		size:   size,
+		values: make(map[string]*list.Element),
+		order:  list.New(),
+	}
+}
+
+// Get returns the value stored in key.
+func (l *LRU) Get(_ context.Context, key string) ([]byte, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	value, ok := l.get(key)
+	if !ok {
+		return nil, internal.NewErrorf(internal.ErrorCodeNotFound, "not found")
+	}
+
+	return append([]byte(nil), value.value...), nil
+}
+
+// Set stores the value in key, it expires after expiration.
+func (l *LRU) Set(_ context.Context, key string, value []byte, expiration time.Duration) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.set(key, value, time.Now().Add(expiration))
+
+	return nil
+}
+
+// Add stores the value in key without expiring, unless it already exists.
+func (l *LRU) Add(_ context.Context, key string, value []byte) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, ok := l.get(key); ok {
+		return internal.NewErrorf(internal.ErrorCodeConflict, "already exists")
+	}
+
+	l.set(key, value, time.Time{})
+
+	return nil
+}
+
+// Increment increases the number stored in key by delta.
+func (l *LRU) Increment(_ context.Context, key string, delta uint64) (uint64, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	value, ok := l.get(key)
+	if !ok {
+		return 0, internal.NewErrorf(internal.ErrorCodeNotFound, "not found")
+	}
+
+	res, err := strconv.ParseUint(string(value.value), 10, 64)
+	if err != nil {
+		return 0, internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "strconv.ParseUint")
+	}
+
+	res += delta
+
+	value.value = []byte(strconv.FormatUint(res, 10))
+
+	return res, nil
+}
+
+// Delete deletes the value stored in key.
+func (l *LRU) Delete(_ context.Context, key string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if elem, ok := l.values[key]; ok {
+		l.remove(elem)
+	}
+
+	return nil
+}
+
+// get returns the value stored in key, marking it as the most recently used one; expired values are deleted.
+func (l *LRU) get(key string) (*lruValue, bool) {
+	elem, ok := l.values[key]
+	if !ok {
+		return nil, false
+	}
+
+	value := elem.Value.(*lruValue) //nolint: forcetypeassert
+
+	if !value.expiration.IsZero() && !time.Now().Before(value.expiration) {
+		l.remove(elem)
+
+		return nil, false
+	}
+
+	l.order.MoveToFront(elem)
+
+	return value, true
+}
+
+func (l *LRU) set(key string, value []byte, expiration time.Time) {
+	value = append([]byte(nil), value...)
+
+	if elem, ok := l.values[key]; ok {
+		elem.Value = &lruValue{key: key, value: value, expiration: expiration}
+		l.order.MoveToFront(elem)
+
+		return
+	}
+
+	l.values[key] = l.order.PushFront(&lruValue{key: key, value: value, expiration: expiration})
+
+	for l.order.Len() > l.size {
+		l.remove(l.order.Back())
+	}
+}
+
+func (l *LRU) remove(elem *list.Element) {
+	l.order.Remove(elem)
+	delete(l.values, elem.Value.(*lruValue).key) //nolint: forcetypeassert
+}
@@ -0,0 +1,116 @@
+package cache_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/MarioCarrion/todo-api-microservice-example/internal"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/cache"
+)
+
+func TestLRU_All(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		verify func(t *testing.T, lru *cache.LRU)
+	}{
+		{
+			name: "Least recently used value is evicted",
+			verify: func(t *testing.T, lru *cache.LRU) {
+				t.Helper()
+
+				for _, key := range []string{"a", "b"} {
+					if err := lru.Set(t.Context(), key, []byte(key), time.Minute); err != nil {
+						t.Fatalf("Failed to set value: %v", err)
+					}
+				}
+
+				// "a" is used so "b" is evicted instead
+				if _, err := lru.Get(t.Context(), "a"); err != nil {
+					t.Fatalf("Failed to get value: %v", err)
+				}
+
+				if err := lru.Set(t.Context(), "c", []byte("c"), time.Minute); err != nil {
+					t.Fatalf("Failed to set value: %v", err)
+				}
+
+				assertCode(t, getErr(t, lru, "b"), internal.ErrorCodeNotFound)
+
+				for _, key := range []string{"a", "c"} {
+					if err := getErr(t, lru, key); err != nil {
+						t.Fatalf("Failed to get %s: %v", key, err)
+					}
+				}
+			},
+		},
+		{
+			name: "Expired values are missing",
+			verify: func(t *testing.T, lru *cache.LRU) {
+				t.Helper()
+
+				if err := lru.Set(t.Context(), "a", []byte("a"), -time.Second); err != nil {
+					t.Fatalf("Failed to set value: %v", err)
+				}
+
+				assertCode(t, getErr(t, lru, "a"), internal.ErrorCodeNotFound)
+			},
+		},
+		{
+			name: "Add and Increment",
+			verify: func(t *testing.T, lru *cache.LRU) {
+				t.Helper()
+
+				_, err := lru.Increment(t.Context(), "a", 1)
+				assertCode(t, err, internal.ErrorCodeNotFound)
+
+				if err := lru.Add(t.Context(), "a", []byte("1")); err != nil {
+					t.Fatalf("Failed to add value: %v", err)
+				}
+
+				assertCode(t, lru.Add(t.Context(), "a", []byte("2")), internal.ErrorCodeConflict)
+
+				res, err := lru.Increment(t.Context(), "a", 2)
+				if err != nil {
+					t.Fatalf("Failed to increment value: %v", err)
+				}
+
+				if res != 3 {
+					t.Fatalf("Expected 3, got %d", res)
+				}
+
+				if err := lru.Delete(t.Context(), "a"); err != nil {
+					t.Fatalf("Failed to delete value: %v", err)
+				}
+
+				assertCode(t, getErr(t, lru, "a"), internal.ErrorCodeNotFound)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.verify(t, cache.NewLRU(2))
+		})
+	}
+}
+
+func getErr(t *testing.T, c cache.Cache, key string) error {
+	t.Helper()
+
+	_, err := c.Get(t.Context(), key)
+
+	return err //nolint: wrapcheck
+}
+
+func assertCode(t *testing.T, err error, code internal.ErrorCode) {
+	t.Helper()
+
+	var ierr *internal.Error
+	if !errors.As(err, &ierr) || ierr.Code() != code {
+		t.Fatalf("Expected error code %d, got %v", code, err)
+	}
+}
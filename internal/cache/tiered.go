@@ -0,0 +1,107 @@
+package cache
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/MarioCarrion/todo-api-microservice-example/internal"
+)
+
+// SharedKeyPrefix is the prefix of the keys Tiered never keeps in the local cache, meant for values that must be seen
+// by all processes as soon as they change, like the generations used for invalidating other keys.
+const SharedKeyPrefix = "cache:shared:"
+
+// Tiered implements Cache using a local cache, usually an LRU, in front of a remote one shared between processes.
+// Values are kept in the local cache for up to expiration, changes made by other processes are seen after that; keys
+// prefixed with SharedKeyPrefix always use the remote cache.
+type Tiered struct {
+	local      Cache
+	remote     Cache
+	expiration time.Duration
+}
+
+// NewTiered instantiates the Cache using local in front of remote, keeping local values for up to expiration.
+func NewTiered(local, remote Cache, expiration time.Duration) *Tiered {
+	return &Tiered{
+		local:      local,
+		remote:     remote,
+		expiration: expiration,
+	}
+}
+
+// Get returns the value stored in key, values missing in the local cache are copied from the remote one.
+func (t *Tiered) Get(ctx context.Context, key string) ([]byte, error) {
+	if isShared(key) {
+		value, err := t.remote.Get(ctx, key)
+		if err != nil {
+			return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "remote.Get")
+		}
+
+		return value, nil
+	}
+
+	if value, err := t.local.Get(ctx, key); err == nil {
+		return value, nil
+	}
+
+	value, err := t.remote.Get(ctx, key)
+	if err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "remote.Get")
+	}
+
+	_ = t.local.Set(ctx, key, value, t.expiration)
+
+	return value, nil
+}
+
+// Set stores the value in both caches, it expires after expiration.
+func (t *Tiered) Set(ctx context.Context, key string, value []byte, expiration time.Duration) error {
+	if err := t.remote.Set(ctx, key, value, expiration); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "remote.Set")
+	}
+
+	if !isShared(key) {
+		_ = t.local.Set(ctx, key, value, min(expiration, t.expiration))
+	}
+
+	return nil
+}
+
+// Add stores the value in the remote cache without expiring, unless it already exists.
+func (t *Tiered) Add(ctx context.Context, key string, value []byte) error {
+	if err := t.remote.Add(ctx, key, value); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "remote.Add")
+	}
+
+	_ = t.local.Delete(ctx, key)
+
+	return nil
+}
+
+// Increment increases the number stored in the remote cache by delta.
+func (t *Tiered) Increment(ctx context.Context, key string, delta uint64) (uint64, error) {
+	res, err := t.remote.Increment(ctx, key, delta)
+	if err != nil {
+		return 0, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "remote.Increment")
+	}
+
+	_ = t.local.Delete(ctx, key)
+
+	return res, nil
+}
+
+// Delete deletes the value stored in both caches.
+func (t *Tiered) Delete(ctx context.Context, key string) error {
+	_ = t.local.Delete(ctx, key)
+
+	if err := t.remote.Delete(ctx, key); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "remote.Delete")
+	}
+
+	return nil
+}
+
+func isShared(key string) bool {
+	return strings.HasPrefix(key, SharedKeyPrefix)
+}
@@ -0,0 +1,78 @@
+package cache_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/MarioCarrion/todo-api-microservice-example/internal"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/cache"
+)
+
+func TestTiered_All(t *testing.T) {
+	t.Parallel()
+
+	local := cache.NewLRU(10)
+	remote := cache.NewLRU(10)
+
+	tiered := cache.NewTiered(local, remote, time.Minute)
+
+	if err := remote.Set(t.Context(), "a", []byte("a"), time.Minute); err != nil {
+		t.Fatalf("Failed to set value: %v", err)
+	}
+
+	// Remote values are copied to the local cache
+	if err := getErr(t, tiered, "a"); err != nil {
+		t.Fatalf("Failed to get value: %v", err)
+	}
+
+	if err := getErr(t, local, "a"); err != nil {
+		t.Fatalf("Expected value copied to local cache: %v", err)
+	}
+
+	if err := tiered.Add(t.Context(), "generation", []byte("1")); err != nil {
+		t.Fatalf("Failed to add value: %v", err)
+	}
+
+	if err := getErr(t, tiered, "generation"); err != nil {
+		t.Fatalf("Failed to get value: %v", err)
+	}
+
+	// Incremented values are deleted from the local cache
+	if _, err := tiered.Increment(t.Context(), "generation", 1); err != nil {
+		t.Fatalf("Failed to increment value: %v", err)
+	}
+
+	value, err := tiered.Get(t.Context(), "generation")
+	if err != nil {
+		t.Fatalf("Failed to get value: %v", err)
+	}
+
+	if string(value) != "2" {
+		t.Fatalf("Expected 2, got %s", value)
+	}
+
+	// Shared values, incremented by other processes, are never copied to the local cache
+	shared := cache.SharedKeyPrefix + "generation"
+
+	if err := tiered.Add(t.Context(), shared, []byte("1")); err != nil {
+		t.Fatalf("Failed to add value: %v", err)
+	}
+
+	if _, err := remote.Increment(t.Context(), shared, 1); err != nil {
+		t.Fatalf("Failed to increment value: %v", err)
+	}
+
+	if value, err := tiered.Get(t.Context(), shared); err != nil || string(value) != "2" {
+		t.Fatalf("Expected 2, got %s: %v", value, err)
+	}
+
+	assertCode(t, getErr(t, local, shared), internal.ErrorCodeNotFound)
+
+	// Deleted values are deleted from both caches
+	if err := tiered.Delete(t.Context(), "a"); err != nil {
+		t.Fatalf("Failed to delete value: %v", err)
+	}
+
+	assertCode(t, getErr(t, local, "a"), internal.ErrorCodeNotFound)
+	assertCode(t, getErr(t, remote, "a"), internal.ErrorCodeNotFound)
+}
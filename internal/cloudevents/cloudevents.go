@@ -0,0 +1,268 @@
+// Package cloudevents implements the envelope, following the CloudEvents specification, used by all the Message
+// Brokers for publishing and consuming Task events.
+package cloudevents
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/MarioCarrion/todo-api-microservice-example/internal"
+)
+
+const (
+	// SpecVersion is the supported version of the CloudEvents specification.
+	SpecVersion = "1.0"
+
+	// SchemaVersion is the current version of the data schema, the data is the todo.v1 message matching the event
+	// type.
+	SchemaVersion = "2"
+
+	// schemaVersion1 is the previous version of the data schema, the data is the Task encoded as JSON, it is still
+	// supported to consume events published before upgrading.
+	schemaVersion1 = "1"
+
+	// Source identifies the context in which the events happen.
+	Source = "/todo-api/tasks"
+
+	// ContentTypeJSON is the content type used for JSON encoded data.
+	ContentTypeJSON = "application/json"
+
+	// ContentTypeProtobuf is the content type used for Protocol Buffers encoded data, the messages are defined in the
+	// todo.v1 package.
+	ContentTypeProtobuf = "application/protobuf"
+)
+
+// Context attribute names, see https://github.com/cloudevents/spec/blob/v1.0.2/cloudevents/spec.md.
+const (
+	AttributeID            = "id"
+	AttributeSource        = "source"
+	AttributeSpecVersion   = "specversion"
+	AttributeType          = "type"
+	AttributeTime          = "time"
+	AttributeSubject       = "subject"
+	AttributeSchemaVersion = "schemaversion" // Extension attribute.
+)
+
+// Envelope represents a Task event following the CloudEvents specification.
+type Envelope struct {
+	ID              string
+	Source          string
+	SpecVersion     string
+	Type            string
+	Time            time.Time
+	Subject         string
+	DataContentType string
+	SchemaVersion   string
+	Data            []byte
+}
+
+// New returns the envelope of the Task event, the data is the todo.v1 message matching the event type encoded using
+// the content type. The envelope ID is not the event ID, instead it is derived from the Task ID, version and event
+// type, this allows consumers to detect duplicated events because each change increases the version.
+func New(event internal.Event, contentType string) (Envelope, error) {
+	msg, err := newMessage(event)
+	if err != nil {
+		return Envelope{}, internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "newMessage")
+	}
+
+	var data []byte
+
+	switch contentType {
+	case ContentTypeJSON:
+		data, err = protojson.Marshal(msg)
+	case ContentTypeProtobuf:
+		data, err = proto.Marshal(msg)
+	default:
+		return Envelope{}, internal.NewErrorf(internal.ErrorCodeInvalidArgument, "unknown content type %q", contentType)
+	}
+
+	if err != nil {
+		return Envelope{}, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "marshal")
+	}
+
+	task := event.Task
+
+	return Envelope{
+		ID:              task.ID + "/" + strconv.FormatInt(task.Version, 10) + "/" + string(event.Type),
+		Source:          Source,
+		SpecVersion:     SpecVersion,
+		Type:            string(event.Type),
+		Time:            time.Now().UTC(),
+		Subject:         task.ID,
+		DataContentType: contentType,
+		SchemaVersion:   SchemaVersion,
+		Data:            data,
+	}, nil
+}
+
+// Event validates the envelope and decodes its data. Envelopes using unknown versions or content types are
+// rejected with internal.ErrorCodeInvalidArgument.
+func (e Envelope) Event() (internal.Event, error) {
+	if e.SpecVersion != SpecVersion {
+		return internal.Event{}, internal.NewErrorf(internal.ErrorCodeInvalidArgument,
+			"unknown spec version %q", e.SpecVersion)
+	}
+
+	if e.ID == "" || e.Type == "" {
+		return internal.Event{}, internal.NewErrorf(internal.ErrorCodeInvalidArgument, "id and type are required")
+	}
+
+	res := internal.Event{
+		ID:   e.ID,
+		Type: internal.EventType(e.Type),
+	}
+
+	switch e.SchemaVersion {
+	case schemaVersion1:
+		if e.DataContentType != ContentTypeJSON {
+			return internal.Event{}, internal.NewErrorf(internal.ErrorCodeInvalidArgument,
+				"unknown content type %q", e.DataContentType)
+		}
+
+		if err := json.Unmarshal(e.Data, &res.Task); err != nil {
+			return internal.Event{}, internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "json.Unmarshal")
+		}
+
+		return res, nil
+	case SchemaVersion:
+	default:
+		return internal.Event{}, internal.NewErrorf(internal.ErrorCodeInvalidArgument,
+			"unknown schema version %q", e.SchemaVersion)
+	}
+
+	msg, err := newEmptyMessage(res.Type)
+	if err != nil {
+		return internal.Event{}, internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "newEmptyMessage")
+	}
+
+	switch e.DataContentType {
+	case ContentTypeJSON:
+		// Unknown fields are discarded to support fields added by newer publishers.
+		err = protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(e.Data, msg)
+	case ContentTypeProtobuf:
+		err = proto.Unmarshal(e.Data, msg)
+	default:
+		return internal.Event{}, internal.NewErrorf(internal.ErrorCodeInvalidArgument,
+			"unknown content type %q", e.DataContentType)
+	}
+
+	if err != nil {
+		return internal.Event{}, internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "unmarshal")
+	}
+
+	res.Task, res.Changes = fromMessage(msg)
+
+	return res, nil
+}
+
+// Attributes returns the context attributes, except the content type, used by the binary content mode bindings.
+func (e Envelope) Attributes() map[string]string {
+	return map[string]string{
+		AttributeID:            e.ID,
+		AttributeSource:        e.Source,
+		AttributeSpecVersion:   e.SpecVersion,
+		AttributeType:          e.Type,
+		AttributeTime:          e.Time.Format(time.RFC3339Nano),
+		AttributeSubject:       e.Subject,
+		AttributeSchemaVersion: e.SchemaVersion,
+	}
+}
+
+// FromAttributes returns the envelope using the context attributes, content type and data received using a binary
+// content mode binding.
+func FromAttributes(attrs map[string]string, contentType string, data []byte) (Envelope, error) {
+	res := Envelope{
+		ID:              attrs[AttributeID],
+		Source:          attrs[AttributeSource],
+		SpecVersion:     attrs[AttributeSpecVersion],
+		Type:            attrs[AttributeType],
+		Subject:         attrs[AttributeSubject],
+		DataContentType: contentType,
+		SchemaVersion:   attrs[AttributeSchemaVersion],
+		Data:            data,
+	}
+
+	if val := attrs[AttributeTime]; val != "" {
+		t, err := time.Parse(time.RFC3339Nano, val)
+		if err != nil {
+			return Envelope{}, internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "time.Parse")
+		}
+
+		res.Time = t
+	}
+
+	return res, nil
+}
+
+//nolint:tagliatelle
+type structured struct {
+	ID              string          `json:"id"`
+	Source          string          `json:"source"`
+	SpecVersion     string          `json:"specversion"`
+	Type            string          `json:"type"`
+	Time            time.Time       `json:"time"`
+	Subject         string          `json:"subject"`
+	DataContentType string          `json:"datacontenttype"`
+	SchemaVersion   string          `json:"schemaversion"`
+	Data            json.RawMessage `json:"data,omitempty"`
+	DataBase64      []byte          `json:"data_base64,omitempty"`
+}
+
+// MarshalJSON encodes the envelope using the JSON structured content mode, JSON data is included as is and any
+// other content type is base64 encoded.
+func (e Envelope) MarshalJSON() ([]byte, error) {
+	res := structured{
+		ID:              e.ID,
+		Source:          e.Source,
+		SpecVersion:     e.SpecVersion,
+		Type:            e.Type,
+		Time:            e.Time,
+		Subject:         e.Subject,
+		DataContentType: e.DataContentType,
+		SchemaVersion:   e.SchemaVersion,
+	}
+
+	if e.DataContentType == ContentTypeJSON {
+		res.Data = e.Data
+	} else {
+		res.DataBase64 = e.Data
+	}
+
+	b, err := json.Marshal(res)
+	if err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "json.Marshal")
+	}
+
+	return b, nil
+}
+
+// UnmarshalJSON decodes the envelope using the JSON structured content mode.
+func (e *Envelope) UnmarshalJSON(b []byte) error {
+	var val structured
+
+	if err := json.Unmarshal(b, &val); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "json.Unmarshal")
+	}
+
+	*e = Envelope{
+		ID:              val.ID,
+		Source:          val.Source,
+		SpecVersion:     val.SpecVersion,
+		Type:            val.Type,
+		Time:            val.Time,
+		Subject:         val.Subject,
+		DataContentType: val.DataContentType,
+		SchemaVersion:   val.SchemaVersion,
+		Data:            val.Data,
+	}
+
+	if len(val.DataBase64) > 0 {
+		e.Data = val.DataBase64
+	}
+
+	return nil
+}
@@ -0,0 +1,294 @@
+package cloudevents_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/MarioCarrion/todo-api-microservice-example/internal"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/cloudevents"
+)
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	task := internal.Task{
+		ID:          "1c7f0a3e-a5b4-4c4f-9f0c-6a0a9b3f0e8d",
+		Description: "test",
+		Priority:    new(internal.PriorityNone),
+		Dates:       &internal.Dates{Start: &start},
+		SubTasks:    []internal.Task{{ID: "2", Priority: new(internal.PriorityHigh)}},
+		Categories:  []internal.Category{"home"},
+		Version:     3,
+	}
+
+	previous := task
+	previous.IsDone = true
+	previous.Dates = nil
+
+	changes := internal.NewTaskChanges(previous, task)
+
+	tests := []struct {
+		name        string
+		contentType string
+		event       internal.Event
+	}{
+		{
+			name:        "OK: json",
+			contentType: cloudevents.ContentTypeJSON,
+			event:       internal.Event{Type: internal.EventTypeTaskCreated, Task: task},
+		},
+		{
+			name:        "OK: json updated",
+			contentType: cloudevents.ContentTypeJSON,
+			event:       internal.Event{Type: internal.EventTypeTaskUpdated, Task: task, Changes: &changes},
+		},
+		{
+			name:        "OK: protobuf updated",
+			contentType: cloudevents.ContentTypeProtobuf,
+			event:       internal.Event{Type: internal.EventTypeTaskUpdated, Task: task, Changes: &changes},
+		},
+		{
+			name:        "OK: protobuf updated without changes",
+			contentType: cloudevents.ContentTypeProtobuf,
+			event:       internal.Event{Type: internal.EventTypeTaskUpdated, Task: task},
+		},
+		{
+			name:        "OK: protobuf deleted",
+			contentType: cloudevents.ContentTypeProtobuf,
+			event: internal.Event{
+				Type: internal.EventTypeTaskDeleted,
+				Task: internal.Task{ID: task.ID, Version: task.Version},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			envelope, err := cloudevents.New(tt.event, tt.contentType)
+			if err != nil {
+				t.Fatalf("expected no error, got %s", err)
+			}
+
+			if expected := task.ID + "/3/" + string(tt.event.Type); envelope.ID != expected {
+				t.Fatalf("expected id %s, got %s", expected, envelope.ID)
+			}
+
+			if envelope.Subject != task.ID || envelope.DataContentType != tt.contentType {
+				t.Fatalf("expected subject and content type do not match, got %+v", envelope)
+			}
+
+			actual, err := envelope.Event()
+			if err != nil {
+				t.Fatalf("expected no error, got %s", err)
+			}
+
+			expected := tt.event
+			expected.ID = envelope.ID
+
+			if diff := cmp.Diff(expected, actual); diff != "" {
+				t.Fatalf("expected event does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestNew_Error(t *testing.T) {
+	t.Parallel()
+
+	event := internal.Event{Type: internal.EventTypeTaskCreated}
+
+	if _, err := cloudevents.New(event, "application/xml"); err == nil {
+		t.Fatalf("expected error, got nil")
+	}
+
+	event.Type = "Task.Unknown"
+
+	if _, err := cloudevents.New(event, cloudevents.ContentTypeProtobuf); err == nil {
+		t.Fatalf("expected error, got nil")
+	}
+}
+
+func TestEnvelope_Event(t *testing.T) {
+	t.Parallel()
+
+	valid := func() cloudevents.Envelope {
+		envelope, _ := cloudevents.New(internal.Event{
+			Type: internal.EventTypeTaskCreated,
+			Task: internal.Task{ID: "1"},
+		}, cloudevents.ContentTypeJSON)
+
+		return envelope
+	}
+
+	tests := []struct {
+		name      string
+		setup     func(*cloudevents.Envelope)
+		expectErr bool
+	}{
+		{
+			name:  "OK",
+			setup: func(_ *cloudevents.Envelope) {},
+		},
+		{
+			name:      "ERR: spec version",
+			setup:     func(e *cloudevents.Envelope) { e.SpecVersion = "0.3" },
+			expectErr: true,
+		},
+		{
+			name:      "ERR: schema version",
+			setup:     func(e *cloudevents.Envelope) { e.SchemaVersion = "3" },
+			expectErr: true,
+		},
+		{
+			name: "OK: schema version 1",
+			setup: func(e *cloudevents.Envelope) {
+				e.SchemaVersion = "1"
+				e.Data = []byte(`{"ID":"1","Description":"test"}`)
+			},
+		},
+		{
+			name: "ERR: schema version 1 protobuf",
+			setup: func(e *cloudevents.Envelope) {
+				e.SchemaVersion = "1"
+				e.DataContentType = cloudevents.ContentTypeProtobuf
+			},
+			expectErr: true,
+		},
+		{
+			name:      "ERR: missing id",
+			setup:     func(e *cloudevents.Envelope) { e.ID = "" },
+			expectErr: true,
+		},
+		{
+			name:      "ERR: content type",
+			setup:     func(e *cloudevents.Envelope) { e.DataContentType = "application/xml" },
+			expectErr: true,
+		},
+		{
+			name: "ERR: protobuf type",
+			setup: func(e *cloudevents.Envelope) {
+				e.DataContentType = cloudevents.ContentTypeProtobuf
+				e.Type = "Task.Unknown"
+			},
+			expectErr: true,
+		},
+		{
+			name:      "ERR: data",
+			setup:     func(e *cloudevents.Envelope) { e.Data = []byte("{") },
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			envelope := valid()
+			tt.setup(&envelope)
+
+			_, err := envelope.Event()
+			if (err != nil) != tt.expectErr {
+				t.Fatalf("expected error %t, got %v", tt.expectErr, err)
+			}
+
+			var ierr *internal.Error
+			if err != nil && (!errors.As(err, &ierr) || ierr.Code() != internal.ErrorCodeInvalidArgument) {
+				t.Fatalf("expected invalid argument error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestEnvelope_Attributes(t *testing.T) {
+	t.Parallel()
+
+	expected, err := cloudevents.New(internal.Event{
+		Type: internal.EventTypeTaskDeleted,
+		Task: internal.Task{ID: "1", Version: 2},
+	}, cloudevents.ContentTypeJSON)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	actual, err := cloudevents.FromAttributes(expected.Attributes(), expected.DataContentType, expected.Data)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Fatalf("expected envelope does not match: %s", diff)
+	}
+
+	if _, err := cloudevents.FromAttributes(map[string]string{"time": "now"}, "", nil); err == nil {
+		t.Fatalf("expected error, got nil")
+	}
+}
+
+func TestEnvelope_JSON(t *testing.T) {
+	t.Parallel()
+
+	event := internal.Event{Type: internal.EventTypeTaskCreated, Task: internal.Task{ID: "1", Description: "test"}}
+
+	envelope, err := cloudevents.New(event, cloudevents.ContentTypeJSON)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	binary, err := cloudevents.New(event, cloudevents.ContentTypeProtobuf)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	for _, expected := range []cloudevents.Envelope{envelope, binary} {
+		b, err := json.Marshal(expected)
+		if err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+
+		var fields map[string]json.RawMessage
+
+		if err := json.Unmarshal(b, &fields); err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+
+		field := "data"
+		if expected.DataContentType != cloudevents.ContentTypeJSON {
+			field = "data_base64"
+		}
+
+		if _, ok := fields[field]; !ok {
+			t.Fatalf("expected %s field, got %s", field, b)
+		}
+
+		var actual cloudevents.Envelope
+
+		if err := json.Unmarshal(b, &actual); err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+
+		// JSON data is compacted when included as is.
+		if diff := cmp.Diff(expected, actual, cmpopts.IgnoreFields(cloudevents.Envelope{}, "Data")); diff != "" {
+			t.Fatalf("expected envelope does not match: %s", diff)
+		}
+
+		expectedEvent, _ := expected.Event()
+
+		actualEvent, err := actual.Event()
+		if err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+
+		if diff := cmp.Diff(expectedEvent, actualEvent); diff != "" {
+			t.Fatalf("expected event does not match: %s", diff)
+		}
+	}
+}
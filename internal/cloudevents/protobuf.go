@@ -0,0 +1,161 @@
+package cloudevents
+
+import (
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/MarioCarrion/todo-api-microservice-example/internal"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/protobuf/todov1"
+)
+
+var taskFields = map[internal.TaskField]todov1.TaskField{ //nolint: gochecknoglobals
+	internal.TaskFieldDescription: todov1.TaskField_TASK_FIELD_DESCRIPTION,
+	internal.TaskFieldPriority:    todov1.TaskField_TASK_FIELD_PRIORITY,
+	internal.TaskFieldIsDone:      todov1.TaskField_TASK_FIELD_IS_DONE,
+	internal.TaskFieldStartDate:   todov1.TaskField_TASK_FIELD_START_DATE,
+	internal.TaskFieldDueDate:     todov1.TaskField_TASK_FIELD_DUE_DATE,
+	internal.TaskFieldSubTasks:    todov1.TaskField_TASK_FIELD_SUB_TASKS,
+	internal.TaskFieldCategories:  todov1.TaskField_TASK_FIELD_CATEGORIES,
+}
+
+// newMessage returns the message used as the data of the event, the previous Task of Updated events is only
+// included when it is known.
+func newMessage(event internal.Event) (proto.Message, error) { //nolint: ireturn
+	switch event.Type {
+	case internal.EventTypeTaskCreated:
+		return &todov1.TaskCreated{Task: newProtobufTask(event.Task)}, nil
+	case internal.EventTypeTaskUpdated:
+		res := todov1.TaskUpdated{Task: newProtobufTask(event.Task)}
+
+		if changes := event.Changes; changes != nil {
+			if changes.Previous.ID != "" {
+				res.Previous = newProtobufTask(changes.Previous)
+			}
+
+			for _, field := range changes.Fields {
+				res.ChangedFields = append(res.ChangedFields, taskFields[field])
+			}
+		}
+
+		return &res, nil
+	case internal.EventTypeTaskDeleted:
+		return &todov1.TaskDeleted{Id: event.Task.ID, Version: event.Task.Version}, nil
+	}
+
+	return nil, internal.NewErrorf(internal.ErrorCodeInvalidArgument, "unknown event type %q", event.Type)
+}
+
+// newEmptyMessage returns the message used for decoding the data of the event type.
+func newEmptyMessage(typ internal.EventType) (proto.Message, error) { //nolint: ireturn
+	switch typ {
+	case internal.EventTypeTaskCreated:
+		return &todov1.TaskCreated{}, nil
+	case internal.EventTypeTaskUpdated:
+		return &todov1.TaskUpdated{}, nil
+	case internal.EventTypeTaskDeleted:
+		return &todov1.TaskDeleted{}, nil
+	}
+
+	return nil, internal.NewErrorf(internal.ErrorCodeInvalidArgument, "unknown event type %q", typ)
+}
+
+// fromMessage returns the Task and, for Updated events including the previous Task, its changes.
+func fromMessage(msg proto.Message) (internal.Task, *internal.TaskChanges) {
+	switch val := msg.(type) {
+	case *todov1.TaskCreated:
+		return newTask(val.GetTask()), nil
+	case *todov1.TaskUpdated:
+		if val.GetPrevious() == nil {
+			return newTask(val.GetTask()), nil
+		}
+
+		changes := internal.TaskChanges{
+			Previous: newTask(val.GetPrevious()),
+		}
+
+		for _, field := range val.GetChangedFields() {
+			for key, value := range taskFields {
+				if value == field {
+					changes.Fields = append(changes.Fields, key)
+				}
+			}
+		}
+
+		return newTask(val.GetTask()), &changes
+	case *todov1.TaskDeleted:
+		return internal.Task{ID: val.GetId(), Version: val.GetVersion()}, nil
+	}
+
+	return internal.Task{}, nil
+}
+
+func newProtobufTask(task internal.Task) *todov1.Task {
+	res := todov1.Task{
+		Id:          task.ID,
+		IsDone:      task.IsDone,
+		Description: task.Description,
+		Version:     task.Version,
+	}
+
+	if task.Priority != nil {
+		// Protocol Buffers enums reserve 0 for the unspecified value.
+		res.Priority = todov1.Priority(*task.Priority) + 1
+	}
+
+	if task.Dates != nil {
+		res.Dates = &todov1.Dates{}
+
+		if task.Dates.Start != nil {
+			res.Dates.Start = timestamppb.New(*task.Dates.Start)
+		}
+
+		if task.Dates.Due != nil {
+			res.Dates.Due = timestamppb.New(*task.Dates.Due)
+		}
+	}
+
+	for _, sub := range task.SubTasks {
+		res.SubTasks = append(res.SubTasks, newProtobufTask(sub))
+	}
+
+	for _, category := range task.Categories {
+		res.Categories = append(res.Categories, string(category))
+	}
+
+	return &res
+}
+
+func newTask(task *todov1.Task) internal.Task {
+	res := internal.Task{
+		ID:          task.GetId(),
+		IsDone:      task.GetIsDone(),
+		Description: task.GetDescription(),
+		Version:     task.GetVersion(),
+	}
+
+	if priority := task.GetPriority(); priority != todov1.Priority_PRIORITY_UNSPECIFIED {
+		res.Priority = new(internal.Priority(priority - 1))
+	}
+
+	if dates := task.GetDates(); dates != nil {
+		res.Dates = &internal.Dates{}
+
+		if start := dates.GetStart(); start != nil {
+			res.Dates.Start = new(start.AsTime())
+		}
+
+		if due := dates.GetDue(); due != nil {
+			res.Dates.Due = new(due.AsTime())
+		}
+	}
+
+	for _, sub := range task.GetSubTasks() {
+		res.SubTasks = append(res.SubTasks, newTask(sub))
+	}
+
+	for _, category := range task.GetCategories() {
+		res.Categories = append(res.Categories, internal.Category(category))
+	}
+
+	return res
+}
@@ -0,0 +1,208 @@
+// Package consumer implements the framework used for consuming Task events from a Message Broker.
+package consumer
+
+import (
+	"context"
+	"errors"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/MarioCarrion/todo-api-microservice-example/internal"
+)
+
+const (
+	maxAttempts    = 3
+	backoffMin     = 100 * time.Millisecond
+	backoffFactor  = 2
+	defaultWorkers = 1
+)
+
+//go:generate counterfeiter -generate
+
+//counterfeiter:generate -o consumertesting/delivery.gen.go . Delivery
+
+// Delivery represents a message received from a Message Broker.
+type Delivery interface {
+	// Event returns the decoded message, an error indicates the message is invalid.
+	Event() (internal.Event, error)
+	// Ack indicates the message was processed.
+	Ack(ctx context.Context) error
+	// Nack indicates the message couldn't be processed because of reason, what happens to it depends on the Message
+	// Broker.
+	Nack(ctx context.Context, reason error) error
+}
+
+//counterfeiter:generate -o consumertesting/source.gen.go . Source
+
+// Source defines the Message Broker adapter used to receive messages.
+type Source interface {
+	// Receive returns the channel used to receive messages, the channel is closed once the context is canceled and
+	// no more messages are going to be received.
+	Receive(ctx context.Context) (<-chan Delivery, error)
+}
+
+//counterfeiter:generate -o consumertesting/handler.gen.go . Handler
+
+// Handler defines the type processing the received events.
+type Handler interface {
+	Handle(ctx context.Context, event internal.Event) error
+}
+
+// HandlerFunc is an adapter to allow the use of ordinary functions as Handlers.
+type HandlerFunc func(ctx context.Context, event internal.Event) error
+
+// Handle calls f(ctx, event).
+func (f HandlerFunc) Handle(ctx context.Context, event internal.Event) error {
+	return f(ctx, event)
+}
+
+// Consumer receives messages from a Source and processes them using a pool of workers.
+//
+// Messages are acked after being handled successfully. Handler errors are retried using exponential backoff and
+// messages are nacked after the last attempt fails; invalid messages and errors with the code
+// internal.ErrorCodeInvalidArgument are nacked without being retried. Events of the same Task are always processed
+// by the same worker to keep them in order.
+type Consumer struct {
+	logger  *zap.Logger
+	source  Source
+	handler Handler
+	workers int
+}
+
+// NewConsumer instantiates the Consumer, workers indicates the number of messages processed concurrently.
+func NewConsumer(logger *zap.Logger, source Source, handler Handler, workers int) *Consumer {
+	if workers < 1 {
+		workers = defaultWorkers
+	}
+
+	return &Consumer{
+		logger:  logger,
+		source:  source,
+		handler: handler,
+		workers: workers,
+	}
+}
+
+// Run consumes messages until the context is canceled. Before returning, the messages already received are
+// processed.
+func (c *Consumer) Run(ctx context.Context) error {
+	deliveries, err := c.source.Receive(ctx)
+	if err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "source.Receive")
+	}
+
+	// In-flight messages are processed even after the context is canceled, to drain the workers gracefully.
+	workCtx := context.WithoutCancel(ctx)
+
+	var wg sync.WaitGroup
+
+	queues := make([]chan Delivery, c.workers)
+
+	for i := range queues {
+		queues[i] = make(chan Delivery)
+
+		wg.Go(func() {
+			for delivery := range queues[i] {
+				c.process(ctx, workCtx, delivery) //nolint: contextcheck
+			}
+		})
+	}
+
+	for delivery := range deliveries {
+		queues[c.worker(delivery)] <- delivery
+	}
+
+	for _, queue := range queues {
+		close(queue)
+	}
+
+	wg.Wait()
+
+	return nil
+}
+
+// worker returns the worker in charge of processing the delivery, based on the Task ID.
+func (c *Consumer) worker(delivery Delivery) int {
+	event, err := delivery.Event()
+	if err != nil {
+		return 0
+	}
+
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(event.Task.ID))
+
+	return int(h.Sum32() % uint32(c.workers)) //nolint: gosec
+}
+
+// process handles the delivery, runCtx is used to stop retrying once Run is asked to return.
+func (c *Consumer) process(runCtx, ctx context.Context, delivery Delivery) {
+	event, err := delivery.Event()
+	if err != nil {
+		c.logger.Warn("Nacking invalid message", zap.Error(err))
+		c.nack(ctx, delivery, err)
+
+		return
+	}
+
+	logger := c.logger.With(zap.String("type", string(event.Type)), zap.String("task", event.Task.ID))
+
+	if err := c.handle(runCtx, ctx, event); err != nil {
+		logger.Error("Nacking message", zap.Error(err))
+		c.nack(ctx, delivery, err)
+
+		return
+	}
+
+	if err := delivery.Ack(ctx); err != nil {
+		logger.Error("Couldn't ack message", zap.Error(err))
+
+		return
+	}
+
+	logger.Info("Consumed")
+}
+
+// handle calls the handler retrying failed attempts.
+func (c *Consumer) handle(runCtx, ctx context.Context, event internal.Event) error {
+	delay := backoffMin
+
+	for attempt := 1; ; attempt++ {
+		err := c.handler.Handle(ctx, event)
+		if err == nil {
+			return nil
+		}
+
+		if attempt == maxAttempts || !Retryable(err) {
+			return err
+		}
+
+		c.logger.Warn("Retrying message", zap.Int("attempt", attempt), zap.Duration("delay", delay), zap.Error(err))
+
+		select {
+		case <-runCtx.Done():
+			return err
+		case <-time.After(delay):
+		}
+
+		delay *= backoffFactor
+	}
+}
+
+// Retryable indicates whether the error is worth retrying, invalid arguments are going to fail again.
+func Retryable(err error) bool {
+	var ierr *internal.Error
+	if errors.As(err, &ierr) {
+		return ierr.Code() != internal.ErrorCodeInvalidArgument
+	}
+
+	return true
+}
+
+func (c *Consumer) nack(ctx context.Context, delivery Delivery, reason error) {
+	if err := delivery.Nack(ctx, reason); err != nil {
+		c.logger.Error("Couldn't nack message", zap.Error(err))
+	}
+}
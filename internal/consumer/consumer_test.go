@@ -0,0 +1,217 @@
+package consumer_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/MarioCarrion/todo-api-microservice-example/internal"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/consumer"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/consumer/consumertesting"
+)
+
+func TestConsumer_Run(t *testing.T) {
+	t.Parallel()
+
+	event := internal.Event{
+		Type: internal.EventTypeTaskCreated,
+		Task: internal.Task{ID: "1c7f0a3e-a5b4-4c4f-9f0c-6a0a9b3f0e8d"},
+	}
+
+	type counts struct {
+		handle, ack, nack int
+	}
+
+	tests := []struct {
+		name     string
+		setup    func(*consumertesting.FakeDelivery, *consumertesting.FakeHandler)
+		expected counts
+	}{
+		{
+			name: "OK",
+			setup: func(d *consumertesting.FakeDelivery, _ *consumertesting.FakeHandler) {
+				d.EventReturns(event, nil)
+			},
+			expected: counts{handle: 1, ack: 1},
+		},
+		{
+			name: "OK: retried",
+			setup: func(d *consumertesting.FakeDelivery, h *consumertesting.FakeHandler) {
+				d.EventReturns(event, nil)
+				h.HandleReturnsOnCall(0, errors.New("unavailable"))
+			},
+			expected: counts{handle: 2, ack: 1},
+		},
+		{
+			name: "ERR: invalid message",
+			setup: func(d *consumertesting.FakeDelivery, _ *consumertesting.FakeHandler) {
+				d.EventReturns(internal.Event{}, errors.New("invalid"))
+			},
+			expected: counts{nack: 1},
+		},
+		{
+			name: "ERR: attempts exhausted",
+			setup: func(d *consumertesting.FakeDelivery, h *consumertesting.FakeHandler) {
+				d.EventReturns(event, nil)
+				h.HandleReturns(errors.New("unavailable"))
+			},
+			expected: counts{handle: 3, nack: 1},
+		},
+		{
+			name: "ERR: not retryable",
+			setup: func(d *consumertesting.FakeDelivery, h *consumertesting.FakeHandler) {
+				d.EventReturns(event, nil)
+				h.HandleReturns(internal.NewErrorf(internal.ErrorCodeInvalidArgument, "unknown"))
+			},
+			expected: counts{handle: 1, nack: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			delivery := &consumertesting.FakeDelivery{}
+			handler := &consumertesting.FakeHandler{}
+			source := &consumertesting.FakeSource{}
+
+			tt.setup(delivery, handler)
+
+			deliveries := make(chan consumer.Delivery, 1)
+			deliveries <- delivery
+			close(deliveries)
+
+			source.ReceiveReturns(deliveries, nil)
+
+			if err := consumer.NewConsumer(zap.NewNop(), source, handler, 2).Run(t.Context()); err != nil {
+				t.Fatalf("expected no error, got %s", err)
+			}
+
+			actual := counts{
+				handle: handler.HandleCallCount(),
+				ack:    delivery.AckCallCount(),
+				nack:   delivery.NackCallCount(),
+			}
+
+			if actual != tt.expected {
+				t.Fatalf("expected %+v, got %+v", tt.expected, actual)
+			}
+
+			if actual.nack > 0 {
+				if _, reason := delivery.NackArgsForCall(0); reason == nil {
+					t.Fatalf("expected nack reason, got nil")
+				}
+			}
+		})
+	}
+}
+
+func TestConsumer_Run_Order(t *testing.T) {
+	t.Parallel()
+
+	ids := []string{"a", "b", "c"}
+
+	deliveries := make(chan consumer.Delivery, 30)
+
+	for i := range 30 {
+		delivery := &consumertesting.FakeDelivery{}
+		delivery.EventReturns(internal.Event{Task: internal.Task{ID: ids[i%len(ids)], Version: int64(i)}}, nil)
+
+		deliveries <- delivery
+	}
+
+	close(deliveries)
+
+	source := &consumertesting.FakeSource{}
+	source.ReceiveReturns(deliveries, nil)
+
+	var (
+		mu      sync.Mutex
+		handled = make(map[string][]int64)
+	)
+
+	handler := consumer.HandlerFunc(func(_ context.Context, event internal.Event) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		handled[event.Task.ID] = append(handled[event.Task.ID], event.Task.Version)
+
+		return nil
+	})
+
+	if err := consumer.NewConsumer(zap.NewNop(), source, handler, 4).Run(t.Context()); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	for id, versions := range handled {
+		for i := 1; i < len(versions); i++ {
+			if versions[i] < versions[i-1] {
+				t.Fatalf("expected events of %s to be handled in order, got %v", id, versions)
+			}
+		}
+	}
+}
+
+func TestConsumer_Run_Drain(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(t.Context())
+
+	deliveries := make(chan consumer.Delivery)
+
+	source := &consumertesting.FakeSource{}
+	source.ReceiveStub = func(ctx context.Context) (<-chan consumer.Delivery, error) {
+		go func() {
+			<-ctx.Done()
+			close(deliveries)
+		}()
+
+		return deliveries, nil
+	}
+
+	delivery := &consumertesting.FakeDelivery{}
+	delivery.EventReturns(internal.Event{Task: internal.Task{ID: "a"}}, nil)
+
+	handler := consumer.HandlerFunc(func(ctx context.Context, _ internal.Event) error {
+		cancel()
+
+		// In-flight messages are handled using a context that is not canceled.
+		return ctx.Err()
+	})
+
+	done := make(chan error)
+
+	go func() {
+		done <- consumer.NewConsumer(zap.NewNop(), source, handler, 1).Run(ctx)
+	}()
+
+	deliveries <- delivery
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected Run to return after the context is canceled")
+	}
+
+	if count := delivery.AckCallCount(); count != 1 {
+		t.Fatalf("expected in-flight message to be acked, got %d", count)
+	}
+}
+
+func TestConsumer_Run_Error(t *testing.T) {
+	t.Parallel()
+
+	source := &consumertesting.FakeSource{}
+	source.ReceiveReturns(nil, errors.New("unavailable"))
+
+	if err := consumer.NewConsumer(zap.NewNop(), source, &consumertesting.FakeHandler{}, 1).Run(t.Context()); err == nil {
+		t.Fatalf("expected error, got nil")
+	}
+}
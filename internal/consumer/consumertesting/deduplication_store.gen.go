@@ -0,0 +1,192 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package consumertesting
+
+import (
+	"context"
+	"sync"
+
+	"github.com/MarioCarrion/todo-api-microservice-example/internal"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/consumer"
+)
+
+type FakeDeduplicationStore struct {
+	MarkProcessedStub        func(context.Context, internal.Event) error
+	markProcessedMutex       sync.RWMutex
+	markProcessedArgsForCall []struct {
+		arg1 context.Context
+		arg2 internal.Event
+	}
+	markProcessedReturns struct {
+		result1 error
+	}
+	markProcessedReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ProcessedStub        func(context.Context, internal.Event) (bool, error)
+	processedMutex       sync.RWMutex
+	processedArgsForCall []struct {
+		arg1 context.Context
+		arg2 internal.Event
+	}
+	processedReturns struct {
+		result1 bool
+		result2 error
+	}
+	processedReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeDeduplicationStore) MarkProcessed(arg1 context.Context, arg2 internal.Event) error {
+	fake.markProcessedMutex.Lock()
+	ret, specificReturn := fake.markProcessedReturnsOnCall[len(fake.markProcessedArgsForCall)]
+	fake.markProcessedArgsForCall = append(fake.markProcessedArgsForCall, struct {
+		arg1 context.Context
+		arg2 internal.Event
+	}{arg1, arg2})
+	stub := fake.MarkProcessedStub
+	fakeReturns := fake.markProcessedReturns
+	fake.recordInvocation("MarkProcessed", []interface{}{arg1, arg2})
+	fake.markProcessedMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeDeduplicationStore) MarkProcessedCallCount() int {
+	fake.markProcessedMutex.RLock()
+	defer fake.markProcessedMutex.RUnlock()
+	return len(fake.markProcessedArgsForCall)
+}
+
+func (fake *FakeDeduplicationStore) MarkProcessedCalls(stub func(context.Context, internal.Event) error) {
+	fake.markProcessedMutex.Lock()
+	defer fake.markProcessedMutex.Unlock()
+	fake.MarkProcessedStub = stub
+}
+
+func (fake *FakeDeduplicationStore) MarkProcessedArgsForCall(i int) (context.Context, internal.Event) {
+	fake.markProcessedMutex.RLock()
+	defer fake.markProcessedMutex.RUnlock()
+	argsForCall := fake.markProcessedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeDeduplicationStore) MarkProcessedReturns(result1 error) {
+	fake.markProcessedMutex.Lock()
+	defer fake.markProcessedMutex.Unlock()
+	fake.MarkProcessedStub = nil
+	fake.markProcessedReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDeduplicationStore) MarkProcessedReturnsOnCall(i int, result1 error) {
+	fake.markProcessedMutex.Lock()
+	defer fake.markProcessedMutex.Unlock()
+	fake.MarkProcessedStub = nil
+	if fake.markProcessedReturnsOnCall == nil {
+		fake.markProcessedReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.markProcessedReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDeduplicationStore) Processed(arg1 context.Context, arg2 internal.Event) (bool, error) {
+	fake.processedMutex.Lock()
+	ret, specificReturn := fake.processedReturnsOnCall[len(fake.processedArgsForCall)]
+	fake.processedArgsForCall = append(fake.processedArgsForCall, struct {
+		arg1 context.Context
+		arg2 internal.Event
+	}{arg1, arg2})
+	stub := fake.ProcessedStub
+	fakeReturns := fake.processedReturns
+	fake.recordInvocation("Processed", []interface{}{arg1, arg2})
+	fake.processedMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDeduplicationStore) ProcessedCallCount() int {
+	fake.processedMutex.RLock()
+	defer fake.processedMutex.RUnlock()
+	return len(fake.processedArgsForCall)
+}
+
+func (fake *FakeDeduplicationStore) ProcessedCalls(stub func(context.Context, internal.Event) (bool, error)) {
+	fake.processedMutex.Lock()
+	defer fake.processedMutex.Unlock()
+	fake.ProcessedStub = stub
+}
+
+func (fake *FakeDeduplicationStore) ProcessedArgsForCall(i int) (context.Context, internal.Event) {
+	fake.processedMutex.RLock()
+	defer fake.processedMutex.RUnlock()
+	argsForCall := fake.processedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeDeduplicationStore) ProcessedReturns(result1 bool, result2 error) {
+	fake.processedMutex.Lock()
+	defer fake.processedMutex.Unlock()
+	fake.ProcessedStub = nil
+	fake.processedReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDeduplicationStore) ProcessedReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.processedMutex.Lock()
+	defer fake.processedMutex.Unlock()
+	fake.ProcessedStub = nil
+	if fake.processedReturnsOnCall == nil {
+		fake.processedReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.processedReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDeduplicationStore) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeDeduplicationStore) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ consumer.DeduplicationStore = new(FakeDeduplicationStore)
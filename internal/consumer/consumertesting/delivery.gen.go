@@ -0,0 +1,253 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package consumertesting
+
+import (
+	"context"
+	"sync"
+
+	"github.com/MarioCarrion/todo-api-microservice-example/internal"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/consumer"
+)
+
+type FakeDelivery struct {
+	AckStub        func(context.Context) error
+	ackMutex       sync.RWMutex
+	ackArgsForCall []struct {
+		arg1 context.Context
+	}
+	ackReturns struct {
+		result1 error
+	}
+	ackReturnsOnCall map[int]struct {
+		result1 error
+	}
+	EventStub        func() (internal.Event, error)
+	eventMutex       sync.RWMutex
+	eventArgsForCall []struct {
+	}
+	eventReturns struct {
+		result1 internal.Event
+		result2 error
+	}
+	eventReturnsOnCall map[int]struct {
+		result1 internal.Event
+		result2 error
+	}
+	NackStub        func(context.Context, error) error
+	nackMutex       sync.RWMutex
+	nackArgsForCall []struct {
+		arg1 context.Context
+		arg2 error
+	}
+	nackReturns struct {
+		result1 error
+	}
+	nackReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeDelivery) Ack(arg1 context.Context) error {
+	fake.ackMutex.Lock()
+	ret, specificReturn := fake.ackReturnsOnCall[len(fake.ackArgsForCall)]
+	fake.ackArgsForCall = append(fake.ackArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.AckStub
+	fakeReturns := fake.ackReturns
+	fake.recordInvocation("Ack", []interface{}{arg1})
+	fake.ackMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeDelivery) AckCallCount() int {
+	fake.ackMutex.RLock()
+	defer fake.ackMutex.RUnlock()
+	return len(fake.ackArgsForCall)
+}
+
+func (fake *FakeDelivery) AckCalls(stub func(context.Context) error) {
+	fake.ackMutex.Lock()
+	defer fake.ackMutex.Unlock()
+	fake.AckStub = stub
+}
+
+func (fake *FakeDelivery) AckArgsForCall(i int) context.Context {
+	fake.ackMutex.RLock()
+	defer fake.ackMutex.RUnlock()
+	argsForCall := fake.ackArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeDelivery) AckReturns(result1 error) {
+	fake.ackMutex.Lock()
+	defer fake.ackMutex.Unlock()
+	fake.AckStub = nil
+	fake.ackReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDelivery) AckReturnsOnCall(i int, result1 error) {
+	fake.ackMutex.Lock()
+	defer fake.ackMutex.Unlock()
+	fake.AckStub = nil
+	if fake.ackReturnsOnCall == nil {
+		fake.ackReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.ackReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDelivery) Event() (internal.Event, error) {
+	fake.eventMutex.Lock()
+	ret, specificReturn := fake.eventReturnsOnCall[len(fake.eventArgsForCall)]
+	fake.eventArgsForCall = append(fake.eventArgsForCall, struct {
+	}{})
+	stub := fake.EventStub
+	fakeReturns := fake.eventReturns
+	fake.recordInvocation("Event", []interface{}{})
+	fake.eventMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDelivery) EventCallCount() int {
+	fake.eventMutex.RLock()
+	defer fake.eventMutex.RUnlock()
+	return len(fake.eventArgsForCall)
+}
+
+func (fake *FakeDelivery) EventCalls(stub func() (internal.Event, error)) {
+	fake.eventMutex.Lock()
+	defer fake.eventMutex.Unlock()
+	fake.EventStub = stub
+}
+
+func (fake *FakeDelivery) EventReturns(result1 internal.Event, result2 error) {
+	fake.eventMutex.Lock()
+	defer fake.eventMutex.Unlock()
+	fake.EventStub = nil
+	fake.eventReturns = struct {
+		result1 internal.Event
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDelivery) EventReturnsOnCall(i int, result1 internal.Event, result2 error) {
+	fake.eventMutex.Lock()
+	defer fake.eventMutex.Unlock()
+	fake.EventStub = nil
+	if fake.eventReturnsOnCall == nil {
+		fake.eventReturnsOnCall = make(map[int]struct {
+			result1 internal.Event
+			result2 error
+		})
+	}
+	fake.eventReturnsOnCall[i] = struct {
+		result1 internal.Event
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDelivery) Nack(arg1 context.Context, arg2 error) error {
+	fake.nackMutex.Lock()
+	ret, specificReturn := fake.nackReturnsOnCall[len(fake.nackArgsForCall)]
+	fake.nackArgsForCall = append(fake.nackArgsForCall, struct {
+		arg1 context.Context
+		arg2 error
+	}{arg1, arg2})
+	stub := fake.NackStub
+	fakeReturns := fake.nackReturns
+	fake.recordInvocation("Nack", []interface{}{arg1, arg2})
+	fake.nackMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeDelivery) NackCallCount() int {
+	fake.nackMutex.RLock()
+	defer fake.nackMutex.RUnlock()
+	return len(fake.nackArgsForCall)
+}
+
+func (fake *FakeDelivery) NackCalls(stub func(context.Context, error) error) {
+	fake.nackMutex.Lock()
+	defer fake.nackMutex.Unlock()
+	fake.NackStub = stub
+}
+
+func (fake *FakeDelivery) NackArgsForCall(i int) (context.Context, error) {
+	fake.nackMutex.RLock()
+	defer fake.nackMutex.RUnlock()
+	argsForCall := fake.nackArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeDelivery) NackReturns(result1 error) {
+	fake.nackMutex.Lock()
+	defer fake.nackMutex.Unlock()
+	fake.NackStub = nil
+	fake.nackReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDelivery) NackReturnsOnCall(i int, result1 error) {
+	fake.nackMutex.Lock()
+	defer fake.nackMutex.Unlock()
+	fake.NackStub = nil
+	if fake.nackReturnsOnCall == nil {
+		fake.nackReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.nackReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDelivery) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeDelivery) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ consumer.Delivery = new(FakeDelivery)
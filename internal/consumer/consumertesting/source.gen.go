@@ -0,0 +1,115 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package consumertesting
+
+import (
+	"context"
+	"sync"
+
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/consumer"
+)
+
+type FakeSource struct {
+	ReceiveStub        func(context.Context) (<-chan consumer.Delivery, error)
+	receiveMutex       sync.RWMutex
+	receiveArgsForCall []struct {
+		arg1 context.Context
+	}
+	receiveReturns struct {
+		result1 <-chan consumer.Delivery
+		result2 error
+	}
+	receiveReturnsOnCall map[int]struct {
+		result1 <-chan consumer.Delivery
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeSource) Receive(arg1 context.Context) (<-chan consumer.Delivery, error) {
+	fake.receiveMutex.Lock()
+	ret, specificReturn := fake.receiveReturnsOnCall[len(fake.receiveArgsForCall)]
+	fake.receiveArgsForCall = append(fake.receiveArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.ReceiveStub
+	fakeReturns := fake.receiveReturns
+	fake.recordInvocation("Receive", []interface{}{arg1})
+	fake.receiveMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeSource) ReceiveCallCount() int {
+	fake.receiveMutex.RLock()
+	defer fake.receiveMutex.RUnlock()
+	return len(fake.receiveArgsForCall)
+}
+
+func (fake *FakeSource) ReceiveCalls(stub func(context.Context) (<-chan consumer.Delivery, error)) {
+	fake.receiveMutex.Lock()
+	defer fake.receiveMutex.Unlock()
+	fake.ReceiveStub = stub
+}
+
+func (fake *FakeSource) ReceiveArgsForCall(i int) context.Context {
+	fake.receiveMutex.RLock()
+	defer fake.receiveMutex.RUnlock()
+	argsForCall := fake.receiveArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeSource) ReceiveReturns(result1 <-chan consumer.Delivery, result2 error) {
+	fake.receiveMutex.Lock()
+	defer fake.receiveMutex.Unlock()
+	fake.ReceiveStub = nil
+	fake.receiveReturns = struct {
+		result1 <-chan consumer.Delivery
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSource) ReceiveReturnsOnCall(i int, result1 <-chan consumer.Delivery, result2 error) {
+	fake.receiveMutex.Lock()
+	defer fake.receiveMutex.Unlock()
+	fake.ReceiveStub = nil
+	if fake.receiveReturnsOnCall == nil {
+		fake.receiveReturnsOnCall = make(map[int]struct {
+			result1 <-chan consumer.Delivery
+			result2 error
+		})
+	}
+	fake.receiveReturnsOnCall[i] = struct {
+		result1 <-chan consumer.Delivery
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSource) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeSource) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ consumer.Source = new(FakeSource)
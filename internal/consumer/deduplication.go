@@ -0,0 +1,144 @@
+package consumer
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/MarioCarrion/todo-api-microservice-example/internal"
+)
+
+//counterfeiter:generate -o consumertesting/deduplication_store.gen.go . DeduplicationStore
+
+// DeduplicationStore defines the datastore keeping track of the events processed by a consumer.
+type DeduplicationStore interface {
+	// Processed indicates whether the event was already processed, either because its ID was recorded or because an
+	// event with the same or a newer version of the Task was recorded.
+	Processed(ctx context.Context, event internal.Event) (bool, error)
+	// MarkProcessed records the event ID and the version of the Task.
+	MarkProcessed(ctx context.Context, event internal.Event) error
+}
+
+// Deduplicate returns a Handler that skips redelivered events and events older than the last one processed for the
+// same Task, the rest are passed to handler and recorded in store after being handled successfully.
+//
+// Events are recorded after being handled, a failure recording them results in handling the event again; handler
+// must be idempotent.
+//
+//nolint:ireturn
+func Deduplicate(logger *zap.Logger, store DeduplicationStore, handler Handler) Handler {
+	return HandlerFunc(func(ctx context.Context, event internal.Event) error {
+		processed, err := store.Processed(ctx, event)
+		if err != nil {
+			return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "store.Processed")
+		}
+
+		if processed {
+			logger.Info("Skipping processed event",
+				zap.String("id", event.ID),
+				zap.String("type", string(event.Type)),
+				zap.String("task", event.Task.ID),
+				zap.Int64("version", event.Version()))
+
+			return nil
+		}
+
+		if err := handler.Handle(ctx, event); err != nil {
+			return err //nolint: wrapcheck
+		}
+
+		if err := store.MarkProcessed(ctx, event); err != nil {
+			return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "store.MarkProcessed")
+		}
+
+		return nil
+	})
+}
+
+// MemoryDeduplicationStore keeps track of the processed events in memory, it is meant to be used when running a
+// single consumer because the state is lost after restarting. Event IDs and Task versions are forgotten once they are
+// older than the TTL, expired entries are evicted at most once per TTL.
+type MemoryDeduplicationStore struct {
+	mu       sync.Mutex
+	ttl      time.Duration
+	events   map[string]time.Time
+	versions map[string]memoryVersion
+	evictAt  time.Time
+}
+
+type memoryVersion struct {
+	version   int64
+	expiresAt time.Time
+}
+
+// NewMemoryDeduplicationStore instantiates the MemoryDeduplicationStore.
+func NewMemoryDeduplicationStore(ttl time.Duration) *MemoryDeduplicationStore {
+	return &MemoryDeduplicationStore{
+		ttl:      ttl,
+		events:   make(map[string]time.Time),
+		versions: make(map[string]memoryVersion),
+		evictAt:  time.Now().Add(ttl),
+	}
+}
+
+// Processed indicates whether the event was already processed.
+func (m *MemoryDeduplicationStore) Processed(_ context.Context, event internal.Event) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+
+	if expiresAt, ok := m.events[event.ID]; ok && now.Before(expiresAt) {
+		return true, nil
+	}
+
+	version, ok := m.versions[event.Task.ID]
+
+	return ok && now.Before(version.expiresAt) && version.version >= event.Version(), nil
+}
+
+// MarkProcessed records the event ID and the version of the Task.
+func (m *MemoryDeduplicationStore) MarkProcessed(_ context.Context, event internal.Event) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+
+	m.evict(now)
+
+	expiresAt := now.Add(m.ttl)
+
+	if event.ID != "" {
+		m.events[event.ID] = expiresAt
+	}
+
+	if version, ok := m.versions[event.Task.ID]; !ok || !now.Before(version.expiresAt) ||
+		event.Version() > version.version {
+		m.versions[event.Task.ID] = memoryVersion{version: event.Version(), expiresAt: expiresAt}
+	}
+
+	return nil
+}
+
+// evict deletes the expired entries, it must be called holding the lock.
+func (m *MemoryDeduplicationStore) evict(now time.Time) {
+	if now.Before(m.evictAt) {
+		return
+	}
+
+	for id, expiresAt := range m.events {
+		if !now.Before(expiresAt) {
+			delete(m.events, id)
+		}
+	}
+
+	for id, version := range m.versions {
+		if !now.Before(version.expiresAt) {
+			delete(m.versions, id)
+		}
+	}
+
+	m.evictAt = now.Add(m.ttl)
+}
@@ -0,0 +1,202 @@
+package consumer_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/MarioCarrion/todo-api-microservice-example/internal"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/consumer"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/consumer/consumertesting"
+)
+
+func TestDeduplicate(t *testing.T) {
+	t.Parallel()
+
+	event := internal.Event{
+		ID:   "1c7f0a3e-a5b4-4c4f-9f0c-6a0a9b3f0e8d/2/Task.Updated",
+		Type: internal.EventTypeTaskUpdated,
+		Task: internal.Task{ID: "1c7f0a3e-a5b4-4c4f-9f0c-6a0a9b3f0e8d", Version: 2},
+	}
+
+	type counts struct {
+		handle, mark int
+	}
+
+	tests := []struct {
+		name      string
+		setup     func(*consumertesting.FakeDeduplicationStore, *consumertesting.FakeHandler)
+		expectErr bool
+		expected  counts
+	}{
+		{
+			name:     "OK",
+			setup:    func(_ *consumertesting.FakeDeduplicationStore, _ *consumertesting.FakeHandler) {},
+			expected: counts{handle: 1, mark: 1},
+		},
+		{
+			name: "OK: processed",
+			setup: func(s *consumertesting.FakeDeduplicationStore, _ *consumertesting.FakeHandler) {
+				s.ProcessedReturns(true, nil)
+			},
+			expected: counts{},
+		},
+		{
+			name: "ERR: processed",
+			setup: func(s *consumertesting.FakeDeduplicationStore, _ *consumertesting.FakeHandler) {
+				s.ProcessedReturns(false, errors.New("unavailable"))
+			},
+			expectErr: true,
+			expected:  counts{},
+		},
+		{
+			name: "ERR: handle",
+			setup: func(_ *consumertesting.FakeDeduplicationStore, h *consumertesting.FakeHandler) {
+				h.HandleReturns(errors.New("failed"))
+			},
+			expectErr: true,
+			expected:  counts{handle: 1},
+		},
+		{
+			name: "ERR: mark processed",
+			setup: func(s *consumertesting.FakeDeduplicationStore, _ *consumertesting.FakeHandler) {
+				s.MarkProcessedReturns(errors.New("unavailable"))
+			},
+			expectErr: true,
+			expected:  counts{handle: 1, mark: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			store := &consumertesting.FakeDeduplicationStore{}
+			handler := &consumertesting.FakeHandler{}
+			tt.setup(store, handler)
+
+			err := consumer.Deduplicate(zap.NewNop(), store, handler).Handle(t.Context(), event)
+			if (err != nil) != tt.expectErr {
+				t.Fatalf("expected error %t, got %v", tt.expectErr, err)
+			}
+
+			actual := counts{handle: handler.HandleCallCount(), mark: store.MarkProcessedCallCount()}
+			if actual != tt.expected {
+				t.Fatalf("expected %+v, got %+v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestMemoryDeduplicationStore(t *testing.T) {
+	t.Parallel()
+
+	newEvent := func(typ internal.EventType, version int64) internal.Event {
+		task := internal.Task{ID: "1c7f0a3e-a5b4-4c4f-9f0c-6a0a9b3f0e8d", Version: version}
+
+		return internal.Event{
+			ID:   fmt.Sprintf("%s/%d/%s", task.ID, version, typ),
+			Type: typ,
+			Task: task,
+		}
+	}
+
+	tests := []struct {
+		name     string
+		recorded []internal.Event
+		event    internal.Event
+		expected bool
+	}{
+		{
+			name:     "OK: new",
+			event:    newEvent(internal.EventTypeTaskCreated, 1),
+			expected: false,
+		},
+		{
+			name:     "OK: newer version",
+			recorded: []internal.Event{newEvent(internal.EventTypeTaskCreated, 1)},
+			event:    newEvent(internal.EventTypeTaskUpdated, 2),
+			expected: false,
+		},
+		{
+			name:     "OK: deleted after last update",
+			recorded: []internal.Event{newEvent(internal.EventTypeTaskUpdated, 2)},
+			event:    newEvent(internal.EventTypeTaskDeleted, 2),
+			expected: false,
+		},
+		{
+			name:     "OK: duplicated",
+			recorded: []internal.Event{newEvent(internal.EventTypeTaskUpdated, 2)},
+			event:    newEvent(internal.EventTypeTaskUpdated, 2),
+			expected: true,
+		},
+		{
+			name:     "OK: out of order",
+			recorded: []internal.Event{newEvent(internal.EventTypeTaskUpdated, 3)},
+			event:    newEvent(internal.EventTypeTaskUpdated, 2),
+			expected: true,
+		},
+		{
+			name:     "OK: updated after deleted",
+			recorded: []internal.Event{newEvent(internal.EventTypeTaskDeleted, 2)},
+			event:    newEvent(internal.EventTypeTaskUpdated, 2),
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			store := consumer.NewMemoryDeduplicationStore(time.Hour)
+
+			for _, event := range tt.recorded {
+				if err := store.MarkProcessed(t.Context(), event); err != nil {
+					t.Fatalf("expected no error, got %s", err)
+				}
+			}
+
+			actual, err := store.Processed(t.Context(), tt.event)
+			if err != nil {
+				t.Fatalf("expected no error, got %s", err)
+			}
+
+			if actual != tt.expected {
+				t.Fatalf("expected %t, got %t", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestMemoryDeduplicationStore_Expired(t *testing.T) {
+	t.Parallel()
+
+	synctest.Test(t, func(t *testing.T) {
+		event := internal.Event{
+			ID:   "1c7f0a3e-a5b4-4c4f-9f0c-6a0a9b3f0e8d/2/Task.Updated",
+			Type: internal.EventTypeTaskUpdated,
+			Task: internal.Task{ID: "1c7f0a3e-a5b4-4c4f-9f0c-6a0a9b3f0e8d", Version: 2},
+		}
+
+		store := consumer.NewMemoryDeduplicationStore(time.Hour)
+
+		if err := store.MarkProcessed(t.Context(), event); err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+
+		time.Sleep(time.Hour)
+
+		actual, err := store.Processed(t.Context(), event)
+		if err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+
+		if actual {
+			t.Fatalf("expected expired event not to be processed")
+		}
+	})
+}
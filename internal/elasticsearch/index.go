@@ -0,0 +1,442 @@
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	esv7 "github.com/elastic/go-elasticsearch/v7"
+	esv7api "github.com/elastic/go-elasticsearch/v7/esapi"
+
+	"github.com/MarioCarrion/todo-api-microservice-example/internal"
+)
+
+const (
+	// TaskAlias is the alias used for reading and writing Tasks, it points to the versioned index.
+	TaskAlias = "tasks"
+
+	// TaskIndexVersion is the version of the index definition, settings and mappings, used by the current code. Add
+	// a new file to the indices directory and increase it when changing the definition.
+	TaskIndexVersion = 2
+)
+
+const (
+	// reindexVersionScript sets the version of the reindexed documents to the Task version.
+	reindexVersionScript = "if (ctx._source.version != null) { ctx._version = ctx._source.version }"
+
+	// migrateBatchSize is the number of documents compared at once when looking for deleted Tasks.
+	migrateBatchSize = 1_000
+
+	// migrateKeepAlive is how long the point in time used for comparing the indices is kept between requests.
+	migrateKeepAlive = "5m"
+)
+
+//go:embed indices/*.json
+var indices embed.FS
+
+// TaskIndexName returns the name of the physical index using the version of the definition.
+func TaskIndexName(version int) string {
+	return fmt.Sprintf("%s_v%d", TaskAlias, version)
+}
+
+// TaskIndexDefinition returns the settings and mappings of the version, as JSON.
+func TaskIndexDefinition(version int) ([]byte, error) {
+	res, err := indices.ReadFile(fmt.Sprintf("indices/%s.json", TaskIndexName(version)))
+	if err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeNotFound, "unknown index version %d", version)
+	}
+
+	return res, nil
+}
+
+// Migrator represents the type in charge of migrating the Tasks to new versions of the index, without downtime.
+type Migrator struct {
+	client *esv7.Client
+}
+
+// NewMigrator instantiates the Migrator.
+func NewMigrator(client *esv7.Client) *Migrator {
+	return &Migrator{
+		client: client,
+	}
+}
+
+// Migrate creates the index using the definition of the version, points the alias to it and returns the name of the
+// index previously used, if any. Tasks in the index currently used by the alias are reindexed before the alias is
+// swapped atomically. Previous versioned indices are not deleted.
+//
+// Writes received while reindexing are copied again after swapping the alias, the Task version, instead of the
+// version of the documents in the current index, is used as external version so newer documents are kept. Documents
+// copied before the swap that don't exist in the current index anymore, because they were deleted while reindexing,
+// are deleted afterwards.
+//
+// An index named like the alias, created before using versioned indices, is deleted when adding the alias instead:
+// writes to it are blocked and copied again before that, so they fail and must be retried until the alias exists.
+func (m *Migrator) Migrate(ctx context.Context, version int) (string, error) {
+	definition, err := TaskIndexDefinition(version)
+	if err != nil {
+		return "", err
+	}
+
+	target := TaskIndexName(version)
+
+	current, isAlias, err := m.current(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if current == target {
+		return current, nil
+	}
+
+	if err := m.createIndex(ctx, target, version, definition); err != nil {
+		return "", err
+	}
+
+	if current != "" {
+		if err := m.reindex(ctx, current, target); err != nil {
+			return "", err
+		}
+	}
+
+	// The alias can't be added while the index named like it exists, writes are blocked and copied again before
+	// deleting the index in the same request that adds the alias.
+	if current != "" && !isAlias {
+		if err := m.blockWrites(ctx, current, true); err != nil {
+			return "", err
+		}
+
+		if err := m.reindex(ctx, current, target); err != nil {
+			m.blockWrites(context.WithoutCancel(ctx), current, false) //nolint: errcheck
+
+			return "", err
+		}
+	}
+
+	// Opened before swapping the alias, the point in time only includes the documents copied from the current index
+	// and not the ones written afterwards, which don't exist in the current index either.
+	var pit string
+
+	if current != "" {
+		if pit, err = m.openPointInTime(ctx, target); err != nil {
+			if !isAlias {
+				m.blockWrites(context.WithoutCancel(ctx), current, false) //nolint: errcheck
+			}
+
+			return "", err
+		}
+
+		defer m.closePointInTime(context.WithoutCancel(ctx), &pit)
+	}
+
+	// Writes are blocked, the index named like the alias is compared before it is deleted.
+	if current != "" && !isAlias {
+		if err := m.deleteMissing(ctx, &pit, current, target); err != nil {
+			m.blockWrites(context.WithoutCancel(ctx), current, false) //nolint: errcheck
+
+			return "", err
+		}
+	}
+
+	actions := []any{
+		map[string]any{"add": map[string]any{"index": target, "alias": TaskAlias}},
+	}
+
+	switch {
+	case current != "" && isAlias:
+		actions = append(actions, map[string]any{"remove": map[string]any{"index": current, "alias": TaskAlias}})
+	case current != "":
+		actions = append(actions, map[string]any{"remove_index": map[string]any{"index": current}})
+	}
+
+	body, err := newBody(map[string]any{"actions": actions})
+	if err != nil {
+		return "", err
+	}
+
+	if err := do(ctx, m.client, esv7api.IndicesUpdateAliasesRequest{Body: body}, nil); err != nil {
+		if current != "" && !isAlias {
+			m.blockWrites(context.WithoutCancel(ctx), current, false) //nolint: errcheck
+		}
+
+		return "", internal.WrapErrorf(err, internal.ErrorCodeUnknown, "IndicesUpdateAliasesRequest.Do")
+	}
+
+	// Copies the documents written to the previous index while reindexing, when it still exists.
+	if current != "" && isAlias {
+		if err := m.reindex(ctx, current, target); err != nil {
+			return "", err
+		}
+
+		if err := m.deleteMissing(ctx, &pit, current, target); err != nil {
+			return "", err
+		}
+	}
+
+	return current, nil
+}
+
+// current returns the index used by the alias, isAlias is false when the index is named like the alias; an empty
+// value indicates the alias does not exist.
+func (m *Migrator) current(ctx context.Context) (string, bool, error) {
+	var res map[string]struct {
+		Aliases map[string]any `json:"aliases"`
+	}
+
+	if err := do(ctx, m.client, esv7api.IndicesGetRequest{Index: []string{TaskAlias}}, &res); err != nil {
+		if internal.HasCode(err, internal.ErrorCodeNotFound) {
+			return "", false, nil
+		}
+
+		return "", false, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "IndicesGetRequest.Do")
+	}
+
+	if len(res) != 1 {
+		return "", false, internal.NewErrorf(internal.ErrorCodeUnknown, "alias %s points to %d indices", TaskAlias, len(res))
+	}
+
+	for name := range res {
+		return name, name != TaskAlias, nil
+	}
+
+	return "", false, nil
+}
+
+// createIndex creates the index template, matching only the index, and then the index; the template guarantees the
+// definition is used even if the index is created by writing to it. Existing indices are reused to support running
+// migrations again after failing.
+func (m *Migrator) createIndex(ctx context.Context, name string, version int, definition []byte) error {
+	body, err := newBody(map[string]any{
+		"index_patterns": []string{name},
+		"version":        version,
+		"template":       json.RawMessage(definition),
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := do(ctx, m.client, esv7api.IndicesPutIndexTemplateRequest{Name: name, Body: body}, nil); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "IndicesPutIndexTemplateRequest.Do")
+	}
+
+	if err := do(ctx, m.client, esv7api.IndicesCreateRequest{Index: name}, nil); err != nil {
+		if internal.HasCode(err, internal.ErrorCodeInvalidArgument) && m.exists(ctx, name) {
+			return nil
+		}
+
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "IndicesCreateRequest.Do")
+	}
+
+	return nil
+}
+
+// blockWrites makes the index read-only, writes fail until the block is removed.
+func (m *Migrator) blockWrites(ctx context.Context, name string, block bool) error {
+	body, err := newBody(map[string]any{"index.blocks.write": block})
+	if err != nil {
+		return err
+	}
+
+	req := esv7api.IndicesPutSettingsRequest{Index: []string{name}, Body: body}
+
+	if err := do(ctx, m.client, req, nil); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "IndicesPutSettingsRequest.Do")
+	}
+
+	return nil
+}
+
+func (m *Migrator) exists(ctx context.Context, name string) bool {
+	return do(ctx, m.client, esv7api.IndicesExistsRequest{Index: []string{name}}, nil) == nil
+}
+
+// reindex copies the documents keeping the newest version of each one, the Task version is used as the external
+// version because the source index may not use it; documents indexed before tasks had versions keep their own.
+func (m *Migrator) reindex(ctx context.Context, source, dest string) error {
+	body, err := newBody(map[string]any{
+		"conflicts": "proceed",
+		"source":    map[string]any{"index": source},
+		"dest":      map[string]any{"index": dest, "version_type": versionTypeExternal},
+		"script":    map[string]any{"source": reindexVersionScript, "lang": "painless"},
+	})
+	if err != nil {
+		return err
+	}
+
+	req := esv7api.ReindexRequest{
+		Body:              body,
+		Refresh:           new(true),
+		WaitForCompletion: new(true),
+	}
+
+	var res struct {
+		Failures []any `json:"failures"`
+	}
+
+	if err := do(ctx, m.client, req, &res); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "ReindexRequest.Do")
+	}
+
+	if len(res.Failures) > 0 {
+		return internal.NewErrorf(internal.ErrorCodeUnknown, "reindexing %s failed: %v", source, res.Failures)
+	}
+
+	return nil
+}
+
+// deleteMissing deletes the documents in the point in time of dest that don't exist in source, the point in time
+// identifier is updated with the one returned by each search.
+func (m *Migrator) deleteMissing(ctx context.Context, pit *string, source, dest string) error {
+	var after []any
+
+	for {
+		query := map[string]any{
+			"size":    migrateBatchSize,
+			"_source": false,
+			"pit":     map[string]any{"id": *pit, "keep_alive": migrateKeepAlive},
+			"sort":    []any{map[string]any{"_shard_doc": "asc"}},
+		}
+
+		if after != nil {
+			query["search_after"] = after
+		}
+
+		body, err := newBody(query)
+		if err != nil {
+			return err
+		}
+
+		//nolint: tagliatelle
+		var res struct {
+			PitID string `json:"pit_id"`
+			Hits  struct {
+				Hits []struct {
+					ID   string `json:"_id"`
+					Sort []any  `json:"sort"`
+				} `json:"hits"`
+			} `json:"hits"`
+		}
+
+		if err := do(ctx, m.client, esv7api.SearchRequest{Body: body}, &res); err != nil {
+			return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "SearchRequest.Do")
+		}
+
+		*pit = res.PitID
+
+		if len(res.Hits.Hits) == 0 {
+			return nil
+		}
+
+		ids := make([]string, len(res.Hits.Hits))
+
+		for i, hit := range res.Hits.Hits {
+			ids[i] = hit.ID
+		}
+
+		missing, err := m.missing(ctx, source, ids)
+		if err != nil {
+			return err
+		}
+
+		if err := (&Task{client: m.client, index: dest}).BulkDelete(ctx, missing); err != nil {
+			return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "BulkDelete")
+		}
+
+		after = res.Hits.Hits[len(res.Hits.Hits)-1].Sort
+	}
+}
+
+// missing returns the identifiers of the documents that don't exist in the index.
+func (m *Migrator) missing(ctx context.Context, index string, ids []string) ([]string, error) {
+	body, err := newBody(map[string]any{"ids": ids})
+	if err != nil {
+		return nil, err
+	}
+
+	//nolint: tagliatelle
+	var res struct {
+		Docs []struct {
+			ID    string `json:"_id"`
+			Found bool   `json:"found"`
+		} `json:"docs"`
+	}
+
+	req := esv7api.MgetRequest{Index: index, Body: body, Source: []string{"false"}}
+
+	if err := do(ctx, m.client, req, &res); err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "MgetRequest.Do")
+	}
+
+	var missing []string
+
+	for _, doc := range res.Docs {
+		if !doc.Found {
+			missing = append(missing, doc.ID)
+		}
+	}
+
+	return missing, nil
+}
+
+// openPointInTime returns the identifier of a point in time of the index.
+func (m *Migrator) openPointInTime(ctx context.Context, index string) (string, error) {
+	var res struct {
+		ID string `json:"id"`
+	}
+
+	req := esv7api.OpenPointInTimeRequest{Index: []string{index}, KeepAlive: migrateKeepAlive}
+
+	if err := do(ctx, m.client, req, &res); err != nil {
+		return "", internal.WrapErrorf(err, internal.ErrorCodeUnknown, "OpenPointInTimeRequest.Do")
+	}
+
+	return res.ID, nil
+}
+
+// closePointInTime releases the point in time, it expires after the keep alive otherwise.
+func (m *Migrator) closePointInTime(ctx context.Context, pit *string) {
+	body, err := newBody(map[string]any{"id": *pit})
+	if err != nil {
+		return
+	}
+
+	do(ctx, m.client, esv7api.ClosePointInTimeRequest{Body: body}, nil) //nolint: errcheck
+}
+
+func newBody(v any) (io.Reader, error) {
+	var buf bytes.Buffer
+
+	if err := json.NewEncoder(&buf).Encode(v); err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "json.NewEncoder.Encode")
+	}
+
+	return &buf, nil
+}
+
+// do sends the request and decodes the response into out, when not nil.
+func do(ctx context.Context, client *esv7.Client, req esv7api.Request, out any) error {
+	resp, err := req.Do(ctx, client)
+	if err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnavailable, "Request.Do")
+	}
+	defer resp.Body.Close()
+
+	if resp.IsError() {
+		return internal.NewErrorf(errorCode(resp.StatusCode), "Request.Do %d", resp.StatusCode)
+	}
+
+	if out == nil {
+		io.Copy(io.Discard, resp.Body) //nolint: errcheck
+
+		return nil
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "json.NewDecoder.Decode")
+	}
+
+	return nil
+}
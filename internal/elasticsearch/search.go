@@ -0,0 +1,364 @@
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"strconv"
+
+	esv7api "github.com/elastic/go-elasticsearch/v7/esapi"
+
+	"github.com/MarioCarrion/todo-api-microservice-example/internal"
+)
+
+const (
+	aggregationPriorities = "priorities"
+	aggregationIsDone     = "is_done"
+
+	// suggestOverFetch is the number of hits requested per suggestion, to make up for duplicated descriptions.
+	suggestOverFetch = 4
+
+	// suggestMaxHits is the maximum number of hits read when looking for distinct descriptions.
+	suggestMaxHits = 400
+)
+
+// Search returns tasks matching all the values in the query, the results include the number of tasks found per
+// priority and done state.
+//
+// The cursor is the sort values of the last hit, used with search_after for getting the next page; it is only
+// returned when the page is full.
+func (t *Task) Search(ctx context.Context, args internal.SearchParams) (internal.SearchResults, error) {
+	if args.IsZero() {
+		return internal.SearchResults{}, nil
+	}
+
+	query := map[string]any{
+		"query": newSearchQuery(args),
+		"sort":  newSearchSort(args.Sort),
+		"aggs": map[string]any{
+			aggregationPriorities: map[string]any{"terms": map[string]any{"field": "priority"}},
+			aggregationIsDone:     map[string]any{"terms": map[string]any{"field": "is_done"}},
+		},
+		"from": args.From,
+		"size": args.Size,
+	}
+
+	if args.Description != nil {
+		query["highlight"] = map[string]any{
+			"encoder": "html",
+			"fields": map[string]any{
+				"description": map[string]any{"number_of_fragments": 3, "fragment_size": 150},
+			},
+		}
+	}
+
+	if args.Cursor != "" {
+		after, err := decodeCursor(args.Cursor)
+		if err != nil {
+			return internal.SearchResults{}, err
+		}
+
+		query["search_after"] = after
+	}
+
+	var buf bytes.Buffer
+
+	if err := json.NewEncoder(&buf).Encode(query); err != nil {
+		return internal.SearchResults{}, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "json.NewEncoder.Encode")
+	}
+
+	req := esv7api.SearchRequest{
+		Index: []string{t.index},
+		Body:  &buf,
+	}
+
+	resp, err := req.Do(ctx, t.client)
+	if err != nil {
+		return internal.SearchResults{}, internal.WrapErrorf(err, internal.ErrorCodeUnavailable, "SearchRequest.Do")
+	}
+	defer resp.Body.Close()
+
+	if resp.IsError() {
+		return internal.SearchResults{}, internal.NewErrorf(errorCode(resp.StatusCode), "SearchRequest.Do %d", resp.StatusCode)
+	}
+
+	type buckets struct {
+		Buckets []struct {
+			Key         any    `json:"key"`
+			KeyAsString string `json:"key_as_string"`
+			DocCount    int64  `json:"doc_count"`
+		} `json:"buckets"`
+	}
+
+	//nolint: tagliatelle
+	var hits struct {
+		Hits struct {
+			Total struct {
+				Value int64 `json:"value"`
+			} `json:"total"`
+			Hits []struct {
+				Source    indexedTask     `json:"_source"`
+				Sort      json.RawMessage `json:"sort"`
+				Highlight struct {
+					Description []string `json:"description"`
+				} `json:"highlight"`
+			} `json:"hits"`
+		} `json:"hits"`
+		Aggregations struct {
+			Priorities buckets `json:"priorities"`
+			IsDone     buckets `json:"is_done"`
+		} `json:"aggregations"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&hits); err != nil {
+		return internal.SearchResults{}, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "json.NewDecoder.Decode")
+	}
+
+	res := internal.SearchResults{
+		Tasks: make([]internal.Task, len(hits.Hits.Hits)),
+		Total: hits.Hits.Total.Value,
+		Facets: internal.SearchFacets{
+			Priorities: make(map[internal.Priority]int64, len(hits.Aggregations.Priorities.Buckets)),
+		},
+	}
+
+	for index, hit := range hits.Hits.Hits {
+		res.Tasks[index] = hit.Source.toTask()
+
+		if len(hit.Highlight.Description) > 0 {
+			if res.Highlights == nil {
+				res.Highlights = make(map[string][]string)
+			}
+
+			res.Highlights[hit.Source.ID] = hit.Highlight.Description
+		}
+	}
+
+	if size := len(hits.Hits.Hits); size > 0 && int64(size) == args.Size {
+		res.Cursor = base64.RawURLEncoding.EncodeToString(hits.Hits.Hits[size-1].Sort)
+	}
+
+	for _, bucket := range hits.Aggregations.Priorities.Buckets {
+		key, ok := bucket.Key.(string)
+		if !ok {
+			continue
+		}
+
+		priority, err := strconv.ParseInt(key, 10, 8)
+		if err != nil {
+			continue
+		}
+
+		res.Facets.Priorities[internal.Priority(priority)] = bucket.DocCount
+	}
+
+	for _, bucket := range hits.Aggregations.IsDone.Buckets {
+		if bucket.KeyAsString == "true" {
+			res.Facets.Done = bucket.DocCount
+		} else {
+			res.Facets.NotDone = bucket.DocCount
+		}
+	}
+
+	return res, nil
+}
+
+// decodeCursor returns the sort values encoded in the cursor.
+func decodeCursor(cursor string) ([]json.RawMessage, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "invalid cursor")
+	}
+
+	var res []json.RawMessage
+
+	if err := json.Unmarshal(raw, &res); err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "invalid cursor")
+	}
+
+	if len(res) == 0 {
+		return nil, internal.NewErrorf(internal.ErrorCodeInvalidArgument, "invalid cursor")
+	}
+
+	return res, nil
+}
+
+// newSearchQuery returns the query matching all the values, the description is used for scoring the results and the
+// rest of values only filter them. Descriptions match misspelled words, the number of allowed edits depends on the
+// length of the word and the first letter must match; exact matches are scored higher.
+func newSearchQuery(args internal.SearchParams) map[string]any {
+	must := make([]any, 0, 1)
+	filter := make([]any, 0, 4)
+
+	if args.Description != nil {
+		must = append(must, map[string]any{
+			match: map[string]any{
+				"description": map[string]any{
+					"query":         *args.Description,
+					"fuzziness":     "AUTO",
+					"prefix_length": 1,
+				},
+			},
+		})
+	}
+
+	if args.Priority != nil {
+		filter = append(filter, map[string]any{
+			"term": map[string]any{
+				"priority": strconv.Itoa(int(*args.Priority)),
+			},
+		})
+	}
+
+	if args.IsDone != nil {
+		filter = append(filter, map[string]any{
+			"term": map[string]any{
+				"is_done": *args.IsDone,
+			},
+		})
+	}
+
+	if !args.StartDate.IsZero() {
+		filter = append(filter, newRangeQuery("date_start", args.StartDate))
+	}
+
+	if !args.DueDate.IsZero() {
+		filter = append(filter, newRangeQuery("date_due", args.DueDate))
+	}
+
+	return map[string]any{
+		"bool": map[string]any{
+			"must":   must,
+			"filter": filter,
+		},
+	}
+}
+
+// newRangeQuery returns the query matching the dates in the range, dates are indexed as nanoseconds.
+func newRangeQuery(field string, r internal.TimeRange) map[string]any {
+	values := make(map[string]any, 2)
+
+	if r.From != nil {
+		values["gte"] = r.From.UnixNano()
+	} else {
+		// Tasks indexed before unset dates were omitted use 0, they must not match unbounded ranges.
+		values["gt"] = 0
+	}
+
+	if r.To != nil {
+		values["lt"] = r.To.UnixNano()
+	}
+
+	return map[string]any{
+		"range": map[string]any{
+			field: values,
+		},
+	}
+}
+
+// newSearchSort returns the sort criteria, the id is always used last to return the results in a consistent order.
+func newSearchSort(sort internal.SearchSort) []any {
+	byID := map[string]any{"id": "asc"}
+
+	switch sort {
+	case internal.SearchSortDueDate:
+		return []any{
+			map[string]any{"date_due": map[string]any{"order": "asc", "missing": "_last"}},
+			"_score",
+			byID,
+		}
+	case internal.SearchSortPriority:
+		return []any{
+			map[string]any{"priority": "desc"},
+			"_score",
+			byID,
+		}
+	case "", internal.SearchSortRelevance:
+	}
+
+	return []any{"_score", byID}
+}
+
+// Suggest returns the descriptions including words starting with each one of the words in the prefix, sorted by
+// relevance, using the edge n-grams indexed in the "description.suggest" field. Duplicated descriptions are only
+// returned once, more hits are requested until size descriptions are found or up to suggestMaxHits are read.
+func (t *Task) Suggest(ctx context.Context, args internal.SuggestParams) ([]string, error) {
+	pageSize := args.Size * suggestOverFetch
+
+	res := make([]string, 0, args.Size)
+	found := make(map[string]struct{}, args.Size)
+
+	for from := int64(0); from < suggestMaxHits; from += pageSize {
+		descriptions, err := t.suggest(ctx, args.Prefix, from, pageSize)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, description := range descriptions {
+			if _, ok := found[description]; ok {
+				continue
+			}
+
+			found[description] = struct{}{}
+
+			if res = append(res, description); int64(len(res)) == args.Size {
+				return res, nil
+			}
+		}
+
+		if int64(len(descriptions)) < pageSize {
+			break
+		}
+	}
+
+	return res, nil
+}
+
+// suggest returns the descriptions of one page of hits matching the prefix.
+func (t *Task) suggest(ctx context.Context, prefix string, from, size int64) ([]string, error) {
+	body, err := newBody(map[string]any{
+		"query": map[string]any{
+			match: map[string]any{
+				"description.suggest": map[string]any{
+					"query":    prefix,
+					"operator": "and",
+				},
+			},
+		},
+		"_source": []string{"description"},
+		"from":    from,
+		"size":    size,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req := esv7api.SearchRequest{
+		Index: []string{t.index},
+		Body:  body,
+	}
+
+	//nolint: tagliatelle
+	var hits struct {
+		Hits struct {
+			Hits []struct {
+				Source struct {
+					Description string `json:"description"`
+				} `json:"_source"`
+			} `json:"hits"`
+		} `json:"hits"`
+	}
+
+	if err := do(ctx, t.client, req, &hits); err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "SearchRequest.Do")
+	}
+
+	res := make([]string, len(hits.Hits.Hits))
+
+	for i, hit := range hits.Hits.Hits {
+		res[i] = hit.Source.Description
+	}
+
+	return res, nil
+}
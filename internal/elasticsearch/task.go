@@ -6,6 +6,7 @@ import (
 	"context"
 	"encoding/json"
 	"io"
+	"net/http"
 	"time"
 
 	esv7 "github.com/elastic/go-elasticsearch/v7"
@@ -14,7 +15,32 @@ import (
 	"github.com/MarioCarrion/todo-api-microservice-example/internal"
 )
 
-const match = "match"
+const (
+	match = "match"
+
+	// versionTypeExternal indicates the document version is the Task version, instead of the one maintained by
+	// Elasticsearch.
+	versionTypeExternal = "external"
+)
+
+// errorCode returns the code matching the HTTP status code returned by Elasticsearch.
+func errorCode(statusCode int) internal.ErrorCode {
+	switch statusCode {
+	case http.StatusBadRequest:
+		return internal.ErrorCodeInvalidArgument
+	case http.StatusNotFound:
+		return internal.ErrorCodeNotFound
+	case http.StatusConflict:
+		return internal.ErrorCodeConflict
+	case http.StatusTooManyRequests,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return internal.ErrorCodeUnavailable
+	}
+
+	return internal.ErrorCodeUnknown
+}
 
 // Task represents the repository used for interacting with Task records.
 type Task struct {
@@ -24,42 +50,110 @@ type Task struct {
 
 //nolint:tagliatelle
 type indexedTask struct {
-	// XXX: `SubTasks` and `Categories` will be added in future episodes
 	ID          string             `json:"id"`
 	Description string             `json:"description"`
 	Priority    *internal.Priority `json:"priority"`
 	IsDone      bool               `json:"is_done"`
-	DateStart   int64              `json:"date_start"`
-	DateDue     int64              `json:"date_due"`
+	DateStart   int64              `json:"date_start,omitempty"`
+	DateDue     int64              `json:"date_due,omitempty"`
+	Categories  []string           `json:"categories,omitempty"`
+	SubTasks    []indexedTask      `json:"sub_tasks,omitempty"`
+	Version     int64              `json:"version"`
 }
 
-// NewTask instantiates the Task repository.
-func NewTask(client *esv7.Client) *Task {
-	return &Task{
-		client: client,
-		index:  "tasks",
-	}
-}
-
-// Index creates or updates a task in an index.
-func (t *Task) Index(ctx context.Context, task internal.Task) error {
-	body := indexedTask{
+func newIndexedTask(task internal.Task) indexedTask {
+	res := indexedTask{
 		ID:          task.ID,
 		Description: task.Description,
 		Priority:    task.Priority,
 		IsDone:      task.IsDone,
+		Version:     task.Version,
 	}
 
 	if task.Dates != nil {
 		if task.Dates.Start != nil {
-			body.DateStart = task.Dates.Start.UnixNano()
+			res.DateStart = task.Dates.Start.UnixNano()
 		}
 
 		if task.Dates.Due != nil {
-			body.DateDue = task.Dates.Due.UnixNano()
+			res.DateDue = task.Dates.Due.UnixNano()
+		}
+	}
+
+	if len(task.Categories) > 0 {
+		res.Categories = make([]string, len(task.Categories))
+
+		for i, category := range task.Categories {
+			res.Categories[i] = string(category)
+		}
+	}
+
+	if len(task.SubTasks) > 0 {
+		res.SubTasks = make([]indexedTask, len(task.SubTasks))
+
+		for i, subTask := range task.SubTasks {
+			res.SubTasks[i] = newIndexedTask(subTask)
+		}
+	}
+
+	return res
+}
+
+func (i indexedTask) toTask() internal.Task {
+	res := internal.Task{
+		ID:          i.ID,
+		Description: i.Description,
+		Priority:    i.Priority,
+		IsDone:      i.IsDone,
+		Version:     i.Version,
+	}
+
+	if i.DateStart != 0 || i.DateDue != 0 {
+		dates := internal.Dates{}
+
+		if i.DateStart != 0 {
+			dates.Start = new(time.Unix(0, i.DateStart).UTC())
+		}
+
+		if i.DateDue != 0 {
+			dates.Due = new(time.Unix(0, i.DateDue).UTC())
+		}
+
+		res.Dates = &dates
+	}
+
+	if len(i.Categories) > 0 {
+		res.Categories = make([]internal.Category, len(i.Categories))
+
+		for j, category := range i.Categories {
+			res.Categories[j] = internal.Category(category)
 		}
 	}
 
+	if len(i.SubTasks) > 0 {
+		res.SubTasks = make([]internal.Task, len(i.SubTasks))
+
+		for j, subTask := range i.SubTasks {
+			res.SubTasks[j] = subTask.toTask()
+		}
+	}
+
+	return res
+}
+
+// NewTask instantiates the Task repository.
+func NewTask(client *esv7.Client) *Task {
+	return &Task{
+		client: client,
+		index:  TaskAlias,
+	}
+}
+
+// Index creates or updates a task in an index. The Task version is used as the external version of the document,
+// indexing a version older than or equal to the indexed one fails with internal.ErrorCodeConflict.
+func (t *Task) Index(ctx context.Context, task internal.Task) error {
+	body := newIndexedTask(task)
+
 	var buf bytes.Buffer
 
 	if err := json.NewEncoder(&buf).Encode(body); err != nil {
@@ -67,20 +161,22 @@ func (t *Task) Index(ctx context.Context, task internal.Task) error {
 	}
 
 	req := esv7api.IndexRequest{
-		Index:      t.index,
-		Body:       &buf,
-		DocumentID: task.ID,
-		Refresh:    "true",
+		Index:       t.index,
+		Body:        &buf,
+		DocumentID:  task.ID,
+		Refresh:     "true",
+		Version:     new(int(task.Version)),
+		VersionType: versionTypeExternal,
 	}
 
 	resp, err := req.Do(ctx, t.client)
 	if err != nil {
-		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "IndexRequest.Do")
+		return internal.WrapErrorf(err, internal.ErrorCodeUnavailable, "IndexRequest.Do")
 	}
 	defer resp.Body.Close()
 
 	if resp.IsError() {
-		return internal.NewErrorf(internal.ErrorCodeUnknown, "IndexRequest.Do %d", resp.StatusCode)
+		return internal.NewErrorf(errorCode(resp.StatusCode), "IndexRequest.Do %d", resp.StatusCode)
 	}
 
 	io.Copy(io.Discard, resp.Body) //nolint: errcheck
@@ -88,21 +184,25 @@ func (t *Task) Index(ctx context.Context, task internal.Task) error {
 	return nil
 }
 
-// Delete removes a task from the index.
-func (t *Task) Delete(ctx context.Context, id string) error {
+// Delete removes a task from the index, version must be newer than the indexed one otherwise it fails with
+// internal.ErrorCodeConflict. Elasticsearch keeps the version of deleted documents for a while, indexing older
+// versions after deleting the task fails as well.
+func (t *Task) Delete(ctx context.Context, id string, version int64) error {
 	req := esv7api.DeleteRequest{
-		Index:      t.index,
-		DocumentID: id,
+		Index:       t.index,
+		DocumentID:  id,
+		Version:     new(int(version)),
+		VersionType: versionTypeExternal,
 	}
 
 	resp, err := req.Do(ctx, t.client)
 	if err != nil {
-		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "DeleteRequest.Do")
+		return internal.WrapErrorf(err, internal.ErrorCodeUnavailable, "DeleteRequest.Do")
 	}
 	defer resp.Body.Close()
 
 	if resp.IsError() {
-		return internal.NewErrorf(internal.ErrorCodeUnknown, "DeleteRequest.Do %d", resp.StatusCode)
+		return internal.NewErrorf(errorCode(resp.StatusCode), "DeleteRequest.Do %d", resp.StatusCode)
 	}
 
 	io.Copy(io.Discard, resp.Body) //nolint: errcheck
@@ -110,68 +210,145 @@ func (t *Task) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
-// Search returns tasks matching a query.
-//
-//nolint:funlen,cyclop
-func (t *Task) Search(ctx context.Context, args internal.SearchParams) (internal.SearchResults, error) {
-	if args.IsZero() {
-		return internal.SearchResults{}, nil
+// BulkIndex creates or updates the tasks using a single request, like Index the Task version is used as the external
+// version of the documents; tasks already indexed using the same or a newer version are skipped.
+func (t *Task) BulkIndex(ctx context.Context, tasks []internal.Task) error {
+	if len(tasks) == 0 {
+		return nil
 	}
 
-	should := make([]any, 0, 3)
+	var buf bytes.Buffer
+
+	enc := json.NewEncoder(&buf)
 
-	if args.Description != nil {
-		should = append(should, map[string]any{
-			match: map[string]any{
-				"description": *args.Description,
+	for _, task := range tasks {
+		action := map[string]any{
+			"index": map[string]any{
+				"_id":          task.ID,
+				"version":      task.Version,
+				"version_type": versionTypeExternal,
 			},
-		})
+		}
+
+		if err := enc.Encode(action); err != nil {
+			return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "json.NewEncoder.Encode")
+		}
+
+		if err := enc.Encode(newIndexedTask(task)); err != nil {
+			return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "json.NewEncoder.Encode")
+		}
 	}
 
-	if args.Priority != nil {
-		should = append(should, map[string]any{
-			match: map[string]any{
-				"priority": *args.Priority,
-			},
-		})
+	req := esv7api.BulkRequest{
+		Index: t.index,
+		Body:  &buf,
 	}
 
-	if args.IsDone != nil {
-		should = append(should, map[string]any{
-			match: map[string]any{
-				"is_done": *args.IsDone,
-			},
-		})
+	resp, err := req.Do(ctx, t.client)
+	if err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnavailable, "BulkRequest.Do")
+	}
+	defer resp.Body.Close()
+
+	if resp.IsError() {
+		return internal.NewErrorf(errorCode(resp.StatusCode), "BulkRequest.Do %d", resp.StatusCode)
 	}
 
-	var query map[string]any
+	//nolint: tagliatelle
+	var res struct {
+		Errors bool `json:"errors"`
+		Items  []struct {
+			Index struct {
+				ID     string `json:"_id"`
+				Status int    `json:"status"`
+			} `json:"index"`
+		} `json:"items"`
+	}
 
-	if len(should) > 1 {
-		query = map[string]any{
-			"query": map[string]any{
-				"bool": map[string]any{
-					"should": should,
-				},
-			},
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "json.NewDecoder.Decode")
+	}
+
+	if !res.Errors {
+		return nil
+	}
+
+	for _, item := range res.Items {
+		if item.Index.Status >= http.StatusBadRequest && item.Index.Status != http.StatusConflict {
+			return internal.NewErrorf(errorCode(item.Index.Status), "BulkRequest.Do %s %d", item.Index.ID, item.Index.Status)
 		}
-	} else {
-		query = map[string]any{
-			"query": should[0],
+	}
+
+	return nil
+}
+
+// Find returns the indexed tasks matching the ids, tasks not indexed are not included.
+func (t *Task) Find(ctx context.Context, ids []string) (map[string]internal.Task, error) {
+	res := make(map[string]internal.Task, len(ids))
+
+	if len(ids) == 0 {
+		return res, nil
+	}
+
+	var buf bytes.Buffer
+
+	if err := json.NewEncoder(&buf).Encode(map[string]any{"ids": ids}); err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "json.NewEncoder.Encode")
+	}
+
+	req := esv7api.MgetRequest{
+		Index: t.index,
+		Body:  &buf,
+	}
+
+	resp, err := req.Do(ctx, t.client)
+	if err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnavailable, "MgetRequest.Do")
+	}
+	defer resp.Body.Close()
+
+	if resp.IsError() {
+		return nil, internal.NewErrorf(errorCode(resp.StatusCode), "MgetRequest.Do %d", resp.StatusCode)
+	}
+
+	//nolint: tagliatelle
+	var docs struct {
+		Docs []struct {
+			Found  bool        `json:"found"`
+			Source indexedTask `json:"_source"`
+		} `json:"docs"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&docs); err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "json.NewDecoder.Decode")
+	}
+
+	for _, doc := range docs.Docs {
+		if doc.Found {
+			res[doc.Source.ID] = doc.Source.toTask()
 		}
 	}
 
-	query["sort"] = []any{
-		"_score",
-		map[string]any{"id": "asc"},
+	return res, nil
+}
+
+// List returns up to limit indexed tasks sorted by ID, starting after the ID; an empty value starts from the
+// beginning.
+func (t *Task) List(ctx context.Context, after string, limit int32) ([]internal.Task, error) {
+	query := map[string]any{
+		"query": map[string]any{"match_all": map[string]any{}},
+		"sort":  []any{map[string]any{"id": "asc"}},
+		"size":  limit,
 	}
 
-	query["from"] = args.From
-	query["size"] = args.Size
+	if after != "" {
+		query["search_after"] = []string{after}
+	}
 
 	var buf bytes.Buffer
 
 	if err := json.NewEncoder(&buf).Encode(query); err != nil {
-		return internal.SearchResults{}, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "json.NewEncoder.Encode")
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "json.NewEncoder.Encode")
 	}
 
 	req := esv7api.SearchRequest{
@@ -181,20 +358,17 @@ func (t *Task) Search(ctx context.Context, args internal.SearchParams) (internal
 
 	resp, err := req.Do(ctx, t.client)
 	if err != nil {
-		return internal.SearchResults{}, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "SearchRequest.Do")
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnavailable, "SearchRequest.Do")
 	}
 	defer resp.Body.Close()
 
 	if resp.IsError() {
-		return internal.SearchResults{}, internal.NewErrorf(internal.ErrorCodeUnknown, "SearchRequest.Do %d", resp.StatusCode)
+		return nil, internal.NewErrorf(errorCode(resp.StatusCode), "SearchRequest.Do %d", resp.StatusCode)
 	}
 
 	//nolint: tagliatelle
 	var hits struct {
 		Hits struct {
-			Total struct {
-				Value int64 `json:"value"`
-			} `json:"total"`
 			Hits []struct {
 				Source indexedTask `json:"_source"`
 			} `json:"hits"`
@@ -202,34 +376,75 @@ func (t *Task) Search(ctx context.Context, args internal.SearchParams) (internal
 	}
 
 	if err := json.NewDecoder(resp.Body).Decode(&hits); err != nil {
-		return internal.SearchResults{}, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "json.NewDecoder.Decode")
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "json.NewDecoder.Decode")
 	}
 
 	res := make([]internal.Task, len(hits.Hits.Hits))
 
-	for index, hit := range hits.Hits.Hits {
-		res[index].ID = hit.Source.ID
-		res[index].Description = hit.Source.Description
-		res[index].Priority = hit.Source.Priority
-		res[index].IsDone = hit.Source.IsDone
+	for i, hit := range hits.Hits.Hits {
+		res[i] = hit.Source.toTask()
+	}
 
-		if hit.Source.DateStart != 0 || hit.Source.DateDue != 0 {
-			dates := internal.Dates{}
+	return res, nil
+}
 
-			if hit.Source.DateStart != 0 {
-				dates.Start = new(time.Unix(0, hit.Source.DateStart).UTC())
-			}
+// BulkDelete removes the tasks from the index using a single request, regardless of their version; it is meant for
+// deleting documents which tasks don't exist anymore. Tasks not indexed are skipped.
+func (t *Task) BulkDelete(ctx context.Context, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	var buf bytes.Buffer
 
-			if hit.Source.DateDue != 0 {
-				dates.Due = new(time.Unix(0, hit.Source.DateDue).UTC())
-			}
+	enc := json.NewEncoder(&buf)
 
-			res[index].Dates = &dates
+	for _, id := range ids {
+		if err := enc.Encode(map[string]any{"delete": map[string]any{"_id": id}}); err != nil {
+			return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "json.NewEncoder.Encode")
 		}
 	}
 
-	return internal.SearchResults{
-		Tasks: res,
-		Total: hits.Hits.Total.Value,
-	}, nil
+	req := esv7api.BulkRequest{
+		Index:   t.index,
+		Body:    &buf,
+		Refresh: "true",
+	}
+
+	resp, err := req.Do(ctx, t.client)
+	if err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnavailable, "BulkRequest.Do")
+	}
+	defer resp.Body.Close()
+
+	if resp.IsError() {
+		return internal.NewErrorf(errorCode(resp.StatusCode), "BulkRequest.Do %d", resp.StatusCode)
+	}
+
+	//nolint: tagliatelle
+	var res struct {
+		Errors bool `json:"errors"`
+		Items  []struct {
+			Delete struct {
+				ID     string `json:"_id"`
+				Status int    `json:"status"`
+			} `json:"delete"`
+		} `json:"items"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "json.NewDecoder.Decode")
+	}
+
+	if !res.Errors {
+		return nil
+	}
+
+	for _, item := range res.Items {
+		if item.Delete.Status >= http.StatusBadRequest && item.Delete.Status != http.StatusNotFound {
+			return internal.NewErrorf(errorCode(item.Delete.Status), "BulkRequest.Do %s %d", item.Delete.ID, item.Delete.Status)
+		}
+	}
+
+	return nil
 }
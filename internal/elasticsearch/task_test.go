@@ -4,11 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"io"
-	"net/http"
-	"net/url"
 	"os"
-	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -69,6 +65,15 @@ func TestTask_All(t *testing.T) {
 			Start: &now,
 			Due:   &now,
 		},
+		Categories: []internal.Category{"work"},
+		Version:    3,
+		SubTasks: []internal.Task{
+			{
+				ID:          "test-456",
+				Description: "Test sub-task for elasticsearch",
+				Priority:    new(internal.PriorityLow),
+			},
+		},
 	}
 
 	//- Testing `Index` method
@@ -76,6 +81,16 @@ func TestTask_All(t *testing.T) {
 		t.Fatalf("Failed to index task: %v", err)
 	}
 
+	//- Older versions are rejected
+	stale := task
+	stale.Description = "Stale task"
+	stale.Version = 2
+
+	var ierr *internal.Error
+	if err := taskRepo.Index(ctx, stale); !errors.As(err, &ierr) || ierr.Code() != internal.ErrorCodeConflict {
+		t.Fatalf("Expected conflict indexing an older version, got %v", err)
+	}
+
 	time.Sleep(500 * time.Millisecond)
 
 	//- Testing `Search` method
@@ -96,8 +111,46 @@ func TestTask_All(t *testing.T) {
 		t.Fatalf("Searched task is not the same as the indexed one: %s", diff)
 	}
 
+	//- Testing `Search` method with filters, sorting and facets
+	results, err = taskRepo.Search(ctx, internal.SearchParams{
+		Priority: new(internal.PriorityHigh),
+		IsDone:   new(true),
+		DueDate:  internal.TimeRange{From: new(now.Add(-time.Hour)), To: new(now.Add(time.Hour))},
+		Sort:     internal.SearchSortDueDate,
+		Size:     10,
+	})
+	if err != nil {
+		t.Fatalf("Failed to search: %v", err)
+	}
+
+	expectedFacets := internal.SearchFacets{
+		Priorities: map[internal.Priority]int64{internal.PriorityHigh: 1},
+		Done:       1,
+	}
+
+	if results.Total != 1 || !cmp.Equal(expectedFacets, results.Facets) {
+		t.Fatalf("Expected one task and facets, got %d: %s", results.Total, cmp.Diff(expectedFacets, results.Facets))
+	}
+
+	results, err = taskRepo.Search(ctx, internal.SearchParams{
+		Description: new("Test"),
+		DueDate:     internal.TimeRange{To: new(now.Add(-time.Hour))},
+		Size:        10,
+	})
+	if err != nil {
+		t.Fatalf("Failed to search: %v", err)
+	}
+
+	if len(results.Tasks) != 0 {
+		t.Fatalf("Expected NOT to find task outside the range")
+	}
+
 	//- Testing `Delete` method
-	if err = taskRepo.Delete(ctx, task.ID); err != nil {
+	if err := taskRepo.Delete(ctx, task.ID, task.Version); !errors.As(err, &ierr) || ierr.Code() != internal.ErrorCodeConflict {
+		t.Fatalf("Expected conflict deleting the indexed version, got %v", err)
+	}
+
+	if err = taskRepo.Delete(ctx, task.ID, task.Version+1); err != nil {
 		t.Fatalf("Failed to delete task: %v", err)
 	}
 
@@ -117,74 +170,274 @@ func TestTask_All(t *testing.T) {
 	}
 }
 
-//-
+func TestMigrator_Migrate(t *testing.T) {
+	t.Parallel()
 
-var setupClient = sync.OnceValue(func() ElasticsearchClient { //nolint: gochecknoglobals
-	var res ElasticsearchClient
+	client := setupClient()
+	if client.err != nil {
+		t.Fatalf("Failed to setupClient: %v", client.err)
+	}
 
-	ctx := context.Background()
+	// The index was created when setting up the client, migrating again does nothing.
+	previous, err := elasticsearchtask.NewMigrator(client.elasticsearch).Migrate(t.Context(), elasticsearchtask.TaskIndexVersion)
+	if err != nil {
+		t.Fatalf("Failed to migrate: %v", err)
+	}
 
-	container, err := elasticsearchtest.Run(ctx, dockerImage)
+	if expected := elasticsearchtask.TaskIndexName(elasticsearchtask.TaskIndexVersion); previous != expected {
+		t.Fatalf("Expected alias to point to %s, got %s", expected, previous)
+	}
+
+	if _, err := elasticsearchtask.NewMigrator(client.elasticsearch).Migrate(t.Context(), 0); err == nil {
+		t.Fatalf("Expected error migrating to an unknown version")
+	}
+}
+
+func TestTask_BulkIndex(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	client := setupClient()
+	if client.err != nil {
+		t.Fatalf("Failed to setupClient: %v", client.err)
+	}
+
+	taskRepo := elasticsearchtask.NewTask(client.elasticsearch)
+
+	tasks := []internal.Task{
+		{ID: "bulk-1", Description: "First bulk task", Priority: new(internal.PriorityLow), Version: 2},
+		{ID: "bulk-2", Description: "Second bulk task", Priority: new(internal.PriorityHigh), Version: 1},
+	}
+
+	if err := taskRepo.BulkIndex(ctx, tasks); err != nil {
+		t.Fatalf("Failed to bulk index tasks: %v", err)
+	}
+
+	//- Older versions are skipped
+
+	stale := tasks[0]
+	stale.Description = "Stale bulk task"
+	stale.Version = 1
+
+	if err := taskRepo.BulkIndex(ctx, []internal.Task{stale}); err != nil {
+		t.Fatalf("Failed to bulk index stale task: %v", err)
+	}
+
+	found, err := taskRepo.Find(ctx, []string{"bulk-1", "bulk-2", "bulk-3"})
 	if err != nil {
-		res.err = fmt.Errorf("failed to start elasticsearch container: %w", err)
+		t.Fatalf("Failed to find tasks: %v", err)
+	}
 
-		return res
+	expected := map[string]internal.Task{
+		"bulk-1": tasks[0],
+		"bulk-2": tasks[1],
 	}
 
-	res.container = container
+	if diff := cmp.Diff(expected, found); diff != "" {
+		t.Fatalf("Found tasks are not the same as the indexed ones: %s", diff)
+	}
+}
+
+func TestTask_ListBulkDelete(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	client := setupClient()
+	if client.err != nil {
+		t.Fatalf("Failed to setupClient: %v", client.err)
+	}
+
+	taskRepo := elasticsearchtask.NewTask(client.elasticsearch)
 
-	//- Create index
+	tasks := []internal.Task{
+		{ID: "list-1", Description: "First listed task", Priority: new(internal.PriorityLow), Version: 1},
+		{ID: "list-2", Description: "Second listed task", Priority: new(internal.PriorityHigh), Version: 2},
+	}
 
-	hclient := http.Client{
-		Timeout: defaultIndexTimeout,
+	if err := taskRepo.BulkIndex(ctx, tasks); err != nil {
+		t.Fatalf("Failed to bulk index tasks: %v", err)
 	}
 
-	u, err := url.Parse(container.Settings.Address)
+	resp, err := client.elasticsearch.Indices.Refresh()
 	if err != nil {
-		res.err = fmt.Errorf("failed to parse container address: %w", err)
+		t.Fatalf("Failed to refresh index: %v", err)
+	}
 
-		return res
+	resp.Body.Close()
+
+	// Other tests use different IDs, "list-" sorts before the ones used by this test.
+	found, err := taskRepo.List(ctx, "list-", 2)
+	if err != nil {
+		t.Fatalf("Failed to list tasks: %v", err)
 	}
 
-	u.Path = "/tasks"
+	if diff := cmp.Diff(tasks, found); diff != "" {
+		t.Fatalf("Listed tasks are not the same as the indexed ones: %s", diff)
+	}
 
-	// Make sure the this payload is the same as the one used in `compose.yml`.
-	body := `{"mappings":{"properties":{"id":{"type":"keyword"},"description":{"type":"text"}}}}`
+	if err := taskRepo.BulkDelete(ctx, []string{"list-1", "list-missing"}); err != nil {
+		t.Fatalf("Failed to bulk delete tasks: %v", err)
+	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPut, u.String(), strings.NewReader(body))
+	found, err = taskRepo.List(ctx, "list-", 1)
 	if err != nil {
-		res.err = fmt.Errorf("failed to parse container address: %w", err)
+		t.Fatalf("Failed to list tasks: %v", err)
+	}
 
-		return res
+	if diff := cmp.Diff(tasks[1:], found); diff != "" {
+		t.Fatalf("Listed tasks are not the same as the remaining ones: %s", diff)
 	}
+}
 
-	req.Header.Set("Content-Type", "application/json")
+func TestTask_SearchCursor(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	client := setupClient()
+	if client.err != nil {
+		t.Fatalf("Failed to setupClient: %v", client.err)
+	}
+
+	taskRepo := elasticsearchtask.NewTask(client.elasticsearch)
+
+	tasks := []internal.Task{
+		{ID: "cursor-1", Description: "Paginated task one", Version: 1},
+		{ID: "cursor-2", Description: "Paginated task two", Version: 1},
+		{ID: "cursor-3", Description: "Paginated task three", Version: 1},
+	}
 
-	resp, err := hclient.Do(req)
+	if err := taskRepo.BulkIndex(ctx, tasks); err != nil {
+		t.Fatalf("Failed to bulk index tasks: %v", err)
+	}
+
+	time.Sleep(1 * time.Second)
+
+	params := internal.SearchParams{
+		Description: new("paginated"),
+		Size:        2,
+	}
+
+	first, err := taskRepo.Search(ctx, params)
 	if err != nil {
-		res.err = fmt.Errorf("failed to do http request: %w", err)
+		t.Fatalf("Failed to search: %v", err)
+	}
 
-		return res
+	if len(first.Tasks) != 2 || first.Cursor == "" {
+		t.Fatalf("Expected a full page and a cursor, got %d tasks", len(first.Tasks))
 	}
 
-	bodyResp, err := io.ReadAll(resp.Body)
+	params.Cursor = first.Cursor
+
+	second, err := taskRepo.Search(ctx, params)
 	if err != nil {
-		res.err = fmt.Errorf("failed to do read all response: %w", err)
+		t.Fatalf("Failed to search: %v", err)
+	}
 
-		return res
+	if len(second.Tasks) != 1 || second.Cursor != "" {
+		t.Fatalf("Expected the last task and no cursor, got %d tasks and %q", len(second.Tasks), second.Cursor)
+	}
+
+	found := map[string]bool{}
+
+	for _, task := range append(first.Tasks, second.Tasks...) {
+		found[task.ID] = true
+	}
+
+	if len(found) != len(tasks) {
+		t.Fatalf("Expected all the tasks once, got %v", found)
+	}
+
+	//- Invalid cursors are rejected
+
+	params.Cursor = "invalid"
+
+	var ierr *internal.Error
+	if _, err := taskRepo.Search(ctx, params); !errors.As(err, &ierr) || ierr.Code() != internal.ErrorCodeInvalidArgument {
+		t.Fatalf("Expected invalid argument using an invalid cursor, got %v", err)
 	}
+}
 
-	defer func() {
-		_ = resp.Body.Close()
-	}()
+func TestTask_Suggest(t *testing.T) {
+	t.Parallel()
 
-	if resp.StatusCode != http.StatusOK {
-		res.err = fmt.Errorf("failed to create index, status is not 200: %d - %s", resp.StatusCode, string(bodyResp))
+	ctx := t.Context()
+
+	client := setupClient()
+	if client.err != nil {
+		t.Fatalf("Failed to setupClient: %v", client.err)
+	}
+
+	taskRepo := elasticsearchtask.NewTask(client.elasticsearch)
+
+	tasks := []internal.Task{
+		{ID: "suggest-1", Description: "Buy groceries at the market", Version: 1},
+		{ID: "suggest-2", Description: "Call the plumber", Version: 1},
+		{ID: "suggest-3", Description: "Wash the dishes", Version: 1},
+		{ID: "suggest-4", Description: "Wash the dishes", Version: 1},
+		{ID: "suggest-5", Description: "Wash the dishes", Version: 1},
+		{ID: "suggest-6", Description: "Wash the car", Version: 1},
+	}
+
+	if err := taskRepo.BulkIndex(ctx, tasks); err != nil {
+		t.Fatalf("Failed to bulk index tasks: %v", err)
+	}
+
+	time.Sleep(1 * time.Second)
+
+	//- Words are matched by prefix
+
+	suggestions, err := taskRepo.Suggest(ctx, internal.SuggestParams{Prefix: "groc mark", Size: 5})
+	if err != nil {
+		t.Fatalf("Failed to suggest: %v", err)
+	}
+
+	if diff := cmp.Diff([]string{tasks[0].Description}, suggestions); diff != "" {
+		t.Fatalf("Suggestions do not match: %s", diff)
+	}
+
+	//- Duplicated descriptions are skipped without returning fewer suggestions
+
+	suggestions, err = taskRepo.Suggest(ctx, internal.SuggestParams{Prefix: "wash", Size: 2})
+	if err != nil {
+		t.Fatalf("Failed to suggest: %v", err)
+	}
+
+	if len(suggestions) != 2 || suggestions[0] == suggestions[1] {
+		t.Fatalf("Expected 2 distinct suggestions, got %v", suggestions)
+	}
+
+	//- Misspelled descriptions are found and highlighted
+
+	results, err := taskRepo.Search(ctx, internal.SearchParams{Description: new("plumbre"), Size: 10})
+	if err != nil {
+		t.Fatalf("Failed to search: %v", err)
+	}
+
+	expected := map[string][]string{tasks[1].ID: {"Call the <em>plumber</em>"}}
+
+	if diff := cmp.Diff(expected, results.Highlights); diff != "" {
+		t.Fatalf("Highlights do not match: %s", diff)
+	}
+}
+
+//-
+
+var setupClient = sync.OnceValue(func() ElasticsearchClient { //nolint: gochecknoglobals
+	var res ElasticsearchClient
+
+	ctx := context.Background()
+
+	container, err := elasticsearchtest.Run(ctx, dockerImage)
+	if err != nil {
+		res.err = fmt.Errorf("failed to start elasticsearch container: %w", err)
 
 		return res
 	}
 
-	//- end of creating index
+	res.container = container
 
 	cfg := elasticsearch.Config{
 		Addresses: []string{container.Settings.Address},
@@ -197,6 +450,17 @@ var setupClient = sync.OnceValue(func() ElasticsearchClient { //nolint: gocheckn
 		return res
 	}
 
+	//- Create index, the same way `compose.yml` does
+
+	ctx, cancel := context.WithTimeout(ctx, defaultIndexTimeout)
+	defer cancel()
+
+	if _, err := elasticsearchtask.NewMigrator(client).Migrate(ctx, elasticsearchtask.TaskIndexVersion); err != nil {
+		res.err = fmt.Errorf("failed to create index: %w", err)
+
+		return res
+	}
+
 	res.elasticsearch = client
 
 	return res
@@ -1,6 +1,7 @@
 package internal
 
 import (
+	"errors"
 	"fmt"
 )
 
@@ -19,10 +20,21 @@ const (
 	ErrorCodeUnknown ErrorCode = iota
 	ErrorCodeNotFound
 	ErrorCodeInvalidArgument
+	ErrorCodeConflict
+	ErrorCodeUnauthorized
+	ErrorCodeForbidden
+	ErrorCodeUnavailable
+	ErrorCodePreconditionFailed
 )
 
-// WrapErrorf returns a wrapped error.
+// WrapErrorf returns a wrapped error. When code is ErrorCodeUnknown and the wrapped error is, or wraps, an *Error
+// then the code of that error is used instead, this allows layers to wrap errors without losing their code.
 func WrapErrorf(orig error, code ErrorCode, format string, a ...any) error {
+	var ierr *Error
+	if code == ErrorCodeUnknown && errors.As(orig, &ierr) {
+		code = ierr.code
+	}
+
 	return &Error{
 		code: code,
 		orig: orig,
@@ -35,6 +47,13 @@ func NewErrorf(code ErrorCode, format string, a ...any) error {
 	return WrapErrorf(nil, code, format, a...)
 }
 
+// HasCode indicates whether the error is, or wraps, an *Error using the code.
+func HasCode(err error, code ErrorCode) bool {
+	var ierr *Error
+
+	return errors.As(err, &ierr) && ierr.code == code
+}
+
 // Error returns the message, when wrapping errors the wrapped error is returned.
 func (e *Error) Error() string {
 	if e.orig != nil {
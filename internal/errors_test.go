@@ -2,6 +2,7 @@ package internal_test
 
 import (
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/MarioCarrion/todo-api-microservice-example/internal"
@@ -39,6 +40,24 @@ func TestWrapErrorf(t *testing.T) {
 			expectedMsg:  "validation failed for Task on field description: original error",
 			expectedCode: internal.ErrorCodeInvalidArgument,
 		},
+		{
+			name:         "wrap internal error with unknown code",
+			orig:         internal.NewErrorf(internal.ErrorCodeNotFound, "not found"),
+			code:         internal.ErrorCodeUnknown,
+			format:       "repo.Find",
+			args:         nil,
+			expectedMsg:  "repo.Find: not found",
+			expectedCode: internal.ErrorCodeNotFound,
+		},
+		{
+			name:         "wrap internal error with explicit code",
+			orig:         internal.NewErrorf(internal.ErrorCodeNotFound, "not found"),
+			code:         internal.ErrorCodeConflict,
+			format:       "repo.Find",
+			args:         nil,
+			expectedMsg:  "repo.Find: not found",
+			expectedCode: internal.ErrorCodeConflict,
+		},
 		{
 			name:         "wrap nil error",
 			orig:         nil,
@@ -145,6 +164,48 @@ func TestNewErrorf(t *testing.T) {
 	}
 }
 
+func TestHasCode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{
+			name:     "same code",
+			err:      internal.NewErrorf(internal.ErrorCodeNotFound, "not found"),
+			expected: true,
+		},
+		{
+			name:     "wrapped error with same code",
+			err:      fmt.Errorf("wrapped: %w", internal.WrapErrorf(errors.New("original"), internal.ErrorCodeNotFound, "find")),
+			expected: true,
+		},
+		{
+			name: "different code",
+			err:  internal.NewErrorf(internal.ErrorCodeConflict, "conflict"),
+		},
+		{
+			name: "not an internal error",
+			err:  errors.New("not found"),
+		},
+		{
+			name: "nil error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if actual := internal.HasCode(tt.err, internal.ErrorCodeNotFound); actual != tt.expected {
+				t.Errorf("expected %t, got %t", tt.expected, actual)
+			}
+		})
+	}
+}
+
 func TestError_Error(t *testing.T) {
 	t.Parallel()
 
@@ -195,6 +256,26 @@ func TestErrorCode(t *testing.T) {
 			name: "ErrorCodeInvalidArgument",
 			code: internal.ErrorCodeInvalidArgument,
 		},
+		{
+			name: "ErrorCodeConflict",
+			code: internal.ErrorCodeConflict,
+		},
+		{
+			name: "ErrorCodeUnauthorized",
+			code: internal.ErrorCodeUnauthorized,
+		},
+		{
+			name: "ErrorCodeForbidden",
+			code: internal.ErrorCodeForbidden,
+		},
+		{
+			name: "ErrorCodeUnavailable",
+			code: internal.ErrorCodeUnavailable,
+		},
+		{
+			name: "ErrorCodePreconditionFailed",
+			code: internal.ErrorCodePreconditionFailed,
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,138 @@
+package internal
+
+import (
+	"slices"
+	"time"
+)
+
+const (
+	// EventTypeTaskCreated indicates a Task was created.
+	EventTypeTaskCreated EventType = "Task.Created"
+
+	// EventTypeTaskDeleted indicates a Task was deleted, only its ID and Version are included.
+	EventTypeTaskDeleted EventType = "Task.Deleted"
+
+	// EventTypeTaskUpdated indicates a Task was updated.
+	EventTypeTaskUpdated EventType = "Task.Updated"
+)
+
+// EventType indicates the change that happened to a Task.
+type EventType string
+
+// Event represents a change to a Task that is pending to be published.
+type Event struct {
+	ID       string
+	Type     EventType
+	Task     Task
+	Changes  *TaskChanges // Only set for Updated events.
+	Attempts int32
+}
+
+// Version returns the version of the Task after the event. Deleted events include the version of the deleted Task,
+// the deletion is considered the next version so it is ordered after the last change.
+func (e Event) Version() int64 {
+	if e.Type == EventTypeTaskDeleted {
+		return e.Task.Version + 1
+	}
+
+	return e.Task.Version
+}
+
+const (
+	// TaskFieldDescription indicates the description changed.
+	TaskFieldDescription TaskField = "description"
+
+	// TaskFieldPriority indicates the priority changed.
+	TaskFieldPriority TaskField = "priority"
+
+	// TaskFieldIsDone indicates the task was completed or reopened.
+	TaskFieldIsDone TaskField = "isDone"
+
+	// TaskFieldStartDate indicates the start date changed.
+	TaskFieldStartDate TaskField = "dates.start"
+
+	// TaskFieldDueDate indicates the due date changed, for example the task was rescheduled.
+	TaskFieldDueDate TaskField = "dates.due"
+
+	// TaskFieldSubTasks indicates the sub-tasks changed.
+	TaskFieldSubTasks TaskField = "subTasks"
+
+	// TaskFieldCategories indicates the categories changed.
+	TaskFieldCategories TaskField = "categories"
+)
+
+// TaskField identifies a Task attribute that changed.
+type TaskField string
+
+// TaskChanges describes how a Task changed, it is included in Updated events.
+type TaskChanges struct {
+	// Previous is the Task before the change.
+	Previous Task
+	// Fields lists the attributes that changed, sorted in the order they are defined.
+	Fields []TaskField
+}
+
+// NewTaskChanges returns the changes between both versions of a Task. Sub-tasks are compared using their values
+// because updating them replaces them.
+func NewTaskChanges(previous, current Task) TaskChanges {
+	return TaskChanges{
+		Previous: previous,
+		Fields:   changedFields(previous, current),
+	}
+}
+
+// Has indicates whether the field changed.
+func (c TaskChanges) Has(field TaskField) bool {
+	return slices.Contains(c.Fields, field)
+}
+
+func changedFields(previous, current Task) []TaskField {
+	var res []TaskField
+
+	if previous.Description != current.Description {
+		res = append(res, TaskFieldDescription)
+	}
+
+	if !equalPointers(previous.Priority, current.Priority, func(a, b Priority) bool { return a == b }) {
+		res = append(res, TaskFieldPriority)
+	}
+
+	if previous.IsDone != current.IsDone {
+		res = append(res, TaskFieldIsDone)
+	}
+
+	prevDates, currDates := PointerToValue(previous.Dates), PointerToValue(current.Dates)
+
+	if !equalPointers(prevDates.Start, currDates.Start, time.Time.Equal) {
+		res = append(res, TaskFieldStartDate)
+	}
+
+	if !equalPointers(prevDates.Due, currDates.Due, time.Time.Equal) {
+		res = append(res, TaskFieldDueDate)
+	}
+
+	if !slices.EqualFunc(previous.SubTasks, current.SubTasks, func(a, b Task) bool {
+		return len(changedFields(a, b)) == 0
+	}) {
+		res = append(res, TaskFieldSubTasks)
+	}
+
+	prevCategories, currCategories := slices.Clone(previous.Categories), slices.Clone(current.Categories)
+
+	slices.Sort(prevCategories)
+	slices.Sort(currCategories)
+
+	if !slices.Equal(prevCategories, currCategories) {
+		res = append(res, TaskFieldCategories)
+	}
+
+	return res
+}
+
+func equalPointers[T any](a, b *T, equal func(T, T) bool) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+
+	return equal(*a, *b)
+}
@@ -0,0 +1,117 @@
+package internal_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/MarioCarrion/todo-api-microservice-example/internal"
+)
+
+func TestNewTaskChanges(t *testing.T) {
+	t.Parallel()
+
+	due := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	task := internal.Task{
+		ID:          "1",
+		Description: "test",
+		Priority:    new(internal.PriorityLow),
+		Dates:       &internal.Dates{Due: &due},
+		SubTasks:    []internal.Task{{ID: "2", Description: "sub"}},
+		Categories:  []internal.Category{"a", "b"},
+		Version:     1,
+	}
+
+	tests := []struct {
+		name     string
+		update   func(*internal.Task)
+		expected []internal.TaskField
+	}{
+		{
+			name:   "OK: no changes",
+			update: func(_ *internal.Task) {},
+		},
+		{
+			name: "OK: completed",
+			update: func(t *internal.Task) {
+				t.IsDone = true
+			},
+			expected: []internal.TaskField{internal.TaskFieldIsDone},
+		},
+		{
+			name: "OK: rescheduled",
+			update: func(t *internal.Task) {
+				t.Dates = &internal.Dates{Due: new(due.Add(time.Hour))}
+			},
+			expected: []internal.TaskField{internal.TaskFieldDueDate},
+		},
+		{
+			name: "OK: same due date in a different location",
+			update: func(t *internal.Task) {
+				t.Dates = &internal.Dates{Due: new(due.In(time.FixedZone("test", 3600)))}
+			},
+		},
+		{
+			name: "OK: dates cleared",
+			update: func(t *internal.Task) {
+				t.Dates = nil
+			},
+			expected: []internal.TaskField{internal.TaskFieldDueDate},
+		},
+		{
+			name: "OK: sub-tasks replaced using the same values",
+			update: func(t *internal.Task) {
+				t.SubTasks = []internal.Task{{ID: "3", Description: "sub"}}
+			},
+		},
+		{
+			name: "OK: categories reordered",
+			update: func(t *internal.Task) {
+				t.Categories = []internal.Category{"b", "a"}
+			},
+		},
+		{
+			name: "OK: multiple",
+			update: func(t *internal.Task) {
+				t.Description = "changed"
+				t.Priority = nil
+				t.SubTasks = nil
+				t.Categories = []internal.Category{"a"}
+			},
+			expected: []internal.TaskField{
+				internal.TaskFieldDescription,
+				internal.TaskFieldPriority,
+				internal.TaskFieldSubTasks,
+				internal.TaskFieldCategories,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			current := task
+			current.Version++
+			tt.update(&current)
+
+			actual := internal.NewTaskChanges(task, current)
+
+			if diff := cmp.Diff(task, actual.Previous); diff != "" {
+				t.Fatalf("expected previous task does not match: %s", diff)
+			}
+
+			if diff := cmp.Diff(tt.expected, actual.Fields); diff != "" {
+				t.Fatalf("expected fields do not match: %s", diff)
+			}
+
+			for _, field := range tt.expected {
+				if !actual.Has(field) {
+					t.Fatalf("expected field %s to be changed", field)
+				}
+			}
+		})
+	}
+}
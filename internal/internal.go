@@ -11,3 +11,20 @@ func PointerToValue[T any](ptr *T) T { //nolint: ireturn
 
 	return *ptr
 }
+
+// Optional represents a value that may be unset, set to null or set to a value; it is used for partial updates
+// where unset values are kept and null values are cleared.
+type Optional[T any] struct {
+	// Value is the new value, nil means null.
+	Value *T
+	// Set indicates whether the value was set, including being set to null.
+	Set bool
+}
+
+// NewOptional returns an Optional set to the received value, a nil value means null.
+func NewOptional[T any](value *T) Optional[T] {
+	return Optional[T]{
+		Value: value,
+		Set:   true,
+	}
+}
@@ -27,3 +27,33 @@ func Test_PointerToValue(t *testing.T) {
 		}
 	})
 }
+
+func Test_NewOptional(t *testing.T) {
+	t.Parallel()
+
+	t.Run("unset", func(t *testing.T) {
+		t.Parallel()
+
+		var val internal.Optional[int]
+
+		if val.Set || val.Value != nil {
+			t.Errorf("expected unset value, got %v", val)
+		}
+	})
+
+	t.Run("null", func(t *testing.T) {
+		t.Parallel()
+
+		if val := internal.NewOptional[int](nil); !val.Set || val.Value != nil {
+			t.Errorf("expected null value, got %v", val)
+		}
+	})
+
+	t.Run("value", func(t *testing.T) {
+		t.Parallel()
+
+		if val := internal.NewOptional(new(42)); !val.Set || *val.Value != 42 {
+			t.Errorf("expected value, got %v", val)
+		}
+	})
+}
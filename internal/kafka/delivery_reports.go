@@ -0,0 +1,28 @@
+package kafka
+
+import (
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"go.uber.org/zap"
+)
+
+// DeliveryReports handles the events sent to the producer's events channel, producer-level errors are logged. Delivery
+// reports of the messages published by Task are sent to their own delivery channel instead, see Task.
+type DeliveryReports struct {
+	logger *zap.Logger
+}
+
+// NewDeliveryReports instantiates the DeliveryReports handler.
+func NewDeliveryReports(logger *zap.Logger) *DeliveryReports {
+	return &DeliveryReports{
+		logger: logger,
+	}
+}
+
+// Run handles the events until the channel is closed, which happens after the producer is closed.
+func (d *DeliveryReports) Run(events <-chan kafka.Event) {
+	for event := range events {
+		if evt, ok := event.(kafka.Error); ok {
+			d.logger.Error("Producer error", zap.Error(evt))
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package kafka_test
+
+import (
+	"testing"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	kafkatask "github.com/MarioCarrion/todo-api-microservice-example/internal/kafka"
+)
+
+func TestDeliveryReports_Run(t *testing.T) {
+	t.Parallel()
+
+	topic := "tasks"
+
+	events := make(chan kafka.Event, 3)
+	events <- &kafka.Message{TopicPartition: kafka.TopicPartition{Topic: &topic}}
+	events <- kafka.NewError(kafka.ErrAllBrokersDown, "down", false)
+	events <- kafka.NewError(kafka.ErrTransport, "transport", false)
+
+	close(events)
+
+	core, logs := observer.New(zapcore.ErrorLevel)
+
+	kafkatask.NewDeliveryReports(zap.New(core)).Run(events)
+
+	if count := logs.FilterMessage("Producer error").Len(); count != 2 {
+		t.Fatalf("expected 2 producer errors logged, got %d", count)
+	}
+}
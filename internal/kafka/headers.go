@@ -0,0 +1,79 @@
+package kafka
+
+import (
+	"strings"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+
+	"github.com/MarioCarrion/todo-api-microservice-example/internal"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/cloudevents"
+)
+
+// headerCarrier adapts the message headers to be used for propagating the trace context.
+type headerCarrier []kafka.Header
+
+// Get returns the value of the header.
+func (h *headerCarrier) Get(key string) string {
+	for _, header := range *h {
+		if header.Key == key {
+			return string(header.Value)
+		}
+	}
+
+	return ""
+}
+
+// Set sets the value of the header, replacing existing ones.
+func (h *headerCarrier) Set(key, value string) {
+	for i, header := range *h {
+		if header.Key == key {
+			(*h)[i].Value = []byte(value)
+
+			return
+		}
+	}
+
+	*h = append(*h, kafka.Header{Key: key, Value: []byte(value)})
+}
+
+// Keys returns the header keys.
+func (h *headerCarrier) Keys() []string {
+	res := make([]string, len(*h))
+
+	for i, header := range *h {
+		res[i] = header.Key
+	}
+
+	return res
+}
+
+// Decode returns the event in the message using the CloudEvents Kafka binding in binary content mode.
+func Decode(msg *kafka.Message) (internal.Event, error) {
+	var contentType string
+
+	attrs := make(map[string]string)
+
+	for _, header := range msg.Headers {
+		if header.Key == HeaderContentType {
+			contentType = string(header.Value)
+
+			continue
+		}
+
+		if name, ok := strings.CutPrefix(header.Key, HeaderPrefix); ok {
+			attrs[name] = string(header.Value)
+		}
+	}
+
+	envelope, err := cloudevents.FromAttributes(attrs, contentType, msg.Value)
+	if err != nil {
+		return internal.Event{}, internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "cloudevents.FromAttributes")
+	}
+
+	event, err := envelope.Event()
+	if err != nil {
+		return internal.Event{}, internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "envelope.Event")
+	}
+
+	return event, nil
+}
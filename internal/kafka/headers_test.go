@@ -0,0 +1,29 @@
+package kafka
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_headerCarrier(t *testing.T) {
+	t.Parallel()
+
+	var headers headerCarrier
+
+	headers.Set("traceparent", "1")
+	headers.Set("tracestate", "2")
+	headers.Set("traceparent", "3")
+
+	if val := headers.Get("traceparent"); val != "3" {
+		t.Fatalf("expected replaced value, got %s", val)
+	}
+
+	if val := headers.Get("missing"); val != "" {
+		t.Fatalf("expected empty value, got %s", val)
+	}
+
+	if diff := cmp.Diff([]string{"traceparent", "tracestate"}, headers.Keys()); diff != "" {
+		t.Fatalf("expected keys do not match: %s", diff)
+	}
+}
@@ -0,0 +1,91 @@
+package kafka
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+)
+
+type partition struct {
+	topic string
+	id    int32
+}
+
+// pending represents the messages of a partition that are being processed, sorted by offset.
+type pending struct {
+	offsets []kafka.Offset
+	done    map[kafka.Offset]struct{}
+}
+
+// offsets keeps track of the messages being processed concurrently, to only commit offsets after all the previous
+// messages in the same partition are done.
+type offsets struct {
+	mu         sync.Mutex
+	partitions map[partition]*pending
+}
+
+func newOffsets() *offsets {
+	return &offsets{
+		partitions: make(map[partition]*pending),
+	}
+}
+
+// add indicates the message was received.
+func (o *offsets) add(tp kafka.TopicPartition) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	key := partition{topic: *tp.Topic, id: tp.Partition}
+
+	p, ok := o.partitions[key]
+	if !ok || (len(p.offsets) > 0 && tp.Offset <= p.offsets[len(p.offsets)-1]) {
+		// New partition, or the partition was rewound after a rebalance.
+		p = &pending{done: make(map[kafka.Offset]struct{})}
+		o.partitions[key] = p
+	}
+
+	p.offsets = append(p.offsets, tp.Offset)
+}
+
+// done indicates the message was processed, it returns the offset to commit when all the previous messages are
+// done as well.
+func (o *offsets) done(tp kafka.TopicPartition) (kafka.TopicPartition, bool) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	p, ok := o.partitions[partition{topic: *tp.Topic, id: tp.Partition}]
+	if !ok || !slices.Contains(p.offsets, tp.Offset) {
+		return kafka.TopicPartition{}, false
+	}
+
+	p.done[tp.Offset] = struct{}{}
+
+	var (
+		last kafka.Offset
+		n    int
+	)
+
+	for _, offset := range p.offsets {
+		if _, ok := p.done[offset]; !ok {
+			break
+		}
+
+		delete(p.done, offset)
+
+		last = offset
+		n++
+	}
+
+	if n == 0 {
+		return kafka.TopicPartition{}, false
+	}
+
+	p.offsets = p.offsets[n:]
+
+	return kafka.TopicPartition{
+		Topic:     tp.Topic,
+		Partition: tp.Partition,
+		Offset:    last + 1,
+	}, true
+}
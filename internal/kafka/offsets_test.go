@@ -0,0 +1,52 @@
+package kafka
+
+import (
+	"testing"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+)
+
+func Test_offsets(t *testing.T) {
+	t.Parallel()
+
+	topic := "tasks"
+
+	tp := func(partition int32, offset kafka.Offset) kafka.TopicPartition {
+		return kafka.TopicPartition{Topic: &topic, Partition: partition, Offset: offset}
+	}
+
+	o := newOffsets()
+
+	for _, offset := range []kafka.Offset{10, 11, 12} {
+		o.add(tp(0, offset))
+	}
+
+	o.add(tp(1, 5))
+
+	if _, ok := o.done(tp(0, 11)); ok {
+		t.Fatalf("expected no commit while previous offsets are pending")
+	}
+
+	if next, ok := o.done(tp(1, 5)); !ok || next.Partition != 1 || next.Offset != 6 {
+		t.Fatalf("expected commit of partition 1 offset 6, got %v %t", next, ok)
+	}
+
+	if next, ok := o.done(tp(0, 10)); !ok || next.Offset != 12 {
+		t.Fatalf("expected commit of offset 12, got %v %t", next, ok)
+	}
+
+	if _, ok := o.done(tp(0, 10)); ok {
+		t.Fatalf("expected no commit of offsets already done")
+	}
+
+	// Rewound after a rebalance.
+	o.add(tp(0, 11))
+
+	if next, ok := o.done(tp(0, 11)); !ok || next.Offset != 12 {
+		t.Fatalf("expected commit of offset 12, got %v %t", next, ok)
+	}
+
+	if _, ok := o.done(tp(0, 12)); ok {
+		t.Fatalf("expected no commit of offsets not tracked")
+	}
+}
@@ -0,0 +1,103 @@
+package kafka
+
+import (
+	"context"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+
+	"github.com/MarioCarrion/todo-api-microservice-example/internal"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/consumer"
+)
+
+const pollTimeoutMs = 150
+
+// TaskSource represents the Message Broker adapter used to receive Task events.
+//
+// Offsets are committed in order, once all the previous messages in the same partition are acked or nacked,
+// because of that nacked messages are not received again.
+type TaskSource struct {
+	client  *kafka.Consumer
+	offsets *offsets
+}
+
+// NewTaskSource instantiates the Task message broker adapter, client must be subscribed to the topic and have
+// automatic commits disabled.
+func NewTaskSource(client *kafka.Consumer) *TaskSource {
+	return &TaskSource{
+		client:  client,
+		offsets: newOffsets(),
+	}
+}
+
+// Receive polls messages until the context is canceled.
+func (s *TaskSource) Receive(ctx context.Context) (<-chan consumer.Delivery, error) {
+	res := make(chan consumer.Delivery)
+
+	go func() {
+		defer close(res)
+
+		for ctx.Err() == nil {
+			msg, ok := s.client.Poll(pollTimeoutMs).(*kafka.Message)
+			if !ok {
+				continue
+			}
+
+			s.offsets.add(msg.TopicPartition)
+
+			select {
+			case <-ctx.Done():
+				// Not committed, the message will be received again.
+				return
+			case res <- s.newDelivery(msg):
+			}
+		}
+	}()
+
+	return res, nil
+}
+
+func (s *TaskSource) newDelivery(msg *kafka.Message) *delivery {
+	res := delivery{
+		source: s,
+		tp:     msg.TopicPartition,
+	}
+
+	res.event, res.err = Decode(msg)
+
+	return &res
+}
+
+func (s *TaskSource) commit(tp kafka.TopicPartition) error {
+	next, ok := s.offsets.done(tp)
+	if !ok {
+		return nil
+	}
+
+	if _, err := s.client.CommitOffsets([]kafka.TopicPartition{next}); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "client.CommitOffsets")
+	}
+
+	return nil
+}
+
+type delivery struct {
+	source *TaskSource
+	tp     kafka.TopicPartition
+	event  internal.Event
+	err    error
+}
+
+// Event returns the decoded message.
+func (d *delivery) Event() (internal.Event, error) {
+	return d.event, d.err
+}
+
+// Ack commits the message offset.
+func (d *delivery) Ack(_ context.Context) error {
+	return d.source.commit(d.tp)
+}
+
+// Nack commits the message offset, partitions are consumed in order so the message can't be received again.
+func (d *delivery) Nack(_ context.Context, _ error) error {
+	return d.source.commit(d.tp)
+}
@@ -2,81 +2,109 @@
 package kafka
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 
 	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"go.opentelemetry.io/otel/propagation"
 
 	"github.com/MarioCarrion/todo-api-microservice-example/internal"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/cloudevents"
 )
 
 const (
 	// TaskCreatedMessageType is the channel used when a Task is created.
-	TaskCreatedMessageType = "Task.Created"
+	TaskCreatedMessageType = internal.EventTypeTaskCreated
 
 	// TaskDeletedMessageType is the channel used when a Task is deleted.
-	TaskDeletedMessageType = "Task.Deleted"
+	TaskDeletedMessageType = internal.EventTypeTaskDeleted
 
 	// TaskUpdatedMessageType is the channel used when a Task is updated.
-	TaskUpdatedMessageType = "Task.Updated"
+	TaskUpdatedMessageType = internal.EventTypeTaskUpdated
+
+	// HeaderPrefix is the prefix of the headers used for the CloudEvents context attributes.
+	HeaderPrefix = "ce_"
+
+	// HeaderContentType is the header used for the CloudEvents content type.
+	HeaderContentType = "content-type"
 )
 
 // Task represents the Message Broker publisher used to publish Task records.
 type Task struct {
-	producer  *kafka.Producer
-	topicName string
+	producer    *kafka.Producer
+	topicName   string
+	contentType string
 }
 
-type event struct {
-	Type  string        `json:"type"`
-	Value internal.Task `json:"value"`
-}
-
-// NewTask instantiates the Task message broker publisher.
-func NewTask(producer *kafka.Producer, topicName string) *Task {
+// NewTask instantiates the Task message broker publisher, the content type is used for encoding the event data.
+func NewTask(producer *kafka.Producer, topicName, contentType string) *Task {
 	return &Task{
-		topicName: topicName,
-		producer:  producer,
+		topicName:   topicName,
+		producer:    producer,
+		contentType: contentType,
 	}
 }
 
 // Created publishes a message indicating a task was created.
 func (t *Task) Created(ctx context.Context, task internal.Task) error {
-	return t.publish(ctx, TaskCreatedMessageType, task)
+	return t.publish(ctx, TaskCreatedMessageType, task, nil)
 }
 
-// Deleted publishes a message indicating a task was deleted.
-func (t *Task) Deleted(ctx context.Context, id string) error {
-	return t.publish(ctx, TaskDeletedMessageType, internal.Task{ID: id})
+// Deleted publishes a message indicating a task was deleted, the message includes the ID and the version of the
+// deleted task.
+func (t *Task) Deleted(ctx context.Context, id string, version int64) error {
+	return t.publish(ctx, TaskDeletedMessageType, internal.Task{ID: id, Version: version}, nil)
 }
 
-// Updated publishes a message indicating a task was updated.
-func (t *Task) Updated(ctx context.Context, task internal.Task) error {
-	return t.publish(ctx, TaskUpdatedMessageType, task)
+// Updated publishes a message indicating a task was updated, the message includes the changes.
+func (t *Task) Updated(ctx context.Context, task internal.Task, changes internal.TaskChanges) error {
+	return t.publish(ctx, TaskUpdatedMessageType, task, &changes)
 }
 
-func (t *Task) publish(_ context.Context, msgType string, task internal.Task) error {
-	var b bytes.Buffer
-
-	evt := event{
-		Type:  msgType,
-		Value: task,
+// publish uses the CloudEvents Kafka binding in binary content mode: context attributes are sent as headers and the
+// data as the message value.
+func (t *Task) publish(ctx context.Context, msgType internal.EventType, task internal.Task, changes *internal.TaskChanges) error {
+	envelope, err := cloudevents.New(internal.Event{Type: msgType, Task: task, Changes: changes}, t.contentType)
+	if err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "cloudevents.New")
 	}
 
-	if err := json.NewEncoder(&b).Encode(evt); err != nil {
-		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "json.Encode")
+	headers := headerCarrier{{Key: HeaderContentType, Value: []byte(envelope.DataContentType)}}
+
+	for name, val := range envelope.Attributes() {
+		headers.Set(HeaderPrefix+name, val)
 	}
 
+	propagation.TraceContext{}.Inject(ctx, &headers)
+
+	// Messages are keyed by Task so all the events of the same Task are published to the same partition, in order.
+	// The delivery report is waited for so events are only considered published once the broker acknowledged them.
+	delivery := make(chan kafka.Event, 1)
+
 	if err := t.producer.Produce(&kafka.Message{
 		TopicPartition: kafka.TopicPartition{
 			Topic:     &t.topicName,
 			Partition: kafka.PartitionAny,
 		},
-		Value: b.Bytes(),
-	}, nil); err != nil {
+		Key:     []byte(task.ID),
+		Value:   envelope.Data,
+		Headers: headers,
+	}, delivery); err != nil {
 		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "product.Producer")
 	}
 
+	select {
+	case <-ctx.Done():
+		return internal.WrapErrorf(ctx.Err(), internal.ErrorCodeUnknown, "waiting for delivery report")
+	case event := <-delivery:
+		msg, ok := event.(*kafka.Message)
+		if !ok {
+			return internal.NewErrorf(internal.ErrorCodeUnknown, "unexpected delivery report: %s", event)
+		}
+
+		if msg.TopicPartition.Error != nil {
+			return internal.WrapErrorf(msg.TopicPartition.Error, internal.ErrorCodeUnknown, "delivery report")
+		}
+	}
+
 	return nil
 }
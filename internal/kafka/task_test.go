@@ -1,9 +1,7 @@
 package kafka_test
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
@@ -17,6 +15,7 @@ import (
 	kafkatest "github.com/testcontainers/testcontainers-go/modules/kafka"
 
 	"github.com/MarioCarrion/todo-api-microservice-example/internal"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/cloudevents"
 	kafkatask "github.com/MarioCarrion/todo-api-microservice-example/internal/kafka"
 )
 
@@ -54,17 +53,12 @@ func TestTask_All(t *testing.T) { //nolint: tparallel
 		t.Fatalf("Failed to setupClient: %v", client.err)
 	}
 
-	type Event struct {
-		Type  string
-		Value internal.Task
-	}
-
-	taskPub := kafkatask.NewTask(client.producer, topicName)
+	taskPub := kafkatask.NewTask(client.producer, topicName, cloudevents.ContentTypeJSON)
 
 	tests := []struct {
 		name   string
 		call   func(t *testing.T)
-		verify func(t *testing.T, evnt Event)
+		verify func(t *testing.T, evnt internal.Event)
 	}{
 		{
 			name: "Created",
@@ -82,7 +76,7 @@ func TestTask_All(t *testing.T) { //nolint: tparallel
 					t.Fatalf("Failed to publish created event: %v", err)
 				}
 			},
-			verify: func(t *testing.T, evnt Event) {
+			verify: func(t *testing.T, evnt internal.Event) {
 				t.Helper()
 
 				if evnt.Type != kafkatask.TaskCreatedMessageType {
@@ -96,7 +90,7 @@ func TestTask_All(t *testing.T) { //nolint: tparallel
 					IsDone:      true,
 				}
 
-				if diff := cmp.Diff(evnt.Value, expected); diff != "" {
+				if diff := cmp.Diff(evnt.Task, expected); diff != "" {
 					t.Fatalf("Received created task event is not the same as the published one: %s", diff)
 				}
 			},
@@ -106,11 +100,11 @@ func TestTask_All(t *testing.T) { //nolint: tparallel
 			call: func(t *testing.T) {
 				t.Helper()
 
-				if err := taskPub.Deleted(t.Context(), "task-id-123-456"); err != nil {
+				if err := taskPub.Deleted(t.Context(), "task-id-123-456", 2); err != nil {
 					t.Fatalf("Failed to publish deleted event: %v", err)
 				}
 			},
-			verify: func(t *testing.T, evnt Event) {
+			verify: func(t *testing.T, evnt internal.Event) {
 				t.Helper()
 
 				if evnt.Type != kafkatask.TaskDeletedMessageType {
@@ -118,10 +112,11 @@ func TestTask_All(t *testing.T) { //nolint: tparallel
 				}
 
 				expected := internal.Task{
-					ID: "task-id-123-456",
+					ID:      "task-id-123-456",
+					Version: 2,
 				}
 
-				if diff := cmp.Diff(evnt.Value, expected); diff != "" {
+				if diff := cmp.Diff(evnt.Task, expected); diff != "" {
 					t.Fatalf("Received deleted task event is not the same as the published one: %s", diff)
 				}
 			},
@@ -138,11 +133,14 @@ func TestTask_All(t *testing.T) { //nolint: tparallel
 					IsDone:      true,
 				}
 
-				if err := taskPub.Updated(t.Context(), task); err != nil {
+				previous := task
+				previous.IsDone = false
+
+				if err := taskPub.Updated(t.Context(), task, internal.NewTaskChanges(previous, task)); err != nil {
 					t.Fatalf("Failed to publish updated event: %v", err)
 				}
 			},
-			verify: func(t *testing.T, evnt Event) {
+			verify: func(t *testing.T, evnt internal.Event) {
 				t.Helper()
 
 				if evnt.Type != kafkatask.TaskUpdatedMessageType {
@@ -156,9 +154,13 @@ func TestTask_All(t *testing.T) { //nolint: tparallel
 					IsDone:      true,
 				}
 
-				if diff := cmp.Diff(evnt.Value, expected); diff != "" {
+				if diff := cmp.Diff(evnt.Task, expected); diff != "" {
 					t.Fatalf("Received updated task event is not the same as the published one: %s", diff)
 				}
+
+				if evnt.Changes == nil || !evnt.Changes.Has(internal.TaskFieldIsDone) || evnt.Changes.Previous.IsDone {
+					t.Fatalf("Received updated task event changes do not match: %+v", evnt.Changes)
+				}
 			},
 		},
 	}
@@ -179,9 +181,14 @@ func TestTask_All(t *testing.T) { //nolint: tparallel
 				t.Fatalf("Failed to read message: %v", err)
 			}
 
-			var evt Event
+			headers := make(map[string]string)
+
+			for _, header := range msg.Headers {
+				headers[header.Key] = string(header.Value)
+			}
 
-			if err := json.NewDecoder(bytes.NewReader(msg.Value)).Decode(&evt); err != nil {
+			evt, err := kafkatask.Decode(msg)
+			if err != nil {
 				t.Errorf("Failed to decode message: %v", err)
 
 				if _, err := client.consumer.CommitMessage(msg); err != nil {
@@ -189,6 +196,16 @@ func TestTask_All(t *testing.T) { //nolint: tparallel
 				}
 			}
 
+			if string(msg.Key) != evt.Task.ID || headers[kafkatask.HeaderPrefix+cloudevents.AttributeSubject] != evt.Task.ID {
+				t.Fatalf("Expected message key and subject %s, got %s: %v", evt.Task.ID, msg.Key, headers)
+			}
+
+			if headers[kafkatask.HeaderContentType] != cloudevents.ContentTypeJSON ||
+				headers[kafkatask.HeaderPrefix+cloudevents.AttributeSpecVersion] != cloudevents.SpecVersion ||
+				headers[kafkatask.HeaderPrefix+cloudevents.AttributeSchemaVersion] != cloudevents.SchemaVersion {
+				t.Fatalf("Expected message headers do not match: %v", headers)
+			}
+
 			tt.verify(t, evt)
 		})
 	}
@@ -0,0 +1,86 @@
+package memcached
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/bradfitz/gomemcache/memcache"
+
+	"github.com/MarioCarrion/todo-api-microservice-example/internal"
+)
+
+// Client implements cache.Cache using memcached.
+type Client struct {
+	client *memcache.Client
+}
+
+// NewClient instantiates the memcached cache.Cache.
+func NewClient(client *memcache.Client) *Client {
+	return &Client{
+		client: client,
+	}
+}
+
+// Get returns the value stored in key.
+func (c *Client) Get(_ context.Context, key string) ([]byte, error) {
+	item, err := c.client.Get(key)
+	if err != nil {
+		return nil, internal.WrapErrorf(err, errorCode(err), "client.Get")
+	}
+
+	return item.Value, nil
+}
+
+// Set stores the value in key, it expires after expiration.
+func (c *Client) Set(_ context.Context, key string, value []byte, expiration time.Duration) error {
+	err := c.client.Set(&memcache.Item{
+		Key:        key,
+		Value:      value,
+		Expiration: int32(time.Now().Add(expiration).Unix()), //nolint:gosec
+	})
+	if err != nil {
+		return internal.WrapErrorf(err, errorCode(err), "client.Set")
+	}
+
+	return nil
+}
+
+// Add stores the value in key without expiring, unless it already exists.
+func (c *Client) Add(_ context.Context, key string, value []byte) error {
+	if err := c.client.Add(&memcache.Item{Key: key, Value: value}); err != nil {
+		return internal.WrapErrorf(err, errorCode(err), "client.Add")
+	}
+
+	return nil
+}
+
+// Increment increases the number stored in key by delta.
+func (c *Client) Increment(_ context.Context, key string, delta uint64) (uint64, error) {
+	res, err := c.client.Increment(key, delta)
+	if err != nil {
+		return 0, internal.WrapErrorf(err, errorCode(err), "client.Increment")
+	}
+
+	return res, nil
+}
+
+// Delete deletes the value stored in key.
+func (c *Client) Delete(_ context.Context, key string) error {
+	if err := c.client.Delete(key); err != nil && !errors.Is(err, memcache.ErrCacheMiss) {
+		return internal.WrapErrorf(err, errorCode(err), "client.Delete")
+	}
+
+	return nil
+}
+
+func errorCode(err error) internal.ErrorCode {
+	switch {
+	case errors.Is(err, memcache.ErrCacheMiss):
+		return internal.ErrorCodeNotFound
+	case errors.Is(err, memcache.ErrNotStored):
+		return internal.ErrorCodeConflict
+	}
+
+	return internal.ErrorCodeUnknown
+}
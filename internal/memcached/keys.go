@@ -0,0 +1,39 @@
+package memcached
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+)
+
+const (
+	// taskNamespace and searchNamespace include the version of the cached values, increase them when the encoded types
+	// or the values used for building the keys change so previously cached values are not used.
+	taskNamespace   = "task:2"
+	searchNamespace = "search:3"
+)
+
+// KeyCodec builds the keys used for caching values. Keys must be valid memcached keys: up to 250 bytes without spaces
+// or control characters.
+type KeyCodec interface {
+	// Key returns the key identifying the values in the namespace, different values must return different keys.
+	Key(namespace string, values ...string) string
+}
+
+// SHA256KeyCodec builds keys using the SHA-256 hash of the values, prefixed with the codec version and the namespace,
+// for example "h1:task:1:<hex encoded hash>". Namespaces must be valid memcached keys.
+type SHA256KeyCodec struct{}
+
+// Key returns the key identifying the values in the namespace.
+func (SHA256KeyCodec) Key(namespace string, values ...string) string {
+	hash := sha256.New()
+
+	// Values are prefixed with their length so different values never write the same bytes, like "a", "bc" and "ab",
+	// "c".
+	for _, value := range values {
+		_, _ = hash.Write(binary.AppendUvarint(nil, uint64(len(value))))
+		_, _ = hash.Write([]byte(value))
+	}
+
+	return "h1:" + namespace + ":" + hex.EncodeToString(hash.Sum(nil))
+}
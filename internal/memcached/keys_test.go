@@ -0,0 +1,174 @@
+package memcached_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode"
+
+	"github.com/MarioCarrion/todo-api-microservice-example/internal"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/cache"
+	memcachedtask "github.com/MarioCarrion/todo-api-microservice-example/internal/memcached"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/memcached/memcachedtesting"
+)
+
+func TestSHA256KeyCodec_Key(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		values []string
+	}{
+		{
+			name:   "ASCII",
+			values: []string{"buy milk"},
+		},
+		{
+			name:   "Unicode",
+			values: []string{"comprar café ☕ 牛乳"},
+		},
+		{
+			name:   "Control characters",
+			values: []string{"buy\r\nmilk\x00now"},
+		},
+		{
+			name:   "Long",
+			values: []string{strings.Repeat("milk ", 1000)},
+		},
+		{
+			name:   "Multiple values",
+			values: []string{"buy", "milk"},
+		},
+		{
+			name:   "Multiple values concatenated",
+			values: []string{"buym", "ilk"},
+		},
+		{
+			name: "Empty",
+		},
+	}
+
+	codec := memcachedtask.SHA256KeyCodec{}
+	keys := make(map[string]string)
+
+	for _, tt := range tests {
+		key := codec.Key("task:1", tt.values...)
+
+		if len(key) > 250 {
+			t.Errorf("%s: expected key with up to 250 bytes, got %d", tt.name, len(key))
+		}
+
+		if i := strings.IndexFunc(key, func(r rune) bool { return r > unicode.MaxASCII || r <= ' ' }); i != -1 {
+			t.Errorf("%s: expected key without spaces, control or non ASCII characters, got %q", tt.name, key)
+		}
+
+		if !strings.HasPrefix(key, "h1:task:1:") {
+			t.Errorf("%s: expected key prefixed with the version and namespace, got %q", tt.name, key)
+		}
+
+		if other, ok := keys[key]; ok {
+			t.Errorf("%s: expected unique key, got the same one as %s", tt.name, other)
+		}
+
+		keys[key] = tt.name
+	}
+
+	if codec.Key("task:1", "test") == codec.Key("search:1", "test") {
+		t.Errorf("expected different keys per namespace")
+	}
+}
+
+func TestSearchableTask_Search_Keys(t *testing.T) { //nolint: paralleltest
+	client := setupClient()
+
+	tests := []struct {
+		name        string
+		description string
+	}{
+		{
+			name:        "Unicode",
+			description: "comprar café ☕ 牛乳",
+		},
+		{
+			name:        "Long",
+			description: strings.Repeat("buy milk ", 100),
+		},
+		{
+			name:        "Control characters",
+			description: "buy\r\nmilk",
+		},
+	}
+
+	for _, tt := range tests { //nolint: paralleltest
+		t.Run(tt.name, func(t *testing.T) {
+			store := &memcachedtesting.FakeSearchableTaskStore{}
+			store.SearchReturns(internal.SearchResults{Total: 1}, nil)
+
+			task := memcachedtask.NewSearchableTask(memcachedtask.NewClient(client.client), store)
+
+			for range 2 {
+				if _, err := task.Search(t.Context(), internal.SearchParams{Description: new(tt.description)}); err != nil {
+					t.Fatalf("Failed to search tasks: %v", err)
+				}
+			}
+
+			// Second call should hit cache
+			if count := store.SearchCallCount(); count != 1 {
+				t.Errorf("Expected store.Search to be called once, got %d", count)
+			}
+		})
+	}
+}
+
+func TestSearchableTask_Search_KeysOptionalValues(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		unset internal.SearchParams
+		zero  internal.SearchParams
+	}{
+		{
+			name: "Description",
+			zero: internal.SearchParams{Description: new("")},
+		},
+		{
+			name: "Priority",
+			zero: internal.SearchParams{Priority: new(internal.PriorityNone)},
+		},
+		{
+			name: "IsDone",
+			zero: internal.SearchParams{IsDone: new(false)},
+		},
+		{
+			name: "StartDate",
+			zero: internal.SearchParams{StartDate: internal.TimeRange{From: new(time.Unix(0, 0))}},
+		},
+		{
+			name:  "DueDate",
+			unset: internal.SearchParams{DueDate: internal.TimeRange{From: new(time.Unix(0, 0))}},
+			zero:  internal.SearchParams{DueDate: internal.TimeRange{To: new(time.Unix(0, 0))}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			store := &memcachedtesting.FakeSearchableTaskStore{}
+			store.SearchReturns(internal.SearchResults{Total: 1}, nil)
+
+			task := memcachedtask.NewSearchableTask(cache.NewLRU(10), store)
+
+			for _, args := range []internal.SearchParams{tt.unset, tt.zero} {
+				if _, err := task.Search(t.Context(), args); err != nil {
+					t.Fatalf("Failed to search tasks: %v", err)
+				}
+			}
+
+			if count := store.SearchCallCount(); count != 2 {
+				t.Errorf("Expected store.Search to be called twice, got %d", count)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package memcached
+
+import (
+	"context"
+	"math"
+	"math/rand/v2"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	"github.com/MarioCarrion/todo-api-microservice-example/internal"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/cache"
+)
+
+// entry represents a cached value, NotFound indicates the value is missing.
+type entry[T any] struct {
+	Value    T
+	NotFound bool
+	// Expiration indicates when the value expires and Delta how long it took to load it, both are used for refreshing
+	// the value before it expires.
+	Expiration time.Time
+	Delta      time.Duration
+}
+
+// loader implements Cache-Aside Caching, values are loaded when missing, concurrent calls loading the same key are
+// coalesced so only one of them uses the original repository. To avoid popular values expiring at the same time they
+// are refreshed early, using Probabilistic Early Expiration: the closer to expiring and the longer they took to load
+// the more likely they are refreshed.
+type loader struct {
+	cache              cache.Cache
+	group              singleflight.Group
+	expiration         time.Duration
+	negativeExpiration time.Duration
+	beta               float64
+}
+
+func newLoader(cache cache.Cache, expiration time.Duration, opts options) *loader {
+	return &loader{
+		cache:              cache,
+		expiration:         expiration,
+		negativeExpiration: opts.negativeExpiration,
+		beta:               opts.beta,
+	}
+}
+
+// load returns the value cached in key, fn is called for loading it when missing or when refreshing it early. Values
+// failing with internal.ErrorCodeNotFound are cached as missing values.
+func load[T any](ctx context.Context, l *loader, key string, fn func(context.Context) (T, error)) (T, error) {
+	var (
+		zero   T
+		cached entry[T]
+	)
+
+	found := getTask(ctx, l.cache, key, &cached) == nil
+
+	if found && !l.refresh(cached.Expiration, cached.Delta) {
+		if cached.NotFound {
+			return zero, internal.NewErrorf(internal.ErrorCodeNotFound, "not found")
+		}
+
+		return cached.Value, nil
+	}
+
+	res, err, _ := l.group.Do(key, func() (any, error) {
+		// The value is shared with the coalesced calls, canceling this one should not cancel the others.
+		ctx := context.WithoutCancel(ctx)
+
+		start := time.Now()
+
+		res, err := fn(ctx)
+		if err != nil {
+			if internal.HasCode(err, internal.ErrorCodeNotFound) && l.negativeExpiration > 0 {
+				setEntry(ctx, l.cache, key, entry[T]{NotFound: true}, l.negativeExpiration)
+			}
+
+			return nil, err
+		}
+
+		setEntry(ctx, l.cache, key, entry[T]{Value: res, Delta: time.Since(start)}, l.expiration)
+
+		return res, nil
+	})
+	if err != nil {
+		// Failing to refresh a value early is not an error, it has not expired yet.
+		if found && !cached.NotFound && !internal.HasCode(err, internal.ErrorCodeNotFound) {
+			return cached.Value, nil
+		}
+
+		return zero, err //nolint: wrapcheck
+	}
+
+	return res.(T), nil //nolint: forcetypeassert
+}
+
+// set caches the value in key, replacing the existing one.
+func set[T any](ctx context.Context, l *loader, key string, value T) {
+	setEntry(ctx, l.cache, key, entry[T]{Value: value}, l.expiration)
+}
+
+// refresh indicates whether the value expiring at expiration, and taking delta to load, should be refreshed early.
+func (l *loader) refresh(expiration time.Time, delta time.Duration) bool {
+	if l.beta <= 0 {
+		return false
+	}
+
+	// Seconds are compared, instead of durations, to avoid overflows; 1 - rand.Float64() is in (0, 1] so the logarithm is
+	// never infinite.
+	early := delta.Seconds() * l.beta * -math.Log(1-rand.Float64()) //nolint: gosec
+
+	return time.Until(expiration).Seconds() <= early
+}
+
+func setEntry[T any](ctx context.Context, cache cache.Cache, key string, value entry[T], expiration time.Duration) {
+	value.Expiration = time.Now().Add(expiration)
+
+	setTask(ctx, cache, key, &value, expiration)
+}
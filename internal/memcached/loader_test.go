@@ -0,0 +1,165 @@
+package memcached_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/MarioCarrion/todo-api-microservice-example/internal"
+	memcachedtask "github.com/MarioCarrion/todo-api-microservice-example/internal/memcached"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/memcached/memcachedtesting"
+)
+
+func TestTask_Find_Loader(t *testing.T) { //nolint: paralleltest
+	client := setupClient()
+
+	tests := []struct {
+		name          string
+		id            string
+		opts          []memcachedtask.Option
+		find          func(ctx context.Context, id string) (internal.Task, error)
+		calls         int
+		expectedCalls int
+		expectedCode  internal.ErrorCode
+	}{
+		{
+			name: "Concurrent misses are coalesced",
+			id:   "test-concurrent",
+			find: func(_ context.Context, id string) (internal.Task, error) {
+				time.Sleep(100 * time.Millisecond)
+
+				return internal.Task{ID: id}, nil
+			},
+			calls:         10,
+			expectedCalls: 1,
+		},
+		{
+			name: "Missing values are cached",
+			id:   "test-missing",
+			find: func(_ context.Context, _ string) (internal.Task, error) {
+				return internal.Task{}, internal.NewErrorf(internal.ErrorCodeNotFound, "not found")
+			},
+			calls:         2,
+			expectedCalls: 1,
+			expectedCode:  internal.ErrorCodeNotFound,
+		},
+		{
+			name: "Missing values are not cached",
+			id:   "test-missing-disabled",
+			opts: []memcachedtask.Option{memcachedtask.WithNegativeExpiration(0)},
+			find: func(_ context.Context, _ string) (internal.Task, error) {
+				return internal.Task{}, internal.NewErrorf(internal.ErrorCodeNotFound, "not found")
+			},
+			calls:         2,
+			expectedCalls: 2,
+			expectedCode:  internal.ErrorCodeNotFound,
+		},
+		{
+			name: "Values are refreshed early",
+			id:   "test-early-refresh",
+			opts: []memcachedtask.Option{memcachedtask.WithEarlyRefresh(1e12)},
+			find: func(_ context.Context, id string) (internal.Task, error) {
+				time.Sleep(time.Millisecond)
+
+				return internal.Task{ID: id}, nil
+			},
+			calls:         2,
+			expectedCalls: 2,
+		},
+		{
+			name: "Cached value is used when refreshing early fails",
+			id:   "test-early-refresh-error",
+			opts: []memcachedtask.Option{memcachedtask.WithEarlyRefresh(1e12)},
+			find: func() func(context.Context, string) (internal.Task, error) {
+				var once sync.Once
+
+				return func(_ context.Context, id string) (internal.Task, error) {
+					err := errors.New("failed")
+
+					once.Do(func() {
+						time.Sleep(time.Millisecond)
+
+						err = nil
+					})
+
+					return internal.Task{ID: id}, err
+				}
+			}(),
+			calls:         2,
+			expectedCalls: 2,
+		},
+	}
+
+	for _, tt := range tests { //nolint: paralleltest
+		t.Run(tt.name, func(t *testing.T) {
+			store := &memcachedtesting.FakeTaskStore{}
+			store.FindStub = tt.find
+
+			task := memcachedtask.NewTask(memcachedtask.NewClient(client.client), store, zap.NewNop(), tt.opts...)
+
+			var wg sync.WaitGroup
+
+			errs := make([]error, tt.calls)
+
+			for i := range tt.calls {
+				// Concurrent calls are coalesced, the rest are sequential.
+				if tt.calls > 2 {
+					wg.Go(func() { _, errs[i] = task.Find(t.Context(), tt.id) })
+				} else {
+					_, errs[i] = task.Find(t.Context(), tt.id)
+				}
+			}
+
+			wg.Wait()
+
+			for _, err := range errs {
+				var ierr *internal.Error
+
+				if tt.expectedCode == internal.ErrorCodeUnknown && err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+
+				if tt.expectedCode != internal.ErrorCodeUnknown && (!errors.As(err, &ierr) || ierr.Code() != tt.expectedCode) {
+					t.Fatalf("expected error code %d, got %v", tt.expectedCode, err)
+				}
+			}
+
+			if count := store.FindCallCount(); count != tt.expectedCalls {
+				t.Errorf("Expected store.Find to be called %d times, got %d", tt.expectedCalls, count)
+			}
+		})
+	}
+}
+
+func TestSearchableTask_Search_Loader(t *testing.T) { //nolint: paralleltest
+	client := setupClient()
+
+	store := &memcachedtesting.FakeSearchableTaskStore{}
+	store.SearchStub = func(_ context.Context, _ internal.SearchParams) (internal.SearchResults, error) {
+		time.Sleep(100 * time.Millisecond)
+
+		return internal.SearchResults{Total: 1}, nil
+	}
+
+	task := memcachedtask.NewSearchableTask(memcachedtask.NewClient(client.client), store)
+
+	var wg sync.WaitGroup
+
+	for range 10 {
+		wg.Go(func() {
+			if _, err := task.Search(t.Context(), internal.SearchParams{Description: new("coalesced")}); err != nil {
+				t.Errorf("Failed to search tasks: %v", err)
+			}
+		})
+	}
+
+	wg.Wait()
+
+	if count := store.SearchCallCount(); count != 1 {
+		t.Errorf("Expected store.Search to be called once, got %d", count)
+	}
+}
@@ -4,46 +4,79 @@ import (
 	"bytes"
 	"context"
 	"encoding/gob"
+	"strconv"
 	"time"
 
-	"github.com/bradfitz/gomemcache/memcache"
-
 	"github.com/MarioCarrion/todo-api-microservice-example/internal"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/cache"
 )
 
-// XXX: "delete" and "set" intentionally ignore errors, a better approach
-// would be to implement an unexported "client" type defining all the three
+// XXX: "delete", "set" and "increaseGeneration" intentionally ignore errors, a better approach
+// would be to implement an unexported "client" type defining all the
 // methods defined in this file that includes a Circuit Breaker for logging
 // errors and retry as needed.
 // See https://youtu.be/UnL2iGcD7vE for more details about that pattern.
 
-func deleteTask(_ context.Context, client *memcache.Client, key string) {
-	_ = client.Delete(key)
+func deleteTask(ctx context.Context, cache cache.Cache, key string) {
+	_ = cache.Delete(ctx, key)
 }
 
-func getTask(_ context.Context, client *memcache.Client, key string, target any) error {
-	item, err := client.Get(key)
+func getTask(ctx context.Context, cache cache.Cache, key string, target any) error {
+	value, err := cache.Get(ctx, key)
 	if err != nil {
-		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "client.Get")
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "cache.Get")
 	}
 
-	if err := gob.NewDecoder(bytes.NewReader(item.Value)).Decode(target); err != nil {
+	if err := gob.NewDecoder(bytes.NewReader(value)).Decode(target); err != nil {
 		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "gob.NewDecoder")
 	}
 
 	return nil
 }
 
-func setTask(_ context.Context, client *memcache.Client, key string, value any, expiration time.Duration) {
+func setTask(ctx context.Context, cache cache.Cache, key string, value any, expiration time.Duration) {
 	var b bytes.Buffer
 
 	if err := gob.NewEncoder(&b).Encode(value); err != nil {
 		return
 	}
 
-	_ = client.Set(&memcache.Item{
-		Key:        key,
-		Value:      b.Bytes(),
-		Expiration: int32(time.Now().Add(expiration).Unix()), //nolint:gosec
-	})
+	_ = cache.Set(ctx, key, b.Bytes(), expiration)
+}
+
+// getGeneration returns the generation stored in key, when missing it is initialized using the current time so it is
+// always greater than any evicted value.
+func getGeneration(ctx context.Context, cache cache.Cache, key string) (uint64, error) {
+	for {
+		value, err := cache.Get(ctx, key)
+		if err == nil {
+			res, err := strconv.ParseUint(string(value), 10, 64)
+			if err != nil {
+				return 0, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "strconv.ParseUint")
+			}
+
+			return res, nil
+		}
+
+		if !internal.HasCode(err, internal.ErrorCodeNotFound) {
+			return 0, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "cache.Get")
+		}
+
+		res := uint64(time.Now().UnixNano()) //nolint:gosec
+
+		err = cache.Add(ctx, key, []byte(strconv.FormatUint(res, 10)))
+		if err == nil {
+			return res, nil
+		}
+
+		// Another client initialized it first, use that value instead.
+		if !internal.HasCode(err, internal.ErrorCodeConflict) {
+			return 0, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "cache.Add")
+		}
+	}
+}
+
+// increaseGeneration increases the generation stored in key, missing values are initialized by getGeneration.
+func increaseGeneration(ctx context.Context, cache cache.Cache, key string) {
+	_, _ = cache.Increment(ctx, key, 1)
 }
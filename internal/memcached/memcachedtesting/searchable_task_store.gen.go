@@ -10,11 +10,12 @@ import (
 )
 
 type FakeSearchableTaskStore struct {
-	DeleteStub        func(context.Context, string) error
+	DeleteStub        func(context.Context, string, int64) error
 	deleteMutex       sync.RWMutex
 	deleteArgsForCall []struct {
 		arg1 context.Context
 		arg2 string
+		arg3 int64
 	}
 	deleteReturns struct {
 		result1 error
@@ -48,23 +49,38 @@ type FakeSearchableTaskStore struct {
 		result1 internal.SearchResults
 		result2 error
 	}
+	SuggestStub        func(context.Context, internal.SuggestParams) ([]string, error)
+	suggestMutex       sync.RWMutex
+	suggestArgsForCall []struct {
+		arg1 context.Context
+		arg2 internal.SuggestParams
+	}
+	suggestReturns struct {
+		result1 []string
+		result2 error
+	}
+	suggestReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeSearchableTaskStore) Delete(arg1 context.Context, arg2 string) error {
+func (fake *FakeSearchableTaskStore) Delete(arg1 context.Context, arg2 string, arg3 int64) error {
 	fake.deleteMutex.Lock()
 	ret, specificReturn := fake.deleteReturnsOnCall[len(fake.deleteArgsForCall)]
 	fake.deleteArgsForCall = append(fake.deleteArgsForCall, struct {
 		arg1 context.Context
 		arg2 string
-	}{arg1, arg2})
+		arg3 int64
+	}{arg1, arg2, arg3})
 	stub := fake.DeleteStub
 	fakeReturns := fake.deleteReturns
-	fake.recordInvocation("Delete", []interface{}{arg1, arg2})
+	fake.recordInvocation("Delete", []interface{}{arg1, arg2, arg3})
 	fake.deleteMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2)
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1
@@ -78,17 +94,17 @@ func (fake *FakeSearchableTaskStore) DeleteCallCount() int {
 	return len(fake.deleteArgsForCall)
 }
 
-func (fake *FakeSearchableTaskStore) DeleteCalls(stub func(context.Context, string) error) {
+func (fake *FakeSearchableTaskStore) DeleteCalls(stub func(context.Context, string, int64) error) {
 	fake.deleteMutex.Lock()
 	defer fake.deleteMutex.Unlock()
 	fake.DeleteStub = stub
 }
 
-func (fake *FakeSearchableTaskStore) DeleteArgsForCall(i int) (context.Context, string) {
+func (fake *FakeSearchableTaskStore) DeleteArgsForCall(i int) (context.Context, string, int64) {
 	fake.deleteMutex.RLock()
 	defer fake.deleteMutex.RUnlock()
 	argsForCall := fake.deleteArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *FakeSearchableTaskStore) DeleteReturns(result1 error) {
@@ -241,6 +257,71 @@ func (fake *FakeSearchableTaskStore) SearchReturnsOnCall(i int, result1 internal
 	}{result1, result2}
 }
 
+func (fake *FakeSearchableTaskStore) Suggest(arg1 context.Context, arg2 internal.SuggestParams) ([]string, error) {
+	fake.suggestMutex.Lock()
+	ret, specificReturn := fake.suggestReturnsOnCall[len(fake.suggestArgsForCall)]
+	fake.suggestArgsForCall = append(fake.suggestArgsForCall, struct {
+		arg1 context.Context
+		arg2 internal.SuggestParams
+	}{arg1, arg2})
+	stub := fake.SuggestStub
+	fakeReturns := fake.suggestReturns
+	fake.recordInvocation("Suggest", []interface{}{arg1, arg2})
+	fake.suggestMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeSearchableTaskStore) SuggestCallCount() int {
+	fake.suggestMutex.RLock()
+	defer fake.suggestMutex.RUnlock()
+	return len(fake.suggestArgsForCall)
+}
+
+func (fake *FakeSearchableTaskStore) SuggestCalls(stub func(context.Context, internal.SuggestParams) ([]string, error)) {
+	fake.suggestMutex.Lock()
+	defer fake.suggestMutex.Unlock()
+	fake.SuggestStub = stub
+}
+
+func (fake *FakeSearchableTaskStore) SuggestArgsForCall(i int) (context.Context, internal.SuggestParams) {
+	fake.suggestMutex.RLock()
+	defer fake.suggestMutex.RUnlock()
+	argsForCall := fake.suggestArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeSearchableTaskStore) SuggestReturns(result1 []string, result2 error) {
+	fake.suggestMutex.Lock()
+	defer fake.suggestMutex.Unlock()
+	fake.SuggestStub = nil
+	fake.suggestReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSearchableTaskStore) SuggestReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.suggestMutex.Lock()
+	defer fake.suggestMutex.Unlock()
+	fake.SuggestStub = nil
+	if fake.suggestReturnsOnCall == nil {
+		fake.suggestReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.suggestReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeSearchableTaskStore) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
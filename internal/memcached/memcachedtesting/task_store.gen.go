@@ -24,17 +24,20 @@ type FakeTaskStore struct {
 		result1 internal.Task
 		result2 error
 	}
-	DeleteStub        func(context.Context, string) error
+	DeleteStub        func(context.Context, string, internal.DeleteParams) (int64, error)
 	deleteMutex       sync.RWMutex
 	deleteArgsForCall []struct {
 		arg1 context.Context
 		arg2 string
+		arg3 internal.DeleteParams
 	}
 	deleteReturns struct {
-		result1 error
+		result1 int64
+		result2 error
 	}
 	deleteReturnsOnCall map[int]struct {
-		result1 error
+		result1 int64
+		result2 error
 	}
 	FindStub        func(context.Context, string) (internal.Task, error)
 	findMutex       sync.RWMutex
@@ -132,24 +135,25 @@ func (fake *FakeTaskStore) CreateReturnsOnCall(i int, result1 internal.Task, res
 	}{result1, result2}
 }
 
-func (fake *FakeTaskStore) Delete(arg1 context.Context, arg2 string) error {
+func (fake *FakeTaskStore) Delete(arg1 context.Context, arg2 string, arg3 internal.DeleteParams) (int64, error) {
 	fake.deleteMutex.Lock()
 	ret, specificReturn := fake.deleteReturnsOnCall[len(fake.deleteArgsForCall)]
 	fake.deleteArgsForCall = append(fake.deleteArgsForCall, struct {
 		arg1 context.Context
 		arg2 string
-	}{arg1, arg2})
+		arg3 internal.DeleteParams
+	}{arg1, arg2, arg3})
 	stub := fake.DeleteStub
 	fakeReturns := fake.deleteReturns
-	fake.recordInvocation("Delete", []interface{}{arg1, arg2})
+	fake.recordInvocation("Delete", []interface{}{arg1, arg2, arg3})
 	fake.deleteMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2)
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
-		return ret.result1
+		return ret.result1, ret.result2
 	}
-	return fakeReturns.result1
+	return fakeReturns.result1, fakeReturns.result2
 }
 
 func (fake *FakeTaskStore) DeleteCallCount() int {
@@ -158,40 +162,43 @@ func (fake *FakeTaskStore) DeleteCallCount() int {
 	return len(fake.deleteArgsForCall)
 }
 
-func (fake *FakeTaskStore) DeleteCalls(stub func(context.Context, string) error) {
+func (fake *FakeTaskStore) DeleteCalls(stub func(context.Context, string, internal.DeleteParams) (int64, error)) {
 	fake.deleteMutex.Lock()
 	defer fake.deleteMutex.Unlock()
 	fake.DeleteStub = stub
 }
 
-func (fake *FakeTaskStore) DeleteArgsForCall(i int) (context.Context, string) {
+func (fake *FakeTaskStore) DeleteArgsForCall(i int) (context.Context, string, internal.DeleteParams) {
 	fake.deleteMutex.RLock()
 	defer fake.deleteMutex.RUnlock()
 	argsForCall := fake.deleteArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
-func (fake *FakeTaskStore) DeleteReturns(result1 error) {
+func (fake *FakeTaskStore) DeleteReturns(result1 int64, result2 error) {
 	fake.deleteMutex.Lock()
 	defer fake.deleteMutex.Unlock()
 	fake.DeleteStub = nil
 	fake.deleteReturns = struct {
-		result1 error
-	}{result1}
+		result1 int64
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *FakeTaskStore) DeleteReturnsOnCall(i int, result1 error) {
+func (fake *FakeTaskStore) DeleteReturnsOnCall(i int, result1 int64, result2 error) {
 	fake.deleteMutex.Lock()
 	defer fake.deleteMutex.Unlock()
 	fake.DeleteStub = nil
 	if fake.deleteReturnsOnCall == nil {
 		fake.deleteReturnsOnCall = make(map[int]struct {
-			result1 error
+			result1 int64
+			result2 error
 		})
 	}
 	fake.deleteReturnsOnCall[i] = struct {
-		result1 error
-	}{result1}
+		result1 int64
+		result2 error
+	}{result1, result2}
 }
 
 func (fake *FakeTaskStore) Find(arg1 context.Context, arg2 string) (internal.Task, error) {
@@ -0,0 +1,50 @@
+package memcached
+
+import (
+	"time"
+)
+
+// Option defines the optional configuration used by the repositories.
+type Option func(*options)
+
+type options struct {
+	codec              KeyCodec
+	negativeExpiration time.Duration
+	beta               float64
+}
+
+// WithKeyCodec sets the codec used for building the keys, SHA256KeyCodec is used by default.
+func WithKeyCodec(codec KeyCodec) Option {
+	return func(o *options) {
+		o.codec = codec
+	}
+}
+
+// WithNegativeExpiration sets how long missing values are cached, 10 seconds by default; zero disables caching them.
+func WithNegativeExpiration(expiration time.Duration) Option {
+	return func(o *options) {
+		o.negativeExpiration = expiration
+	}
+}
+
+// WithEarlyRefresh sets the beta used for refreshing values before they expire, 1 by default; values greater than 1
+// favor refreshing earlier, zero disables refreshing them.
+func WithEarlyRefresh(beta float64) Option {
+	return func(o *options) {
+		o.beta = beta
+	}
+}
+
+func newOptions(opts []Option) options {
+	res := options{
+		codec:              SHA256KeyCodec{},
+		negativeExpiration: 10 * time.Second,
+		beta:               1,
+	}
+
+	for _, opt := range opts {
+		opt(&res)
+	}
+
+	return res
+}
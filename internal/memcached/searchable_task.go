@@ -2,99 +2,156 @@ package memcached
 
 import (
 	"context"
-	"errors"
-	"fmt"
+	"strconv"
 	"time"
 
-	"github.com/bradfitz/gomemcache/memcache"
-
 	"github.com/MarioCarrion/todo-api-microservice-example/internal"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/cache"
 )
 
 //go:generate counterfeiter -generate
 
+const (
+	// searchGenerationKey is the key of the generation included in the search keys, it is increased every time a task
+	// is indexed or deleted so cached results are never stale. It is shared so cache.Tiered never keeps it locally.
+	searchGenerationKey = cache.SharedKeyPrefix + "search_generation"
+
+	// searchExpiration indicates how long search results are cached when no tasks are indexed or deleted.
+	searchExpiration = 5 * time.Minute
+)
+
 // SearchableTask ...
 type SearchableTask struct {
-	client *memcache.Client
+	cache  cache.Cache
 	orig   SearchableTaskStore
+	loader *loader
+	codec  KeyCodec
 }
 
 //counterfeiter:generate -o memcachedtesting/searchable_task_store.gen.go . SearchableTaskStore
 
 type SearchableTaskStore interface {
-	Delete(ctx context.Context, id string) error
+	Delete(ctx context.Context, id string, version int64) error
 	Index(ctx context.Context, task internal.Task) error
 	Search(ctx context.Context, args internal.SearchParams) (internal.SearchResults, error)
+	Suggest(ctx context.Context, args internal.SuggestParams) ([]string, error)
 }
 
 // NewSearchableTask instantiates the Task repository.
-func NewSearchableTask(client *memcache.Client, orig SearchableTaskStore) *SearchableTask {
+func NewSearchableTask(cache cache.Cache, orig SearchableTaskStore, opts ...Option) *SearchableTask {
+	options := newOptions(opts)
+
 	return &SearchableTask{
-		client: client,
+		cache:  cache,
 		orig:   orig,
+		loader: newLoader(cache, searchExpiration, options),
+		codec:  options.codec,
 	}
 }
 
-// Index ...
+// Index indexes the task and invalidates the cached search results.
 func (t *SearchableTask) Index(ctx context.Context, task internal.Task) error {
 	if err := t.orig.Index(ctx, task); err != nil {
 		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "orig.Index")
 	}
 
+	increaseGeneration(ctx, t.cache, searchGenerationKey)
+
 	return nil
 }
 
-// Delete ...
-func (t *SearchableTask) Delete(ctx context.Context, id string) error {
-	if err := t.orig.Delete(ctx, id); err != nil {
+// Delete deletes the indexed task and invalidates the cached search results.
+func (t *SearchableTask) Delete(ctx context.Context, id string, version int64) error {
+	if err := t.orig.Delete(ctx, id, version); err != nil {
 		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "orig.Delete")
 	}
 
+	increaseGeneration(ctx, t.cache, searchGenerationKey)
+
 	return nil
 }
 
-// Search ...
+// Search returns the cached results, searching the tasks when missing. The cache is skipped when the generation
+// can't be read, so errors caching don't fail the search.
 func (t *SearchableTask) Search(ctx context.Context, args internal.SearchParams) (internal.SearchResults, error) {
-	key := newSearchableKey(args)
+	generation, err := getGeneration(ctx, t.cache, searchGenerationKey)
+	if err != nil {
+		res, err := t.orig.Search(ctx, args)
+		if err != nil {
+			return internal.SearchResults{}, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "orig.Search")
+		}
 
-	var res internal.SearchResults
+		return res, nil
+	}
 
-	if err := getTask(ctx, t.client, key, &res); err != nil {
-		if errors.Is(err, memcache.ErrCacheMiss) {
-			res, err := t.orig.Search(ctx, args)
-			if err != nil {
-				return internal.SearchResults{}, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "orig.Search")
-			}
+	key := t.codec.Key(searchNamespace, newSearchableKey(generation, args)...)
 
-			setTask(ctx, t.client, key, &res, 25*time.Second)
+	res, err := load(ctx, t.loader, key, func(ctx context.Context) (internal.SearchResults, error) {
+		return t.orig.Search(ctx, args)
+	})
+	if err != nil {
+		return internal.SearchResults{}, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "orig.Search")
+	}
 
-			return res, nil
-		}
+	return res, nil
+}
 
-		return internal.SearchResults{}, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "get")
+// Suggest ...
+func (t *SearchableTask) Suggest(ctx context.Context, args internal.SuggestParams) ([]string, error) {
+	res, err := t.orig.Suggest(ctx, args)
+	if err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "orig.Suggest")
 	}
 
 	return res, nil
 }
 
-func newSearchableKey(args internal.SearchParams) string {
-	var (
-		description string
-		priority    int8
-		isDone      bool
+// newSearchableKey returns the values identifying the arguments in the generation, optional values are encoded
+// using newOptionalKey so unset values and zero values use different keys.
+func newSearchableKey(generation uint64, args internal.SearchParams) []string {
+	res := []string{
+		strconv.FormatUint(generation, 10),
+		newOptionalKey(args.Description, func(v string) string { return v }),
+		newOptionalKey(args.Priority, func(v internal.Priority) string { return strconv.Itoa(int(v)) }),
+		newOptionalKey(args.IsDone, strconv.FormatBool),
+	}
+
+	res = append(res, newTimeRangeKey(args.StartDate)...)
+	res = append(res, newTimeRangeKey(args.DueDate)...)
+
+	return append(res,
+		strconv.FormatInt(args.From, 10),
+		strconv.FormatInt(args.Size, 10),
+		newOptionalKey(nonZero(string(args.Sort)), func(v string) string { return v }),
+		newOptionalKey(nonZero(args.Cursor), func(v string) string { return v }),
 	)
+}
 
-	if args.Description != nil {
-		description = *args.Description
+func newTimeRangeKey(r internal.TimeRange) []string {
+	unixNano := func(v time.Time) string { return strconv.FormatInt(v.UnixNano(), 10) }
+
+	return []string{
+		newOptionalKey(r.From, unixNano),
+		newOptionalKey(r.To, unixNano),
 	}
+}
 
-	if args.Priority != nil {
-		priority = int8(*args.Priority)
+// newOptionalKey returns "-" when the value is not set, otherwise the formatted value prefixed with "v:".
+func newOptionalKey[T any](value *T, format func(T) string) string {
+	if value == nil {
+		return "-"
 	}
 
-	if args.IsDone != nil {
-		isDone = *args.IsDone
+	return "v:" + format(*value)
+}
+
+// nonZero returns nil when the value is the zero value, used for the optional values that are not pointers.
+func nonZero[T comparable](value T) *T {
+	var zero T
+
+	if value == zero {
+		return nil
 	}
 
-	return fmt.Sprintf("%s_%d_%t_%d_%d", description, priority, isDone, args.From, args.Size)
+	return &value
 }
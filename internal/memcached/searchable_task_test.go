@@ -6,6 +6,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 
 	"github.com/MarioCarrion/todo-api-microservice-example/internal"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/cache/cachetesting"
 	memcachedtask "github.com/MarioCarrion/todo-api-microservice-example/internal/memcached"
 	"github.com/MarioCarrion/todo-api-microservice-example/internal/memcached/memcachedtesting"
 )
@@ -60,14 +61,14 @@ func TestSearchableTask_All(t *testing.T) {
 
 				expected := "test-123"
 
-				if err := task.Delete(t.Context(), "test-123"); err != nil {
+				if err := task.Delete(t.Context(), "test-123", 4); err != nil {
 					t.Fatalf("Failed to index task: %v", err)
 				}
 
-				_, got := store.DeleteArgsForCall(0)
+				_, got, version := store.DeleteArgsForCall(0)
 
-				if got != expected {
-					t.Fatalf("Received %s task id not the same as expected one: %s", got, expected)
+				if got != expected || version != 4 {
+					t.Fatalf("Received %s task id with version %d not the same as expected one: %s", got, version, expected)
 				}
 
 				// Verify store was called once
@@ -129,6 +130,45 @@ func TestSearchableTask_All(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "Search after Index",
+			setUpMockStore: func() *memcachedtesting.FakeSearchableTaskStore {
+				mock := &memcachedtesting.FakeSearchableTaskStore{}
+				mock.SearchReturnsOnCall(0, internal.SearchResults{}, nil)
+				mock.SearchReturnsOnCall(1, internal.SearchResults{
+					Tasks: []internal.Task{{ID: "test-456", Description: "Indexed task"}},
+					Total: 1,
+				}, nil)
+
+				return mock
+			},
+			callAndVerify: func(t *testing.T, task *memcachedtask.SearchableTask, store *memcachedtesting.FakeSearchableTaskStore) {
+				t.Helper()
+
+				args := internal.SearchParams{Description: new("indexed"), Size: 2}
+
+				if _, err := task.Search(t.Context(), args); err != nil {
+					t.Fatalf("Failed to search tasks: %v", err)
+				}
+
+				if err := task.Index(t.Context(), internal.Task{ID: "test-456", Description: "Indexed task"}); err != nil {
+					t.Fatalf("Failed to index task: %v", err)
+				}
+
+				got, err := task.Search(t.Context(), args)
+				if err != nil {
+					t.Fatalf("Failed to search tasks: %v", err)
+				}
+
+				if count := store.SearchCallCount(); count != 2 {
+					t.Errorf("Expected store.Search to be called twice, got %d", count)
+				}
+
+				if got.Total != 1 {
+					t.Fatalf("Expected the indexed task to be found, got %v", got)
+				}
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -136,9 +176,40 @@ func TestSearchableTask_All(t *testing.T) {
 			t.Parallel()
 
 			mockStore := tt.setUpMockStore()
-			task := memcachedtask.NewSearchableTask(client.client, mockStore)
+			task := memcachedtask.NewSearchableTask(memcachedtask.NewClient(client.client), mockStore)
 
 			tt.callAndVerify(t, task, mockStore)
 		})
 	}
 }
+
+func TestSearchableTask_Search_CacheUnavailable(t *testing.T) {
+	t.Parallel()
+
+	fake := &cachetesting.FakeCache{}
+	fake.GetReturns(nil, internal.NewErrorf(internal.ErrorCodeUnknown, "connection refused"))
+
+	store := &memcachedtesting.FakeSearchableTaskStore{}
+	store.SearchReturns(internal.SearchResults{Total: 1}, nil)
+
+	task := memcachedtask.NewSearchableTask(fake, store)
+
+	for range 2 {
+		got, err := task.Search(t.Context(), internal.SearchParams{Description: new("unavailable")})
+		if err != nil {
+			t.Fatalf("Failed to search tasks: %v", err)
+		}
+
+		if got.Total != 1 {
+			t.Fatalf("Expected the searched results, got %v", got)
+		}
+	}
+
+	if count := store.SearchCallCount(); count != 2 {
+		t.Errorf("Expected store.Search to be called twice, got %d", count)
+	}
+
+	if count := fake.SetCallCount(); count != 0 {
+		t.Errorf("Expected results not to be cached, got %d calls", count)
+	}
+}
@@ -1,38 +1,42 @@
-// Package memcached implements the memcached repository to cache tasks.
+// Package memcached implements the repositories caching tasks, using memcached by default or any other cache.Cache.
 package memcached
 
 import (
 	"context"
 	"time"
 
-	"github.com/bradfitz/gomemcache/memcache"
 	"go.uber.org/zap"
 
 	"github.com/MarioCarrion/todo-api-microservice-example/internal"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/cache"
 )
 
 //counterfeiter:generate -o memcachedtesting/task_store.gen.go . TaskStore
 
 type Task struct {
-	client     *memcache.Client
-	orig       TaskStore
-	expiration time.Duration
-	logger     *zap.Logger
+	cache  cache.Cache
+	orig   TaskStore
+	loader *loader
+	logger *zap.Logger
+	codec  KeyCodec
 }
 
 type TaskStore interface {
 	Create(ctx context.Context, params internal.CreateParams) (internal.Task, error)
-	Delete(ctx context.Context, id string) error
+	Delete(ctx context.Context, id string, params internal.DeleteParams) (int64, error)
 	Find(ctx context.Context, id string) (internal.Task, error)
 	Update(ctx context.Context, id string, params internal.UpdateParams) error
 }
 
-func NewTask(client *memcache.Client, orig TaskStore, logger *zap.Logger) *Task {
+func NewTask(cache cache.Cache, orig TaskStore, logger *zap.Logger, opts ...Option) *Task {
+	options := newOptions(opts)
+
 	return &Task{
-		client:     client,
-		orig:       orig,
-		expiration: 10 * time.Minute,
-		logger:     logger,
+		cache:  cache,
+		orig:   orig,
+		loader: newLoader(cache, 10*time.Minute, options),
+		logger: logger,
+		codec:  options.codec,
 	}
 }
 
@@ -46,41 +50,36 @@ func (t *Task) Create(ctx context.Context, params internal.CreateParams) (intern
 
 	t.logger.Info("Create: setting value")
 
-	setTask(ctx, t.client, task.ID, &task, t.expiration)
+	set(ctx, t.loader, t.key(task.ID), task)
 
 	return task, nil
 }
 
-func (t *Task) Delete(ctx context.Context, id string) error {
-	if err := t.orig.Delete(ctx, id); err != nil {
-		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "orig.Delete")
+func (t *Task) Delete(ctx context.Context, id string, params internal.DeleteParams) (int64, error) {
+	version, err := t.orig.Delete(ctx, id, params)
+	if err != nil {
+		return 0, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "orig.Delete")
 	}
 
-	deleteTask(ctx, t.client, id)
+	deleteTask(ctx, t.cache, t.key(id))
 
-	return nil
+	return version, nil
 }
 
 func (t *Task) Find(ctx context.Context, id string) (internal.Task, error) {
-	var res internal.Task
-
 	t.logger.Info("Find: get value")
 
-	if err := getTask(ctx, t.client, id, &res); err == nil {
-		return res, nil
-	}
-
-	t.logger.Info("Find: not found, let's cache it")
-
 	// Cache-Aside Caching
 
-	res, err := t.orig.Find(ctx, id)
+	res, err := load(ctx, t.loader, t.key(id), func(ctx context.Context) (internal.Task, error) {
+		t.logger.Info("Find: not found, let's cache it")
+
+		return t.orig.Find(ctx, id)
+	})
 	if err != nil {
-		return res, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "orig.Find")
+		return internal.Task{}, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "orig.Find")
 	}
 
-	setTask(ctx, t.client, res.ID, &res, t.expiration)
-
 	return res, nil
 }
 
@@ -99,14 +98,18 @@ func (t *Task) Update(ctx context.Context, id string, params internal.UpdatePara
 	// What if any of the following instructions fail? We may end up with stale
 	// values
 
-	deleteTask(ctx, t.client, id) // XXX
+	deleteTask(ctx, t.cache, t.key(id)) // XXX
 
 	task, err := t.orig.Find(ctx, id)
 	if err != nil {
 		return nil //nolint: nilerr
 	}
 
-	setTask(ctx, t.client, task.ID, &task, t.expiration) // XXX
+	set(ctx, t.loader, t.key(task.ID), task) // XXX
 
 	return nil
 }
+
+func (t *Task) key(id string) string {
+	return t.codec.Key(taskNamespace, id)
+}
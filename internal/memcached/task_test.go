@@ -160,8 +160,9 @@ func TestTask_All(t *testing.T) { //nolint: tparallel
 					Priority:    new(internal.PriorityHigh),
 				}
 				mockStore.CreateReturns(testTask, nil)
+				mockStore.FindReturns(testTask, nil)
 
-				mockStore.DeleteReturns(nil)
+				mockStore.DeleteReturns(2, nil)
 
 				return mockStore
 			},
@@ -204,17 +205,26 @@ func TestTask_All(t *testing.T) { //nolint: tparallel
 				}
 
 				//- Now delete the task
-				if err := task.Delete(ctx, expected.ID); err != nil {
+				version, err := task.Delete(ctx, expected.ID, internal.DeleteParams{})
+				if err != nil {
 					t.Fatalf("Failed to delete task: %v", err)
 				}
 
+				if version != 2 {
+					t.Errorf("Expected deleted version 2, got %d", version)
+				}
+
 				if count := store.DeleteCallCount(); count != 1 {
 					t.Errorf("Expected store.Delete to be called once, got %d", count)
 				}
 
-				_, err = client.client.Get(expected.ID)
-				if !errors.Is(err, memcache.ErrCacheMiss) {
-					t.Errorf("Expected a ErrCacheMiss, but got %v", err)
+				// Finding the deleted task should miss the cache
+				if _, err := task.Find(ctx, expected.ID); err != nil {
+					t.Fatalf("Failed to find task: %v", err)
+				}
+
+				if count := store.FindCallCount(); count != 1 {
+					t.Errorf("Expected store.Find to be called once (cache miss), got %d", count)
 				}
 			},
 		},
@@ -224,7 +234,7 @@ func TestTask_All(t *testing.T) { //nolint: tparallel
 		t.Run(tt.name, func(t *testing.T) {
 			mockStore := tt.setUpMockStore()
 
-			taskCache := memcachedtask.NewTask(client.client, mockStore, logger)
+			taskCache := memcachedtask.NewTask(memcachedtask.NewClient(client.client), mockStore, logger)
 
 			tt.callAndVerify(t, taskCache, mockStore)
 		})
@@ -1,6 +1,8 @@
 package internal
 
 import (
+	"time"
+
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 )
 
@@ -9,15 +11,13 @@ type CreateParams struct {
 	Description string
 	Priority    *Priority
 	Dates       *Dates
+	SubTasks    []CreateParams
+	Categories  []Category
 }
 
 // Validate indicates whether the fields are valid or not.
 func (c CreateParams) Validate() error {
-	task := Task{
-		Description: c.Description,
-		Priority:    c.Priority,
-		Dates:       c.Dates,
-	}
+	task := c.task()
 
 	if err := validation.Validate(&task); err != nil {
 		return WrapErrorf(err, ErrorCodeInvalidArgument, "validation.Validate")
@@ -26,13 +26,99 @@ func (c CreateParams) Validate() error {
 	return nil
 }
 
+// task converts the params, including its sub-tasks, to a Task used for validating values.
+func (c CreateParams) task() Task {
+	var subTasks []Task
+
+	if len(c.SubTasks) > 0 {
+		subTasks = make([]Task, len(c.SubTasks))
+
+		for i, subTask := range c.SubTasks {
+			subTasks[i] = subTask.task()
+		}
+	}
+
+	return Task{
+		Description: c.Description,
+		Priority:    c.Priority,
+		Dates:       c.Dates,
+		SubTasks:    subTasks,
+		Categories:  c.Categories,
+	}
+}
+
+//-
+
+// DeleteParams defines the arguments used for deleting Task records.
+//
+// When not nil, `Version` must match the current version of the record, otherwise an error with the code
+// ErrorCodePreconditionFailed is returned.
+type DeleteParams struct {
+	Version *int64
+}
+
 //-
 
-// SearchParams defines the arguments used for searching Task records.
+const (
+	// SearchSortRelevance sorts the results by how well they match the description, it is the default value.
+	SearchSortRelevance SearchSort = "relevance"
+
+	// SearchSortDueDate sorts the results by due date, the ones due first are returned first and the ones without due
+	// date are returned last.
+	SearchSortDueDate SearchSort = "dueDate"
+
+	// SearchSortPriority sorts the results by priority, the most urgent ones are returned first.
+	SearchSortPriority SearchSort = "priority"
+)
+
+// SearchSort indicates how to sort the search results.
+type SearchSort string
+
+// Validate ...
+func (s SearchSort) Validate() error {
+	switch s {
+	case "", SearchSortRelevance, SearchSortDueDate, SearchSortPriority:
+		return nil
+	}
+
+	return NewErrorf(ErrorCodeInvalidArgument, "unknown value")
+}
+
+// TimeRange defines a period of time, From is inclusive and To is exclusive; nil values are unbounded.
+type TimeRange struct {
+	From *time.Time
+	To   *time.Time
+}
+
+// IsZero determines whether the range has values or not.
+func (r TimeRange) IsZero() bool {
+	return r.From == nil && r.To == nil
+}
+
+// Validate ...
+func (r TimeRange) Validate() error {
+	if r.From != nil && r.To != nil && !r.From.Before(*r.To) {
+		return NewErrorf(ErrorCodeInvalidArgument, "from should be before to")
+	}
+
+	return nil
+}
+
+// SearchParams defines the arguments used for searching Task records, results match all the values.
+//
+// Tasks without dates do not match the date ranges, for example use a DueDate.To value of now together with an
+// IsDone value of false to search for overdue Tasks.
+//
+// Use Cursor, instead of From, for paginating the results; it returns the results after the last one of the previous
+// page even if Tasks were indexed or deleted in between, and it is not limited to the first 10,000 results.
 type SearchParams struct {
 	Description *string
 	Priority    *Priority
 	IsDone      *bool
+	StartDate   TimeRange
+	DueDate     TimeRange
+	Sort        SearchSort
+	Cursor      string
 	From        int64
 	Size        int64
 }
@@ -41,19 +127,119 @@ type SearchParams struct {
 func (a SearchParams) IsZero() bool {
 	return a.Description == nil &&
 		a.Priority == nil &&
-		a.IsDone == nil
+		a.IsDone == nil &&
+		a.StartDate.IsZero() &&
+		a.DueDate.IsZero()
+}
+
+// Validate indicates whether the fields are valid or not.
+func (a SearchParams) Validate() error {
+	if err := validation.ValidateStruct(&a,
+		validation.Field(&a.Priority),
+		validation.Field(&a.StartDate),
+		validation.Field(&a.DueDate),
+		validation.Field(&a.Sort),
+		validation.Field(&a.From,
+			validation.Min(int64(0)),
+			validation.When(a.Cursor != "", validation.Max(int64(0)).Error("must be 0 when using a cursor"))),
+		validation.Field(&a.Size, validation.Min(int64(0))),
+	); err != nil {
+		return WrapErrorf(err, ErrorCodeInvalidArgument, "invalid values")
+	}
+
+	return nil
 }
 
 // SearchResults defines the collection of tasks that were found.
 type SearchResults struct {
-	Tasks []Task
-	Total int64
+	Tasks  []Task
+	Total  int64
+	Facets SearchFacets
+	// Cursor is the value used as SearchParams.Cursor for getting the next page, it is empty when there are no more
+	// results.
+	Cursor string
+	// Degraded indicates the results were returned by a fallback repository, because the primary one was
+	// unavailable.
+	Degraded bool
+	// Highlights are the fragments of the description matching SearchParams.Description, indexed by Task ID; matching
+	// terms are wrapped in <em> tags and the rest of the text is HTML escaped.
+	Highlights map[string][]string
 }
 
-// UpdateParams defines the arguments used to update a Task record.
+// SearchFacets defines the number of tasks found per value, they include all the results and not only the returned
+// ones.
+type SearchFacets struct {
+	Priorities map[Priority]int64
+	Done       int64
+	NotDone    int64
+}
+
+// SuggestParams defines the arguments used for suggesting Task descriptions.
+type SuggestParams struct {
+	// Prefix is the text typed so far, descriptions including words starting with each of its words are suggested.
+	Prefix string
+	Size   int64
+}
+
+// Validate indicates whether the fields are valid or not.
+func (a SuggestParams) Validate() error {
+	if err := validation.ValidateStruct(&a,
+		validation.Field(&a.Prefix, validation.Required, validation.RuneLength(1, 100)),
+		validation.Field(&a.Size, validation.Required, validation.Min(int64(1)), validation.Max(int64(20))),
+	); err != nil {
+		return WrapErrorf(err, ErrorCodeInvalidArgument, "invalid values")
+	}
+
+	return nil
+}
+
+// UpdateParams defines the arguments used to update a Task record, nil values are not updated.
+//
+// When not nil, `SubTasks` and `Categories` replace the existing values and `Version` must match the current
+// version of the record, otherwise an error with the code ErrorCodePreconditionFailed is returned.
 type UpdateParams struct {
 	Description *string
 	Priority    *Priority
-	Dates       *Dates
+	Dates       UpdateDates
 	IsDone      *bool
+	SubTasks    *[]CreateParams
+	Categories  *[]Category
+	Version     *int64
+}
+
+// Validate indicates whether the fields are valid or not, only the values being updated are validated using the same
+// rules used when creating Tasks.
+func (u UpdateParams) Validate() error {
+	var subTasks []Task
+
+	if u.SubTasks != nil {
+		subTasks = make([]Task, len(*u.SubTasks))
+
+		for i, subTask := range *u.SubTasks {
+			subTasks[i] = subTask.task()
+		}
+	}
+
+	if err := (validation.Errors{
+		"Description": validation.Validate(u.Description, validation.NilOrNotEmpty),
+		"Priority":    validation.Validate(u.Priority),
+		"Dates":       validation.Validate(u.Dates),
+		"SubTasks":    validation.Validate(subTasks),
+		"Categories":  validation.Validate(PointerToValue(u.Categories)),
+	}).Filter(); err != nil {
+		return WrapErrorf(err, ErrorCodeInvalidArgument, "invalid values")
+	}
+
+	return nil
+}
+
+// UpdateDates defines the dates used to update a Task record, unset dates are not updated and null ones are cleared.
+type UpdateDates struct {
+	Start Optional[time.Time]
+	Due   Optional[time.Time]
+}
+
+// Validate indicates whether the dates are valid or not, they are only compared when both are updated.
+func (u UpdateDates) Validate() error {
+	return Dates{Start: u.Start.Value, Due: u.Due.Value}.Validate()
 }
@@ -3,6 +3,7 @@ package internal_test
 import (
 	"errors"
 	"testing"
+	"time"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 
@@ -35,6 +36,37 @@ func TestCreateParams_Validate(t *testing.T) {
 			internal.CreateParams{},
 			true,
 		},
+		{
+			"OK: SubTasks",
+			internal.CreateParams{
+				Description: "Description",
+				SubTasks: []internal.CreateParams{
+					{
+						Description: "Sub Description",
+						SubTasks: []internal.CreateParams{
+							{Description: "Sub Sub Description"},
+						},
+					},
+				},
+				Categories: []internal.Category{"category"},
+			},
+			false,
+		},
+		{
+			"ERR: SubTasks",
+			internal.CreateParams{
+				Description: "Description",
+				SubTasks: []internal.CreateParams{
+					{
+						Description: "Sub Description",
+						SubTasks: []internal.CreateParams{
+							{},
+						},
+					},
+				},
+			},
+			true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -54,6 +86,88 @@ func TestCreateParams_Validate(t *testing.T) {
 	}
 }
 
+func TestUpdateParams_Validate(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+	due := start.Add(-time.Hour)
+
+	tests := []struct {
+		name     string
+		input    internal.UpdateParams
+		expected []string
+	}{
+		{
+			name: "OK: nothing updated",
+		},
+		{
+			name: "OK",
+			input: internal.UpdateParams{
+				Description: new("Description"),
+				Priority:    new(internal.PriorityLow),
+				Dates: internal.UpdateDates{
+					Start: internal.NewOptional(&due),
+					Due:   internal.NewOptional(&start),
+				},
+				SubTasks:   &[]internal.CreateParams{{Description: "Sub Description"}},
+				Categories: &[]internal.Category{"category"},
+			},
+		},
+		{
+			name: "OK: only start date updated",
+			input: internal.UpdateParams{
+				Dates: internal.UpdateDates{Start: internal.NewOptional(&start)},
+			},
+		},
+		{
+			name: "ERR",
+			input: internal.UpdateParams{
+				Description: new(""),
+				Priority:    new(internal.Priority(-1)),
+				Dates: internal.UpdateDates{
+					Start: internal.NewOptional(&start),
+					Due:   internal.NewOptional(&due),
+				},
+				SubTasks: &[]internal.CreateParams{
+					{Description: "Sub Description", SubTasks: []internal.CreateParams{{}}},
+				},
+				Categories: &[]internal.Category{""},
+			},
+			expected: []string{"Categories", "Dates", "Description", "Priority", "SubTasks"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.input.Validate()
+			if (err != nil) != (len(tt.expected) > 0) {
+				t.Fatalf("expected error %t, got %s", len(tt.expected) > 0, err)
+			}
+
+			if err == nil {
+				return
+			}
+
+			var verrs validation.Errors
+			if !errors.As(err, &verrs) {
+				t.Fatalf("expected validation errors, got %s", err)
+			}
+
+			for _, field := range tt.expected {
+				if _, ok := verrs[field]; !ok {
+					t.Errorf("expected error for %s, got %s", field, err)
+				}
+			}
+
+			if len(verrs) != len(tt.expected) {
+				t.Errorf("expected %d errors, got %s", len(tt.expected), err)
+			}
+		})
+	}
+}
+
 func TestSearchParams_IsZero(t *testing.T) {
 	t.Parallel()
 
@@ -95,6 +209,21 @@ func TestSearchParams_IsZero(t *testing.T) {
 			},
 			false,
 		},
+		{
+			"OK: DueDate",
+			internal.SearchParams{
+				DueDate: internal.TimeRange{To: new(time.Now())},
+			},
+			false,
+		},
+		{
+			"OK: zero",
+			internal.SearchParams{
+				Sort: internal.SearchSortDueDate,
+				Size: 10,
+			},
+			true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -107,3 +236,119 @@ func TestSearchParams_IsZero(t *testing.T) {
 		})
 	}
 }
+
+func TestSearchParams_Validate(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	tests := []struct {
+		name    string
+		input   internal.SearchParams
+		withErr bool
+	}{
+		{
+			"OK",
+			internal.SearchParams{
+				Description: new("description"),
+				StartDate:   internal.TimeRange{From: &now},
+				DueDate:     internal.TimeRange{From: &now, To: new(now.Add(time.Hour))},
+				Sort:        internal.SearchSortPriority,
+				Size:        10,
+			},
+			false,
+		},
+		{
+			"ERR: range",
+			internal.SearchParams{
+				DueDate: internal.TimeRange{From: &now, To: &now},
+			},
+			true,
+		},
+		{
+			"ERR: sort",
+			internal.SearchParams{
+				Sort: "description",
+			},
+			true,
+		},
+		{
+			"OK: cursor",
+			internal.SearchParams{
+				Description: new("description"),
+				Cursor:      "WzEuMCwiaWQiXQ",
+				Size:        10,
+			},
+			false,
+		},
+		{
+			"ERR: size",
+			internal.SearchParams{
+				Size: -1,
+			},
+			true,
+		},
+		{
+			"ERR: cursor and from",
+			internal.SearchParams{
+				Cursor: "WzEuMCwiaWQiXQ",
+				From:   10,
+			},
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if err := tt.input.Validate(); (err != nil) != tt.withErr {
+				t.Fatalf("expected error %t, got %v", tt.withErr, err)
+			}
+		})
+	}
+}
+
+func TestSuggestParams_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		input   internal.SuggestParams
+		withErr bool
+	}{
+		{
+			"OK",
+			internal.SuggestParams{
+				Prefix: "buy mi",
+				Size:   5,
+			},
+			false,
+		},
+		{
+			"ERR: prefix",
+			internal.SuggestParams{
+				Size: 5,
+			},
+			true,
+		},
+		{
+			"ERR: size",
+			internal.SuggestParams{
+				Prefix: "buy",
+				Size:   21,
+			},
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if err := tt.input.Validate(); (err != nil) != tt.withErr {
+				t.Fatalf("expected error %t, got %v", tt.withErr, err)
+			}
+		})
+	}
+}
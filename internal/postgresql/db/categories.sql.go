@@ -0,0 +1,102 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.31.1
+// source: categories.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+const DeleteTaskCategories = `-- name: DeleteTaskCategories :exec
+DELETE FROM
+  tasks_categories
+WHERE
+  task_id = $1
+`
+
+func (q *Queries) DeleteTaskCategories(ctx context.Context, taskID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, DeleteTaskCategories, taskID)
+	return err
+}
+
+const InsertCategory = `-- name: InsertCategory :one
+INSERT INTO categories (
+  name
+)
+VALUES (
+  $1
+)
+ON CONFLICT (name) DO UPDATE SET name = EXCLUDED.name
+RETURNING id
+`
+
+func (q *Queries) InsertCategory(ctx context.Context, name string) (uuid.UUID, error) {
+	row := q.db.QueryRow(ctx, InsertCategory, name)
+	var id uuid.UUID
+	err := row.Scan(&id)
+	return id, err
+}
+
+const InsertTaskCategory = `-- name: InsertTaskCategory :exec
+INSERT INTO tasks_categories (
+  task_id,
+  category_id
+)
+VALUES (
+  $1,
+  $2
+)
+ON CONFLICT DO NOTHING
+`
+
+type InsertTaskCategoryParams struct {
+	TaskID     uuid.UUID
+	CategoryID uuid.UUID
+}
+
+func (q *Queries) InsertTaskCategory(ctx context.Context, arg InsertTaskCategoryParams) error {
+	_, err := q.db.Exec(ctx, InsertTaskCategory, arg.TaskID, arg.CategoryID)
+	return err
+}
+
+const SelectTasksCategories = `-- name: SelectTasksCategories :many
+SELECT
+  tc.task_id,
+  c.name
+FROM
+  tasks_categories tc
+INNER JOIN categories c ON c.id = tc.category_id
+WHERE
+  tc.task_id = ANY($1::uuid[])
+ORDER BY
+  c.name
+`
+
+type SelectTasksCategoriesRow struct {
+	TaskID uuid.UUID
+	Name   string
+}
+
+func (q *Queries) SelectTasksCategories(ctx context.Context, taskIds []uuid.UUID) ([]SelectTasksCategoriesRow, error) {
+	rows, err := q.db.Query(ctx, SelectTasksCategories, taskIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SelectTasksCategoriesRow{}
+	for rows.Next() {
+		var i SelectTasksCategoriesRow
+		if err := rows.Scan(&i.TaskID, &i.Name); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -1,6 +1,6 @@
 // Code generated by sqlc. DO NOT EDIT.
 // versions:
-//   sqlc v1.30.0
+//   sqlc v1.31.1
 
 package db
 
@@ -56,6 +56,35 @@ func (ns NullPriority) Value() (driver.Value, error) {
 	return string(ns.Priority), nil
 }
 
+type Categories struct {
+	ID   uuid.UUID
+	Name string
+}
+
+type Outbox struct {
+	ID            uuid.UUID
+	EventType     string
+	TaskID        uuid.UUID
+	Payload       []byte
+	Attempts      int32
+	CreatedAt     pgtype.Timestamp
+	NextAttemptAt pgtype.Timestamp
+	DeliveredAt   pgtype.Timestamp
+	DeadAt        pgtype.Timestamp
+}
+
+type ProcessedEvents struct {
+	Consumer    string
+	EventID     string
+	ProcessedAt pgtype.Timestamp
+}
+
+type ProcessedTaskVersions struct {
+	Consumer string
+	TaskID   string
+	Version  int64
+}
+
 type Tasks struct {
 	ID          uuid.UUID
 	Description string
@@ -63,4 +92,13 @@ type Tasks struct {
 	StartDate   pgtype.Timestamp
 	DueDate     pgtype.Timestamp
 	Done        bool
+	ParentID    uuid.NullUUID
+	Position    int32
+	Version     int64
+	Search      interface{}
+}
+
+type TasksCategories struct {
+	TaskID     uuid.UUID
+	CategoryID uuid.UUID
 }
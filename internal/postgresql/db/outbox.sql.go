@@ -0,0 +1,166 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.31.1
+// source: outbox.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const ClaimOutboxEvents = `-- name: ClaimOutboxEvents :many
+UPDATE outbox SET
+  next_attempt_at = NOW() + $1::interval
+WHERE id IN (
+  SELECT
+    pending.id
+  FROM
+    outbox pending
+  WHERE
+    pending.delivered_at IS NULL AND
+    pending.dead_at IS NULL AND
+    pending.next_attempt_at <= NOW() AND
+    NOT EXISTS (
+      SELECT
+        1
+      FROM
+        outbox older
+      WHERE
+        older.task_id = pending.task_id AND
+        older.delivered_at IS NULL AND
+        older.dead_at IS NULL AND
+        older.created_at < pending.created_at
+    )
+  ORDER BY
+    pending.created_at
+  LIMIT $2
+  FOR UPDATE SKIP LOCKED
+)
+RETURNING
+  id,
+  event_type,
+  payload,
+  attempts,
+  created_at
+`
+
+type ClaimOutboxEventsParams struct {
+	Lease     pgtype.Interval
+	MaxEvents int32
+}
+
+type ClaimOutboxEventsRow struct {
+	ID        uuid.UUID
+	EventType string
+	Payload   []byte
+	Attempts  int32
+	CreatedAt pgtype.Timestamp
+}
+
+func (q *Queries) ClaimOutboxEvents(ctx context.Context, arg ClaimOutboxEventsParams) ([]ClaimOutboxEventsRow, error) {
+	rows, err := q.db.Query(ctx, ClaimOutboxEvents, arg.Lease, arg.MaxEvents)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ClaimOutboxEventsRow{}
+	for rows.Next() {
+		var i ClaimOutboxEventsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.EventType,
+			&i.Payload,
+			&i.Attempts,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const DeleteDeliveredOutboxEvents = `-- name: DeleteDeliveredOutboxEvents :execrows
+DELETE FROM outbox
+WHERE
+  delivered_at < NOW() - $1::interval
+`
+
+func (q *Queries) DeleteDeliveredOutboxEvents(ctx context.Context, retention pgtype.Interval) (int64, error) {
+	result, err := q.db.Exec(ctx, DeleteDeliveredOutboxEvents, retention)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const InsertOutboxEvent = `-- name: InsertOutboxEvent :exec
+INSERT INTO outbox (
+  event_type,
+  task_id,
+  payload
+)
+VALUES (
+  $1,
+  $2,
+  $3
+)
+`
+
+type InsertOutboxEventParams struct {
+	EventType string
+	TaskID    uuid.UUID
+	Payload   []byte
+}
+
+func (q *Queries) InsertOutboxEvent(ctx context.Context, arg InsertOutboxEventParams) error {
+	_, err := q.db.Exec(ctx, InsertOutboxEvent, arg.EventType, arg.TaskID, arg.Payload)
+	return err
+}
+
+const MarkOutboxEventDead = `-- name: MarkOutboxEventDead :exec
+UPDATE outbox SET
+  attempts = attempts + 1,
+  dead_at  = NOW()
+WHERE id = $1
+`
+
+func (q *Queries) MarkOutboxEventDead(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.Exec(ctx, MarkOutboxEventDead, id)
+	return err
+}
+
+const MarkOutboxEventDelivered = `-- name: MarkOutboxEventDelivered :exec
+UPDATE outbox SET
+  delivered_at = NOW()
+WHERE id = $1
+`
+
+func (q *Queries) MarkOutboxEventDelivered(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.Exec(ctx, MarkOutboxEventDelivered, id)
+	return err
+}
+
+const MarkOutboxEventFailed = `-- name: MarkOutboxEventFailed :exec
+UPDATE outbox SET
+  attempts        = attempts + 1,
+  next_attempt_at = NOW() + $1::interval
+WHERE id = $2
+`
+
+type MarkOutboxEventFailedParams struct {
+	RetryIn pgtype.Interval
+	ID      uuid.UUID
+}
+
+func (q *Queries) MarkOutboxEventFailed(ctx context.Context, arg MarkOutboxEventFailedParams) error {
+	_, err := q.db.Exec(ctx, MarkOutboxEventFailed, arg.RetryIn, arg.ID)
+	return err
+}
@@ -0,0 +1,107 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.31.1
+// source: processed_events.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const DeleteExpiredProcessedEvents = `-- name: DeleteExpiredProcessedEvents :exec
+DELETE FROM processed_events
+WHERE
+  consumer = $1 AND
+  processed_at < NOW() - $2::interval
+`
+
+type DeleteExpiredProcessedEventsParams struct {
+	Consumer  string
+	Retention pgtype.Interval
+}
+
+func (q *Queries) DeleteExpiredProcessedEvents(ctx context.Context, arg DeleteExpiredProcessedEventsParams) error {
+	_, err := q.db.Exec(ctx, DeleteExpiredProcessedEvents, arg.Consumer, arg.Retention)
+	return err
+}
+
+const InsertProcessedEvent = `-- name: InsertProcessedEvent :exec
+INSERT INTO processed_events (
+  consumer,
+  event_id
+)
+VALUES (
+  $1,
+  $2
+)
+ON CONFLICT (consumer, event_id) DO NOTHING
+`
+
+type InsertProcessedEventParams struct {
+	Consumer string
+	EventID  string
+}
+
+func (q *Queries) InsertProcessedEvent(ctx context.Context, arg InsertProcessedEventParams) error {
+	_, err := q.db.Exec(ctx, InsertProcessedEvent, arg.Consumer, arg.EventID)
+	return err
+}
+
+const SelectEventProcessed = `-- name: SelectEventProcessed :one
+SELECT
+  EXISTS (
+    SELECT 1 FROM processed_events e
+    WHERE e.consumer = $1 AND e.event_id = $2
+  ) OR EXISTS (
+    SELECT 1 FROM processed_task_versions v
+    WHERE v.consumer = $1 AND v.task_id = $3 AND v.version >= $4
+  ) AS processed
+`
+
+type SelectEventProcessedParams struct {
+	Consumer string
+	EventID  string
+	TaskID   string
+	Version  int64
+}
+
+func (q *Queries) SelectEventProcessed(ctx context.Context, arg SelectEventProcessedParams) (pgtype.Bool, error) {
+	row := q.db.QueryRow(ctx, SelectEventProcessed,
+		arg.Consumer,
+		arg.EventID,
+		arg.TaskID,
+		arg.Version,
+	)
+	var processed pgtype.Bool
+	err := row.Scan(&processed)
+	return processed, err
+}
+
+const UpsertProcessedTaskVersion = `-- name: UpsertProcessedTaskVersion :exec
+INSERT INTO processed_task_versions (
+  consumer,
+  task_id,
+  version
+)
+VALUES (
+  $1,
+  $2,
+  $3
+)
+ON CONFLICT (consumer, task_id) DO UPDATE SET
+  version = GREATEST(processed_task_versions.version, EXCLUDED.version)
+`
+
+type UpsertProcessedTaskVersionParams struct {
+	Consumer string
+	TaskID   string
+	Version  int64
+}
+
+func (q *Queries) UpsertProcessedTaskVersion(ctx context.Context, arg UpsertProcessedTaskVersionParams) error {
+	_, err := q.db.Exec(ctx, UpsertProcessedTaskVersion, arg.Consumer, arg.TaskID, arg.Version)
+	return err
+}
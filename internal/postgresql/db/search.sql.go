@@ -0,0 +1,221 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.31.1
+// source: search.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const SearchTasks = `-- name: SearchTasks :many
+SELECT
+  id,
+  description,
+  priority,
+  start_date,
+  due_date,
+  done,
+  version,
+  CASE
+    WHEN $1::text IS NULL THEN ''
+    ELSE ts_headline('english', description, websearch_to_tsquery('english', $1::text), $2::text)
+  END::text AS highlight
+FROM
+  tasks
+WHERE
+  parent_id IS NULL AND
+  ($1::text IS NULL OR search @@ websearch_to_tsquery('english', $1::text)) AND
+  ($3::priority IS NULL OR priority = $3::priority) AND
+  ($4::boolean IS NULL OR done = $4::boolean) AND
+  ($5::timestamp IS NULL OR start_date >= $5::timestamp) AND
+  ($6::timestamp IS NULL OR start_date < $6::timestamp) AND
+  ($7::timestamp IS NULL OR due_date >= $7::timestamp) AND
+  ($8::timestamp IS NULL OR due_date < $8::timestamp)
+ORDER BY
+  CASE WHEN $9::text = 'dueDate' THEN due_date END ASC NULLS LAST,
+  CASE WHEN $9::text = 'priority' THEN priority END DESC,
+  CASE
+    WHEN $1::text IS NULL THEN 0
+    ELSE ts_rank(search, websearch_to_tsquery('english', $1::text))
+  END DESC,
+  id
+OFFSET $10
+LIMIT $11
+`
+
+type SearchTasksParams struct {
+	Description     pgtype.Text
+	HeadlineOptions string
+	Priority        NullPriority
+	Done            pgtype.Bool
+	StartFrom       pgtype.Timestamp
+	StartTo         pgtype.Timestamp
+	DueFrom         pgtype.Timestamp
+	DueTo           pgtype.Timestamp
+	Sort            string
+	SkipTasks       int32
+	MaxTasks        int32
+}
+
+type SearchTasksRow struct {
+	ID          uuid.UUID
+	Description string
+	Priority    Priority
+	StartDate   pgtype.Timestamp
+	DueDate     pgtype.Timestamp
+	Done        bool
+	Version     int64
+	Highlight   string
+}
+
+func (q *Queries) SearchTasks(ctx context.Context, arg SearchTasksParams) ([]SearchTasksRow, error) {
+	rows, err := q.db.Query(ctx, SearchTasks,
+		arg.Description,
+		arg.HeadlineOptions,
+		arg.Priority,
+		arg.Done,
+		arg.StartFrom,
+		arg.StartTo,
+		arg.DueFrom,
+		arg.DueTo,
+		arg.Sort,
+		arg.SkipTasks,
+		arg.MaxTasks,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SearchTasksRow{}
+	for rows.Next() {
+		var i SearchTasksRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Description,
+			&i.Priority,
+			&i.StartDate,
+			&i.DueDate,
+			&i.Done,
+			&i.Version,
+			&i.Highlight,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const SearchTasksFacets = `-- name: SearchTasksFacets :many
+SELECT
+  priority,
+  done,
+  COUNT(*) AS total
+FROM
+  tasks
+WHERE
+  parent_id IS NULL AND
+  ($1::text IS NULL OR search @@ websearch_to_tsquery('english', $1::text)) AND
+  ($2::priority IS NULL OR priority = $2::priority) AND
+  ($3::boolean IS NULL OR done = $3::boolean) AND
+  ($4::timestamp IS NULL OR start_date >= $4::timestamp) AND
+  ($5::timestamp IS NULL OR start_date < $5::timestamp) AND
+  ($6::timestamp IS NULL OR due_date >= $6::timestamp) AND
+  ($7::timestamp IS NULL OR due_date < $7::timestamp)
+GROUP BY
+  priority,
+  done
+`
+
+type SearchTasksFacetsParams struct {
+	Description pgtype.Text
+	Priority    NullPriority
+	Done        pgtype.Bool
+	StartFrom   pgtype.Timestamp
+	StartTo     pgtype.Timestamp
+	DueFrom     pgtype.Timestamp
+	DueTo       pgtype.Timestamp
+}
+
+type SearchTasksFacetsRow struct {
+	Priority Priority
+	Done     bool
+	Total    int64
+}
+
+func (q *Queries) SearchTasksFacets(ctx context.Context, arg SearchTasksFacetsParams) ([]SearchTasksFacetsRow, error) {
+	rows, err := q.db.Query(ctx, SearchTasksFacets,
+		arg.Description,
+		arg.Priority,
+		arg.Done,
+		arg.StartFrom,
+		arg.StartTo,
+		arg.DueFrom,
+		arg.DueTo,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SearchTasksFacetsRow{}
+	for rows.Next() {
+		var i SearchTasksFacetsRow
+		if err := rows.Scan(&i.Priority, &i.Done, &i.Total); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const SuggestTasks = `-- name: SuggestTasks :many
+SELECT
+  description
+FROM
+  tasks
+WHERE
+  parent_id IS NULL AND
+  search @@ to_tsquery('english', $1::text)
+GROUP BY
+  description
+ORDER BY
+  MAX(ts_rank(search, to_tsquery('english', $1::text))) DESC,
+  description
+LIMIT $2
+`
+
+type SuggestTasksParams struct {
+	Query    string
+	MaxTasks int32
+}
+
+func (q *Queries) SuggestTasks(ctx context.Context, arg SuggestTasksParams) ([]string, error) {
+	rows, err := q.db.Query(ctx, SuggestTasks, arg.Query, arg.MaxTasks)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var description string
+		if err := rows.Scan(&description); err != nil {
+			return nil, err
+		}
+		items = append(items, description)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -1,6 +1,6 @@
 // Code generated by sqlc. DO NOT EDIT.
 // versions:
-//   sqlc v1.30.0
+//   sqlc v1.31.1
 // source: tasks.sql
 
 package db
@@ -12,23 +12,59 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const CountTasks = `-- name: CountTasks :one
+SELECT
+  COUNT(*)
+FROM
+  tasks
+WHERE
+  parent_id IS NULL
+`
+
+func (q *Queries) CountTasks(ctx context.Context) (int64, error) {
+	row := q.db.QueryRow(ctx, CountTasks)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const DeleteSubTasks = `-- name: DeleteSubTasks :exec
+DELETE FROM
+  tasks
+WHERE
+  parent_id = $1
+`
+
+func (q *Queries) DeleteSubTasks(ctx context.Context, parentID uuid.NullUUID) error {
+	_, err := q.db.Exec(ctx, DeleteSubTasks, parentID)
+	return err
+}
+
 const DeleteTask = `-- name: DeleteTask :one
 DELETE FROM
   tasks
 WHERE
-  id = $1
-RETURNING id AS res
+  parent_id IS NULL AND
+  id = $1 AND ($2::bigint IS NULL OR version = $2)
+RETURNING version
 `
 
-func (q *Queries) DeleteTask(ctx context.Context, id uuid.UUID) (uuid.UUID, error) {
-	row := q.db.QueryRow(ctx, DeleteTask, id)
-	var res uuid.UUID
-	err := row.Scan(&res)
-	return res, err
+type DeleteTaskParams struct {
+	ID      uuid.UUID
+	Version pgtype.Int8
+}
+
+func (q *Queries) DeleteTask(ctx context.Context, arg DeleteTaskParams) (int64, error) {
+	row := q.db.QueryRow(ctx, DeleteTask, arg.ID, arg.Version)
+	var version int64
+	err := row.Scan(&version)
+	return version, err
 }
 
 const InsertTask = `-- name: InsertTask :one
 INSERT INTO tasks (
+  parent_id,
+  position,
   description,
   priority,
   start_date,
@@ -38,28 +74,143 @@ VALUES (
   $1,
   $2,
   $3,
-  $4
+  $4,
+  $5,
+  $6
 )
-RETURNING id
+RETURNING id, version
 `
 
 type InsertTaskParams struct {
+	ParentID    uuid.NullUUID
+	Position    int32
 	Description string
 	Priority    Priority
 	StartDate   pgtype.Timestamp
 	DueDate     pgtype.Timestamp
 }
 
-func (q *Queries) InsertTask(ctx context.Context, arg InsertTaskParams) (uuid.UUID, error) {
+type InsertTaskRow struct {
+	ID      uuid.UUID
+	Version int64
+}
+
+func (q *Queries) InsertTask(ctx context.Context, arg InsertTaskParams) (InsertTaskRow, error) {
 	row := q.db.QueryRow(ctx, InsertTask,
+		arg.ParentID,
+		arg.Position,
 		arg.Description,
 		arg.Priority,
 		arg.StartDate,
 		arg.DueDate,
 	)
-	var id uuid.UUID
-	err := row.Scan(&id)
-	return id, err
+	var i InsertTaskRow
+	err := row.Scan(&i.ID, &i.Version)
+	return i, err
+}
+
+const LockTask = `-- name: LockTask :one
+SELECT
+  id
+FROM
+  tasks
+WHERE
+  parent_id IS NULL AND
+  id = $1
+FOR UPDATE
+`
+
+func (q *Queries) LockTask(ctx context.Context, id uuid.UUID) (uuid.UUID, error) {
+	row := q.db.QueryRow(ctx, LockTask, id)
+	var id_2 uuid.UUID
+	err := row.Scan(&id_2)
+	return id_2, err
+}
+
+const SelectSubTasks = `-- name: SelectSubTasks :many
+WITH RECURSIVE sub_tasks AS (
+  SELECT
+    id,
+    parent_id,
+    position,
+    description,
+    priority,
+    start_date,
+    due_date,
+    done,
+    version
+  FROM
+    tasks
+  WHERE
+    tasks.parent_id = $1::uuid
+  UNION ALL
+  SELECT
+    t.id,
+    t.parent_id,
+    t.position,
+    t.description,
+    t.priority,
+    t.start_date,
+    t.due_date,
+    t.done,
+    t.version
+  FROM
+    tasks t
+  INNER JOIN sub_tasks s ON t.parent_id = s.id
+)
+SELECT
+  id,
+  parent_id,
+  description,
+  priority,
+  start_date,
+  due_date,
+  done,
+  version
+FROM
+  sub_tasks
+ORDER BY
+  position
+`
+
+type SelectSubTasksRow struct {
+	ID          uuid.UUID
+	ParentID    uuid.NullUUID
+	Description string
+	Priority    Priority
+	StartDate   pgtype.Timestamp
+	DueDate     pgtype.Timestamp
+	Done        bool
+	Version     int64
+}
+
+func (q *Queries) SelectSubTasks(ctx context.Context, parentID uuid.UUID) ([]SelectSubTasksRow, error) {
+	rows, err := q.db.Query(ctx, SelectSubTasks, parentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SelectSubTasksRow{}
+	for rows.Next() {
+		var i SelectSubTasksRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ParentID,
+			&i.Description,
+			&i.Priority,
+			&i.StartDate,
+			&i.DueDate,
+			&i.Done,
+			&i.Version,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
 const SelectTask = `-- name: SelectTask :one
@@ -69,17 +220,29 @@ SELECT
   priority,
   start_date,
   due_date,
-  done
+  done,
+  version
 FROM
   tasks
 WHERE
+  parent_id IS NULL AND
   id = $1
 LIMIT 1
 `
 
-func (q *Queries) SelectTask(ctx context.Context, id uuid.UUID) (Tasks, error) {
+type SelectTaskRow struct {
+	ID          uuid.UUID
+	Description string
+	Priority    Priority
+	StartDate   pgtype.Timestamp
+	DueDate     pgtype.Timestamp
+	Done        bool
+	Version     int64
+}
+
+func (q *Queries) SelectTask(ctx context.Context, id uuid.UUID) (SelectTaskRow, error) {
 	row := q.db.QueryRow(ctx, SelectTask, id)
-	var i Tasks
+	var i SelectTaskRow
 	err := row.Scan(
 		&i.ID,
 		&i.Description,
@@ -87,38 +250,108 @@ func (q *Queries) SelectTask(ctx context.Context, id uuid.UUID) (Tasks, error) {
 		&i.StartDate,
 		&i.DueDate,
 		&i.Done,
+		&i.Version,
 	)
 	return i, err
 }
 
-const UpdateTask = `-- name: UpdateTask :one
-UPDATE tasks SET
-  description = $1,
-  priority    = $2,
-  start_date  = $3,
-  due_date    = $4,
-  done        = $5
-WHERE id = $6
-RETURNING id AS res
+const SelectTasksAfter = `-- name: SelectTasksAfter :many
+SELECT
+  id,
+  description,
+  priority,
+  start_date,
+  due_date,
+  done,
+  version
+FROM
+  tasks
+WHERE
+  parent_id IS NULL AND
+  id > $1
+ORDER BY
+  id
+LIMIT $2
 `
 
-type UpdateTaskParams struct {
+type SelectTasksAfterParams struct {
+	After    uuid.UUID
+	MaxTasks int32
+}
+
+type SelectTasksAfterRow struct {
+	ID          uuid.UUID
 	Description string
 	Priority    Priority
 	StartDate   pgtype.Timestamp
 	DueDate     pgtype.Timestamp
 	Done        bool
-	ID          uuid.UUID
+	Version     int64
+}
+
+func (q *Queries) SelectTasksAfter(ctx context.Context, arg SelectTasksAfterParams) ([]SelectTasksAfterRow, error) {
+	rows, err := q.db.Query(ctx, SelectTasksAfter, arg.After, arg.MaxTasks)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SelectTasksAfterRow{}
+	for rows.Next() {
+		var i SelectTasksAfterRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Description,
+			&i.Priority,
+			&i.StartDate,
+			&i.DueDate,
+			&i.Done,
+			&i.Version,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const UpdateTask = `-- name: UpdateTask :one
+UPDATE tasks SET
+  description = COALESCE($1, description),
+  priority    = COALESCE($2, priority),
+  start_date  = CASE WHEN $3::boolean THEN $4 ELSE start_date END,
+  due_date    = CASE WHEN $5::boolean THEN $6 ELSE due_date END,
+  done        = COALESCE($7, done),
+  version     = version + 1
+WHERE parent_id IS NULL AND id = $8 AND ($9::bigint IS NULL OR version = $9)
+RETURNING id AS res
+`
+
+type UpdateTaskParams struct {
+	Description  pgtype.Text
+	Priority     NullPriority
+	SetStartDate bool
+	StartDate    pgtype.Timestamp
+	SetDueDate   bool
+	DueDate      pgtype.Timestamp
+	Done         pgtype.Bool
+	ID           uuid.UUID
+	Version      pgtype.Int8
 }
 
 func (q *Queries) UpdateTask(ctx context.Context, arg UpdateTaskParams) (uuid.UUID, error) {
 	row := q.db.QueryRow(ctx, UpdateTask,
 		arg.Description,
 		arg.Priority,
+		arg.SetStartDate,
 		arg.StartDate,
+		arg.SetDueDate,
 		arg.DueDate,
 		arg.Done,
 		arg.ID,
+		arg.Version,
 	)
 	var res uuid.UUID
 	err := row.Scan(&res)
@@ -0,0 +1,73 @@
+package postgresql
+
+import (
+	"context"
+	"time"
+
+	"github.com/MarioCarrion/todo-api-microservice-example/internal"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/postgresql/db"
+)
+
+// DeduplicationStore represents the repository used for tracking the events processed by a consumer. Event IDs are
+// deleted after the configured TTL, Task versions are kept.
+type DeduplicationStore struct {
+	db       DBTX
+	q        *db.Queries
+	consumer string
+	ttl      time.Duration
+}
+
+// NewDeduplicationStore instantiates the DeduplicationStore repository, consumer identifies the events processed by
+// different consumers and ttl indicates how long the event IDs are kept.
+func NewDeduplicationStore(d DBTX, consumer string, ttl time.Duration) *DeduplicationStore {
+	return &DeduplicationStore{
+		db:       d,
+		q:        db.New(d),
+		consumer: consumer,
+		ttl:      ttl,
+	}
+}
+
+// Processed indicates whether the event was already processed.
+func (d *DeduplicationStore) Processed(ctx context.Context, event internal.Event) (bool, error) {
+	processed, err := d.q.SelectEventProcessed(ctx, db.SelectEventProcessedParams{
+		Consumer: d.consumer,
+		EventID:  event.ID,
+		TaskID:   event.Task.ID,
+		Version:  event.Version(),
+	})
+	if err != nil {
+		return false, internal.WrapErrorf(err, errorCode(err), "select event processed")
+	}
+
+	return processed.Bool, nil
+}
+
+// MarkProcessed records the event ID and the version of the Task, expired event IDs are deleted as well.
+func (d *DeduplicationStore) MarkProcessed(ctx context.Context, event internal.Event) error {
+	return transaction(ctx, d.db, func(q *db.Queries) error {
+		if err := q.InsertProcessedEvent(ctx, db.InsertProcessedEventParams{
+			Consumer: d.consumer,
+			EventID:  event.ID,
+		}); err != nil {
+			return internal.WrapErrorf(err, errorCode(err), "insert processed event")
+		}
+
+		if err := q.UpsertProcessedTaskVersion(ctx, db.UpsertProcessedTaskVersionParams{
+			Consumer: d.consumer,
+			TaskID:   event.Task.ID,
+			Version:  event.Version(),
+		}); err != nil {
+			return internal.WrapErrorf(err, errorCode(err), "upsert processed task version")
+		}
+
+		if err := q.DeleteExpiredProcessedEvents(ctx, db.DeleteExpiredProcessedEventsParams{
+			Consumer:  d.consumer,
+			Retention: newInterval(d.ttl),
+		}); err != nil {
+			return internal.WrapErrorf(err, errorCode(err), "delete expired processed events")
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,81 @@
+package postgresql_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/MarioCarrion/todo-api-microservice-example/internal"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/postgresql"
+)
+
+func TestDeduplicationStore_Processed(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Processed: OK", func(t *testing.T) {
+		t.Parallel()
+
+		pool := newDB(t)
+
+		store := postgresql.NewDeduplicationStore(pool, "indexer", time.Hour)
+
+		updated := internal.Event{
+			ID:   "1c7f0a3e-a5b4-4c4f-9f0c-6a0a9b3f0e8d/2/Task.Updated",
+			Type: internal.EventTypeTaskUpdated,
+			Task: internal.Task{ID: "1c7f0a3e-a5b4-4c4f-9f0c-6a0a9b3f0e8d", Version: 2},
+		}
+
+		processed, err := store.Processed(t.Context(), updated)
+		if err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+
+		if processed {
+			t.Fatalf("expected event not to be processed")
+		}
+
+		if err := store.MarkProcessed(t.Context(), updated); err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+
+		older := internal.Event{
+			ID:   "1c7f0a3e-a5b4-4c4f-9f0c-6a0a9b3f0e8d/1/Task.Created",
+			Type: internal.EventTypeTaskCreated,
+			Task: internal.Task{ID: updated.Task.ID, Version: 1},
+		}
+
+		deleted := internal.Event{
+			ID:   "1c7f0a3e-a5b4-4c4f-9f0c-6a0a9b3f0e8d/2/Task.Deleted",
+			Type: internal.EventTypeTaskDeleted,
+			Task: internal.Task{ID: updated.Task.ID, Version: 2},
+		}
+
+		for _, tt := range []struct {
+			event    internal.Event
+			expected bool
+		}{
+			{event: updated, expected: true},
+			{event: older, expected: true},
+			{event: deleted, expected: false},
+		} {
+			processed, err := store.Processed(t.Context(), tt.event)
+			if err != nil {
+				t.Fatalf("expected no error, got %s", err)
+			}
+
+			if processed != tt.expected {
+				t.Fatalf("expected %s processed to be %t, got %t", tt.event.ID, tt.expected, processed)
+			}
+		}
+
+		//- Other consumers track their own events
+
+		processed, err = postgresql.NewDeduplicationStore(pool, "other", time.Hour).Processed(t.Context(), updated)
+		if err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+
+		if processed {
+			t.Fatalf("expected event not to be processed by other consumer")
+		}
+	})
+}
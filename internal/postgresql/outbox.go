@@ -0,0 +1,150 @@
+package postgresql
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/MarioCarrion/todo-api-microservice-example/internal"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/postgresql/db"
+)
+
+// outboxPayload is stored as JSON, the Task fields are embedded to keep supporting events stored before changes were
+// included.
+type outboxPayload struct {
+	internal.Task
+
+	Changes *internal.TaskChanges `json:",omitempty"`
+}
+
+// Outbox represents the repository used for interacting with the Task events pending to be published.
+type Outbox struct {
+	q *db.Queries
+}
+
+// NewOutbox instantiates the Outbox repository.
+func NewOutbox(d db.DBTX) *Outbox {
+	return &Outbox{
+		q: db.New(d),
+	}
+}
+
+// Claim returns up to limit pending events, sorted by creation. Claimed events are not returned again until the
+// lease expires, this allows running multiple relays concurrently because locked rows are skipped. Only the oldest
+// pending event of each task is claimed, so events of the same task are published in order. Dead events are never
+// claimed.
+func (o *Outbox) Claim(ctx context.Context, limit int32, lease time.Duration) ([]internal.Event, error) {
+	rows, err := o.q.ClaimOutboxEvents(ctx, db.ClaimOutboxEventsParams{
+		Lease:     newInterval(lease),
+		MaxEvents: limit,
+	})
+	if err != nil {
+		return nil, internal.WrapErrorf(err, errorCode(err), "claim outbox events")
+	}
+
+	slices.SortFunc(rows, func(a, b db.ClaimOutboxEventsRow) int {
+		return a.CreatedAt.Time.Compare(b.CreatedAt.Time)
+	})
+
+	res := make([]internal.Event, len(rows))
+
+	for i, row := range rows {
+		var payload outboxPayload
+
+		if err := json.Unmarshal(row.Payload, &payload); err != nil {
+			return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "json.Unmarshal")
+		}
+
+		res[i] = internal.Event{
+			ID:       row.ID.String(),
+			Type:     internal.EventType(row.EventType),
+			Task:     payload.Task,
+			Changes:  payload.Changes,
+			Attempts: row.Attempts,
+		}
+	}
+
+	return res, nil
+}
+
+// Delivered marks the event as published.
+func (o *Outbox) Delivered(ctx context.Context, id string) error {
+	val, err := uuid.Parse(id)
+	if err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "invalid uuid")
+	}
+
+	if err := o.q.MarkOutboxEventDelivered(ctx, val); err != nil {
+		return internal.WrapErrorf(err, errorCode(err), "mark outbox event delivered")
+	}
+
+	return nil
+}
+
+// Failed records a failed attempt to publish the event, it will be claimed again after retryIn.
+func (o *Outbox) Failed(ctx context.Context, id string, retryIn time.Duration) error {
+	val, err := uuid.Parse(id)
+	if err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "invalid uuid")
+	}
+
+	if err := o.q.MarkOutboxEventFailed(ctx, db.MarkOutboxEventFailedParams{
+		ID:      val,
+		RetryIn: newInterval(retryIn),
+	}); err != nil {
+		return internal.WrapErrorf(err, errorCode(err), "mark outbox event failed")
+	}
+
+	return nil
+}
+
+// Dead marks the event as failed permanently, it is not claimed again and stops blocking the following events of
+// the same task.
+func (o *Outbox) Dead(ctx context.Context, id string) error {
+	val, err := uuid.Parse(id)
+	if err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "invalid uuid")
+	}
+
+	if err := o.q.MarkOutboxEventDead(ctx, val); err != nil {
+		return internal.WrapErrorf(err, errorCode(err), "mark outbox event dead")
+	}
+
+	return nil
+}
+
+// DeleteDelivered deletes the events published more than retention ago, it returns the number of deleted events.
+func (o *Outbox) DeleteDelivered(ctx context.Context, retention time.Duration) (int64, error) {
+	res, err := o.q.DeleteDeliveredOutboxEvents(ctx, newInterval(retention))
+	if err != nil {
+		return 0, internal.WrapErrorf(err, errorCode(err), "delete delivered outbox events")
+	}
+
+	return res, nil
+}
+
+// insertEvent stores the event in the outbox, it must be called using the same transaction that changed the task.
+func insertEvent(ctx context.Context, q *db.Queries, event internal.Event) error {
+	id, err := uuid.Parse(event.Task.ID)
+	if err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "invalid uuid")
+	}
+
+	payload, err := json.Marshal(outboxPayload{Task: event.Task, Changes: event.Changes})
+	if err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "json.Marshal")
+	}
+
+	if err := q.InsertOutboxEvent(ctx, db.InsertOutboxEventParams{
+		EventType: string(event.Type),
+		TaskID:    id,
+		Payload:   payload,
+	}); err != nil {
+		return internal.WrapErrorf(err, errorCode(err), "insert outbox event")
+	}
+
+	return nil
+}
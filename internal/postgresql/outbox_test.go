@@ -0,0 +1,199 @@
+package postgresql_test
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/MarioCarrion/todo-api-microservice-example/internal"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/postgresql"
+)
+
+func TestOutbox_Claim(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Claim: OK", func(t *testing.T) {
+		t.Parallel()
+
+		pool := newDB(t)
+
+		store := postgresql.NewTask(pool)
+		outbox := postgresql.NewOutbox(pool)
+
+		task, err := store.Create(t.Context(), internal.CreateParams{
+			Description: "test",
+			Priority:    new(internal.PriorityNone),
+		})
+		if err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+
+		if err := store.Update(t.Context(), task.ID, internal.UpdateParams{IsDone: new(true)}); err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+
+		if _, err := store.Delete(t.Context(), task.ID, internal.DeleteParams{}); err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+
+		expected := []internal.EventType{
+			internal.EventTypeTaskCreated,
+			internal.EventTypeTaskUpdated,
+			internal.EventTypeTaskDeleted,
+		}
+
+		// Events of the same task are claimed one at a time, after the previous one is delivered.
+		events := make([]internal.Event, 0, len(expected))
+
+		for range expected {
+			claimed, err := outbox.Claim(t.Context(), 10, time.Minute)
+			if err != nil || len(claimed) != 1 {
+				t.Fatalf("expected one event, got %d: %v", len(claimed), err)
+			}
+
+			// Claimed events are leased and the following ones wait for them.
+			if leased, _ := outbox.Claim(t.Context(), 10, time.Minute); len(leased) != 0 {
+				t.Fatalf("expected leased events not to be claimed, got %d", len(leased))
+			}
+
+			if err := outbox.Delivered(t.Context(), claimed[0].ID); err != nil {
+				t.Fatalf("expected no error, got %s", err)
+			}
+
+			events = append(events, claimed[0])
+		}
+
+		for i, event := range events {
+			if event.Type != expected[i] || event.Task.ID != task.ID {
+				t.Fatalf("expected event %s for task %s, got %s for %s", expected[i], task.ID, event.Type, event.Task.ID)
+			}
+		}
+
+		if !events[1].Task.IsDone || events[2].Task.Version != 2 {
+			t.Fatalf("expected events to include the task values, got %v", events)
+		}
+
+		changes := events[1].Changes
+		if changes == nil || changes.Previous.IsDone || changes.Previous.Version != 1 ||
+			!slices.Equal(changes.Fields, []internal.TaskField{internal.TaskFieldIsDone}) {
+			t.Fatalf("expected updated event to include the changes, got %+v", changes)
+		}
+
+		if events[0].Changes != nil || events[2].Changes != nil {
+			t.Fatalf("expected changes only in updated events, got %v", events)
+		}
+	})
+
+	t.Run("Claim: OK retrying failed events", func(t *testing.T) {
+		t.Parallel()
+
+		pool := newDB(t)
+
+		store := postgresql.NewTask(pool)
+		outbox := postgresql.NewOutbox(pool)
+
+		if _, err := store.Create(t.Context(), internal.CreateParams{
+			Description: "test",
+			Priority:    new(internal.PriorityNone),
+		}); err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+
+		events, err := outbox.Claim(t.Context(), 10, time.Minute)
+		if err != nil || len(events) != 1 {
+			t.Fatalf("expected one event, got %d: %v", len(events), err)
+		}
+
+		if err := outbox.Failed(t.Context(), events[0].ID, 0); err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+
+		events, err = outbox.Claim(t.Context(), 10, time.Minute)
+		if err != nil || len(events) != 1 || events[0].Attempts != 1 {
+			t.Fatalf("expected one retried event, got %v: %v", events, err)
+		}
+
+		if err := outbox.Delivered(t.Context(), events[0].ID); err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+
+		if events, _ := outbox.Claim(t.Context(), 10, time.Minute); len(events) != 0 {
+			t.Fatalf("expected delivered events not to be claimed, got %d", len(events))
+		}
+	})
+
+	t.Run("Claim: OK skipping dead events", func(t *testing.T) {
+		t.Parallel()
+
+		pool := newDB(t)
+
+		store := postgresql.NewTask(pool)
+		outbox := postgresql.NewOutbox(pool)
+
+		task, err := store.Create(t.Context(), internal.CreateParams{
+			Description: "test",
+			Priority:    new(internal.PriorityNone),
+		})
+		if err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+
+		if _, err := store.Delete(t.Context(), task.ID, internal.DeleteParams{}); err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+
+		events, err := outbox.Claim(t.Context(), 10, time.Minute)
+		if err != nil || len(events) != 1 {
+			t.Fatalf("expected one event, got %d: %v", len(events), err)
+		}
+
+		if err := outbox.Dead(t.Context(), events[0].ID); err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+
+		// The following event of the same task is not blocked by the dead one.
+		events, err = outbox.Claim(t.Context(), 10, time.Minute)
+		if err != nil || len(events) != 1 || events[0].Type != internal.EventTypeTaskDeleted {
+			t.Fatalf("expected the deleted event, got %v: %v", events, err)
+		}
+	})
+
+	t.Run("DeleteDelivered: OK", func(t *testing.T) {
+		t.Parallel()
+
+		pool := newDB(t)
+
+		store := postgresql.NewTask(pool)
+		outbox := postgresql.NewOutbox(pool)
+
+		for range 2 {
+			if _, err := store.Create(t.Context(), internal.CreateParams{
+				Description: "test",
+				Priority:    new(internal.PriorityNone),
+			}); err != nil {
+				t.Fatalf("expected no error, got %s", err)
+			}
+		}
+
+		events, err := outbox.Claim(t.Context(), 1, time.Minute)
+		if err != nil || len(events) != 1 {
+			t.Fatalf("expected one event, got %d: %v", len(events), err)
+		}
+
+		if err := outbox.Delivered(t.Context(), events[0].ID); err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+
+		if n, err := outbox.DeleteDelivered(t.Context(), time.Hour); err != nil || n != 0 {
+			t.Fatalf("expected recently delivered events to be kept, got %d: %v", n, err)
+		}
+
+		if n, err := outbox.DeleteDelivered(t.Context(), 0); err != nil || n != 1 {
+			t.Fatalf("expected one deleted event, got %d: %v", n, err)
+		}
+
+		if events, _ := outbox.Claim(t.Context(), 10, time.Minute); len(events) != 1 {
+			t.Fatalf("expected pending events to be kept, got %d", len(events))
+		}
+	})
+}
@@ -1,9 +1,13 @@
 package postgresql
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"time"
 
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgtype"
 
 	"github.com/MarioCarrion/todo-api-microservice-example/internal"
@@ -12,6 +16,54 @@ import (
 
 //go:generate sqlc generate
 
+// transaction executes f using a new transaction, the transaction is committed when f does not return an error,
+// otherwise it is rolled back and the error returned by f is returned as is.
+func transaction(ctx context.Context, d DBTX, f func(q *db.Queries) error) error {
+	tx, err := d.Begin(ctx)
+	if err != nil {
+		return internal.WrapErrorf(err, errorCode(err), "db.Begin")
+	}
+
+	if err := f(db.New(tx)); err != nil {
+		_ = tx.Rollback(ctx)
+
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return internal.WrapErrorf(err, errorCode(err), "tx.Commit")
+	}
+
+	return nil
+}
+
+// errorCode returns the code matching the received PostgreSQL error, see
+// https://www.postgresql.org/docs/current/errcodes-appendix.html for details.
+func errorCode(err error) internal.ErrorCode {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case "23505", // unique_violation
+			"23503", // foreign_key_violation
+			"40001": // serialization_failure
+			return internal.ErrorCodeConflict
+		case "53300", // too_many_connections
+			"57P01", // admin_shutdown
+			"57P03": // cannot_connect_now
+			return internal.ErrorCodeUnavailable
+		}
+
+		return internal.ErrorCodeUnknown
+	}
+
+	var connErr *pgconn.ConnectError
+	if errors.As(err, &connErr) || pgconn.Timeout(err) {
+		return internal.ErrorCodeUnavailable
+	}
+
+	return internal.ErrorCodeUnknown
+}
+
 func convertPriority(priority db.Priority) (internal.Priority, error) {
 	switch priority {
 	case db.PriorityNone:
@@ -62,3 +114,135 @@ func newPriority(p *internal.Priority) db.Priority {
 
 	return "invalid"
 }
+
+func newNullPriority(p *internal.Priority) db.NullPriority {
+	if p == nil {
+		return db.NullPriority{}
+	}
+
+	return db.NullPriority{
+		Priority: newPriority(p),
+		Valid:    true,
+	}
+}
+
+func newText(s *string) pgtype.Text {
+	if s == nil {
+		return pgtype.Text{}
+	}
+
+	return pgtype.Text{
+		String: *s,
+		Valid:  true,
+	}
+}
+
+func newInt8(i *int64) pgtype.Int8 {
+	if i == nil {
+		return pgtype.Int8{}
+	}
+
+	return pgtype.Int8{
+		Int64: *i,
+		Valid: true,
+	}
+}
+
+func newBool(b *bool) pgtype.Bool {
+	if b == nil {
+		return pgtype.Bool{}
+	}
+
+	return pgtype.Bool{
+		Bool:  *b,
+		Valid: true,
+	}
+}
+
+func newInterval(d time.Duration) pgtype.Interval {
+	return pgtype.Interval{
+		Microseconds: d.Microseconds(),
+		Valid:        true,
+	}
+}
+
+func newTask(id uuid.UUID,
+	description string,
+	priority db.Priority,
+	start, due pgtype.Timestamp,
+	done bool,
+	version int64,
+) (internal.Task, error) {
+	prio, err := convertPriority(priority)
+	if err != nil {
+		return internal.Task{}, internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "convert priority")
+	}
+
+	var dates *internal.Dates
+	if start.Valid || due.Valid {
+		dates = &internal.Dates{}
+
+		if start.Valid {
+			dates.Start = &start.Time
+		}
+
+		if due.Valid {
+			dates.Due = &due.Time
+		}
+	}
+
+	return internal.Task{
+		ID:          id.String(),
+		Description: description,
+		Priority:    &prio,
+		Dates:       dates,
+		IsDone:      done,
+		Version:     version,
+	}, nil
+}
+
+// newTaskTree assigns the sub-tasks and the categories to the root task, sub-tasks are expected to be sorted
+// by position.
+func newTaskTree(root internal.Task,
+	subTasks []db.SelectSubTasksRow,
+	categories []db.SelectTasksCategoriesRow,
+) (internal.Task, error) {
+	children := make(map[string][]internal.Task)
+
+	for _, row := range subTasks {
+		task, err := newTask(row.ID, row.Description, row.Priority, row.StartDate, row.DueDate, row.Done, row.Version)
+		if err != nil {
+			return internal.Task{}, err
+		}
+
+		parentID := row.ParentID.UUID.String()
+
+		children[parentID] = append(children[parentID], task)
+	}
+
+	names := make(map[string][]internal.Category)
+
+	for _, row := range categories {
+		id := row.TaskID.String()
+
+		names[id] = append(names[id], internal.Category(row.Name))
+	}
+
+	var build func(task internal.Task) internal.Task
+
+	build = func(task internal.Task) internal.Task {
+		task.Categories = names[task.ID]
+
+		if subs := children[task.ID]; len(subs) > 0 {
+			task.SubTasks = make([]internal.Task, len(subs))
+
+			for i, sub := range subs {
+				task.SubTasks[i] = build(sub)
+			}
+		}
+
+		return task
+	}
+
+	return build(root), nil
+}
@@ -1,9 +1,14 @@
 package postgresql
 
 import (
+	"errors"
+	"fmt"
 	"testing"
 	"time"
 
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgtype"
 
 	"github.com/MarioCarrion/todo-api-microservice-example/internal"
@@ -194,3 +199,224 @@ func Test_newPriority(t *testing.T) {
 		})
 	}
 }
+
+func Test_newTaskTree(t *testing.T) {
+	t.Parallel()
+
+	var (
+		rootID   = uuid.MustParse("44633fe3-b039-4fb3-a35f-a57fe3c906c7")
+		firstID  = uuid.MustParse("3f1f3c3e-52f8-4a59-a4a4-4f2a9b4f3a01")
+		secondID = uuid.MustParse("3f1f3c3e-52f8-4a59-a4a4-4f2a9b4f3a02")
+		nestedID = uuid.MustParse("3f1f3c3e-52f8-4a59-a4a4-4f2a9b4f3a03")
+	)
+
+	root := internal.Task{
+		ID:          rootID.String(),
+		Description: "root",
+		Priority:    new(internal.PriorityNone),
+	}
+
+	subTasks := []db.SelectSubTasksRow{
+		{
+			ID:          firstID,
+			ParentID:    uuid.NullUUID{UUID: rootID, Valid: true},
+			Description: "first",
+			Priority:    db.PriorityLow,
+		},
+		{
+			ID:          nestedID,
+			ParentID:    uuid.NullUUID{UUID: firstID, Valid: true},
+			Description: "nested",
+			Priority:    db.PriorityHigh,
+			Done:        true,
+		},
+		{
+			ID:          secondID,
+			ParentID:    uuid.NullUUID{UUID: rootID, Valid: true},
+			Description: "second",
+			Priority:    db.PriorityNone,
+		},
+	}
+
+	categories := []db.SelectTasksCategoriesRow{
+		{TaskID: rootID, Name: "home"},
+		{TaskID: rootID, Name: "work"},
+		{TaskID: nestedID, Name: "urgent"},
+	}
+
+	expected := internal.Task{
+		ID:          rootID.String(),
+		Description: "root",
+		Priority:    new(internal.PriorityNone),
+		Categories:  []internal.Category{"home", "work"},
+		SubTasks: []internal.Task{
+			{
+				ID:          firstID.String(),
+				Description: "first",
+				Priority:    new(internal.PriorityLow),
+				SubTasks: []internal.Task{
+					{
+						ID:          nestedID.String(),
+						Description: "nested",
+						Priority:    new(internal.PriorityHigh),
+						IsDone:      true,
+						Categories:  []internal.Category{"urgent"},
+					},
+				},
+			},
+			{
+				ID:          secondID.String(),
+				Description: "second",
+				Priority:    new(internal.PriorityNone),
+			},
+		},
+	}
+
+	actual, err := newTaskTree(root, subTasks, categories)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Fatalf("expected result does not match: %s", diff)
+	}
+
+	subTasks = append(subTasks, db.SelectSubTasksRow{
+		ID:       uuid.New(),
+		ParentID: uuid.NullUUID{UUID: rootID, Valid: true},
+		Priority: db.Priority("invalid"),
+	})
+
+	if _, err := newTaskTree(root, subTasks, categories); err == nil {
+		t.Fatalf("expected error, got nothing")
+	}
+}
+
+func Test_errorCode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		input    error
+		expected internal.ErrorCode
+	}{
+		{
+			name:     "unique violation",
+			input:    fmt.Errorf("wrapped: %w", &pgconn.PgError{Code: "23505"}),
+			expected: internal.ErrorCodeConflict,
+		},
+		{
+			name:     "serialization failure",
+			input:    &pgconn.PgError{Code: "40001"},
+			expected: internal.ErrorCodeConflict,
+		},
+		{
+			name:     "too many connections",
+			input:    &pgconn.PgError{Code: "53300"},
+			expected: internal.ErrorCodeUnavailable,
+		},
+		{
+			name:     "invalid text representation",
+			input:    &pgconn.PgError{Code: "22P02"},
+			expected: internal.ErrorCodeUnknown,
+		},
+		{
+			name:     "other",
+			input:    errors.New("other"),
+			expected: internal.ErrorCodeUnknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if result := errorCode(tt.input); result != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func Test_newHighlights(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{
+			name:     "no matches",
+			input:    "Buy milk",
+			expected: nil,
+		},
+		{
+			name:     "escaped",
+			input:    "Buy \x02milk\x03 & <bread>",
+			expected: []string{"Buy <em>milk</em> &amp; &lt;bread&gt;"},
+		},
+		{
+			name:     "fragments",
+			input:    "\x02milk\x03 first \x1f then \x02milk\x03 again",
+			expected: []string{"<em>milk</em> first", "then <em>milk</em> again"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if result := newHighlights(tt.input); !cmp.Equal(tt.expected, result) {
+				t.Errorf("expected result does not match: %s", cmp.Diff(tt.expected, result))
+			}
+		})
+	}
+}
+
+func Test_decodeCursor(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		input    string
+		expected int64
+		withErr  bool
+	}{
+		{
+			name:     "OK",
+			input:    "MjA",
+			expected: 20,
+		},
+		{
+			name:    "ERR: encoding",
+			input:   "!",
+			withErr: true,
+		},
+		{
+			name:    "ERR: not a number",
+			input:   "YWJj",
+			withErr: true,
+		},
+		{
+			name:    "ERR: negative",
+			input:   "LTE",
+			withErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := decodeCursor(tt.input)
+			if (err != nil) != tt.withErr {
+				t.Fatalf("expected error %t, got %v", tt.withErr, err)
+			}
+
+			if result != tt.expected {
+				t.Errorf("expected %d, got %d", tt.expected, result)
+			}
+		})
+	}
+}
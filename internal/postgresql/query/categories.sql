@@ -0,0 +1,38 @@
+-- name: InsertCategory :one
+INSERT INTO categories (
+  name
+)
+VALUES (
+  @name
+)
+ON CONFLICT (name) DO UPDATE SET name = EXCLUDED.name
+RETURNING id;
+
+-- name: InsertTaskCategory :exec
+INSERT INTO tasks_categories (
+  task_id,
+  category_id
+)
+VALUES (
+  @task_id,
+  @category_id
+)
+ON CONFLICT DO NOTHING;
+
+-- name: SelectTasksCategories :many
+SELECT
+  tc.task_id,
+  c.name
+FROM
+  tasks_categories tc
+INNER JOIN categories c ON c.id = tc.category_id
+WHERE
+  tc.task_id = ANY(@task_ids::uuid[])
+ORDER BY
+  c.name;
+
+-- name: DeleteTaskCategories :exec
+DELETE FROM
+  tasks_categories
+WHERE
+  task_id = @task_id;
@@ -0,0 +1,68 @@
+-- name: InsertOutboxEvent :exec
+INSERT INTO outbox (
+  event_type,
+  task_id,
+  payload
+)
+VALUES (
+  @event_type,
+  @task_id,
+  @payload
+);
+
+-- name: ClaimOutboxEvents :many
+UPDATE outbox SET
+  next_attempt_at = NOW() + @lease::interval
+WHERE id IN (
+  SELECT
+    pending.id
+  FROM
+    outbox pending
+  WHERE
+    pending.delivered_at IS NULL AND
+    pending.dead_at IS NULL AND
+    pending.next_attempt_at <= NOW() AND
+    NOT EXISTS (
+      SELECT
+        1
+      FROM
+        outbox older
+      WHERE
+        older.task_id = pending.task_id AND
+        older.delivered_at IS NULL AND
+        older.dead_at IS NULL AND
+        older.created_at < pending.created_at
+    )
+  ORDER BY
+    pending.created_at
+  LIMIT @max_events
+  FOR UPDATE SKIP LOCKED
+)
+RETURNING
+  id,
+  event_type,
+  payload,
+  attempts,
+  created_at;
+
+-- name: MarkOutboxEventDelivered :exec
+UPDATE outbox SET
+  delivered_at = NOW()
+WHERE id = @id;
+
+-- name: MarkOutboxEventFailed :exec
+UPDATE outbox SET
+  attempts        = attempts + 1,
+  next_attempt_at = NOW() + @retry_in::interval
+WHERE id = @id;
+
+-- name: MarkOutboxEventDead :exec
+UPDATE outbox SET
+  attempts = attempts + 1,
+  dead_at  = NOW()
+WHERE id = @id;
+
+-- name: DeleteDeliveredOutboxEvents :execrows
+DELETE FROM outbox
+WHERE
+  delivered_at < NOW() - @retention::interval;
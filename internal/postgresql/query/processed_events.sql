@@ -0,0 +1,40 @@
+-- name: SelectEventProcessed :one
+SELECT
+  EXISTS (
+    SELECT 1 FROM processed_events e
+    WHERE e.consumer = @consumer AND e.event_id = @event_id
+  ) OR EXISTS (
+    SELECT 1 FROM processed_task_versions v
+    WHERE v.consumer = @consumer AND v.task_id = @task_id AND v.version >= @version
+  ) AS processed;
+
+-- name: InsertProcessedEvent :exec
+INSERT INTO processed_events (
+  consumer,
+  event_id
+)
+VALUES (
+  @consumer,
+  @event_id
+)
+ON CONFLICT (consumer, event_id) DO NOTHING;
+
+-- name: UpsertProcessedTaskVersion :exec
+INSERT INTO processed_task_versions (
+  consumer,
+  task_id,
+  version
+)
+VALUES (
+  @consumer,
+  @task_id,
+  @version
+)
+ON CONFLICT (consumer, task_id) DO UPDATE SET
+  version = GREATEST(processed_task_versions.version, EXCLUDED.version);
+
+-- name: DeleteExpiredProcessedEvents :exec
+DELETE FROM processed_events
+WHERE
+  consumer = @consumer AND
+  processed_at < NOW() - @retention::interval;
@@ -0,0 +1,69 @@
+-- name: SearchTasks :many
+SELECT
+  id,
+  description,
+  priority,
+  start_date,
+  due_date,
+  done,
+  version,
+  CASE
+    WHEN sqlc.narg('description')::text IS NULL THEN ''
+    ELSE ts_headline('english', description, websearch_to_tsquery('english', sqlc.narg('description')::text), @headline_options::text)
+  END::text AS highlight
+FROM
+  tasks
+WHERE
+  parent_id IS NULL AND
+  (sqlc.narg('description')::text IS NULL OR search @@ websearch_to_tsquery('english', sqlc.narg('description')::text)) AND
+  (sqlc.narg('priority')::priority IS NULL OR priority = sqlc.narg('priority')::priority) AND
+  (sqlc.narg('done')::boolean IS NULL OR done = sqlc.narg('done')::boolean) AND
+  (sqlc.narg('start_from')::timestamp IS NULL OR start_date >= sqlc.narg('start_from')::timestamp) AND
+  (sqlc.narg('start_to')::timestamp IS NULL OR start_date < sqlc.narg('start_to')::timestamp) AND
+  (sqlc.narg('due_from')::timestamp IS NULL OR due_date >= sqlc.narg('due_from')::timestamp) AND
+  (sqlc.narg('due_to')::timestamp IS NULL OR due_date < sqlc.narg('due_to')::timestamp)
+ORDER BY
+  CASE WHEN @sort::text = 'dueDate' THEN due_date END ASC NULLS LAST,
+  CASE WHEN @sort::text = 'priority' THEN priority END DESC,
+  CASE
+    WHEN sqlc.narg('description')::text IS NULL THEN 0
+    ELSE ts_rank(search, websearch_to_tsquery('english', sqlc.narg('description')::text))
+  END DESC,
+  id
+OFFSET @skip_tasks
+LIMIT @max_tasks;
+
+-- name: SearchTasksFacets :many
+SELECT
+  priority,
+  done,
+  COUNT(*) AS total
+FROM
+  tasks
+WHERE
+  parent_id IS NULL AND
+  (sqlc.narg('description')::text IS NULL OR search @@ websearch_to_tsquery('english', sqlc.narg('description')::text)) AND
+  (sqlc.narg('priority')::priority IS NULL OR priority = sqlc.narg('priority')::priority) AND
+  (sqlc.narg('done')::boolean IS NULL OR done = sqlc.narg('done')::boolean) AND
+  (sqlc.narg('start_from')::timestamp IS NULL OR start_date >= sqlc.narg('start_from')::timestamp) AND
+  (sqlc.narg('start_to')::timestamp IS NULL OR start_date < sqlc.narg('start_to')::timestamp) AND
+  (sqlc.narg('due_from')::timestamp IS NULL OR due_date >= sqlc.narg('due_from')::timestamp) AND
+  (sqlc.narg('due_to')::timestamp IS NULL OR due_date < sqlc.narg('due_to')::timestamp)
+GROUP BY
+  priority,
+  done;
+
+-- name: SuggestTasks :many
+SELECT
+  description
+FROM
+  tasks
+WHERE
+  parent_id IS NULL AND
+  search @@ to_tsquery('english', @query::text)
+GROUP BY
+  description
+ORDER BY
+  MAX(ts_rank(search, to_tsquery('english', @query::text))) DESC,
+  description
+LIMIT @max_tasks;
@@ -5,41 +5,136 @@ SELECT
   priority,
   start_date,
   due_date,
-  done
+  done,
+  version
 FROM
   tasks
 WHERE
+  parent_id IS NULL AND
   id = @id
 LIMIT 1;
 
+-- name: LockTask :one
+SELECT
+  id
+FROM
+  tasks
+WHERE
+  parent_id IS NULL AND
+  id = @id
+FOR UPDATE;
+
+-- name: SelectSubTasks :many
+WITH RECURSIVE sub_tasks AS (
+  SELECT
+    id,
+    parent_id,
+    position,
+    description,
+    priority,
+    start_date,
+    due_date,
+    done,
+    version
+  FROM
+    tasks
+  WHERE
+    tasks.parent_id = @parent_id::uuid
+  UNION ALL
+  SELECT
+    t.id,
+    t.parent_id,
+    t.position,
+    t.description,
+    t.priority,
+    t.start_date,
+    t.due_date,
+    t.done,
+    t.version
+  FROM
+    tasks t
+  INNER JOIN sub_tasks s ON t.parent_id = s.id
+)
+SELECT
+  id,
+  parent_id,
+  description,
+  priority,
+  start_date,
+  due_date,
+  done,
+  version
+FROM
+  sub_tasks
+ORDER BY
+  position;
+
 -- name: InsertTask :one
 INSERT INTO tasks (
+  parent_id,
+  position,
   description,
   priority,
   start_date,
   due_date
 )
 VALUES (
+  @parent_id,
+  @position,
   @description,
   @priority,
   @start_date,
   @due_date
 )
-RETURNING id;
+RETURNING id, version;
 
 -- name: UpdateTask :one
 UPDATE tasks SET
-  description = @description,
-  priority    = @priority,
-  start_date  = @start_date,
-  due_date    = @due_date,
-  done        = @done
-WHERE id = @id
+  description = COALESCE(sqlc.narg('description'), description),
+  priority    = COALESCE(sqlc.narg('priority'), priority),
+  start_date  = CASE WHEN @set_start_date::boolean THEN sqlc.narg('start_date') ELSE start_date END,
+  due_date    = CASE WHEN @set_due_date::boolean THEN sqlc.narg('due_date') ELSE due_date END,
+  done        = COALESCE(sqlc.narg('done'), done),
+  version     = version + 1
+WHERE parent_id IS NULL AND id = @id AND (sqlc.narg('version')::bigint IS NULL OR version = sqlc.narg('version'))
 RETURNING id AS res;
 
 -- name: DeleteTask :one
 DELETE FROM
   tasks
 WHERE
-  id = @id
-RETURNING id AS res;
+  parent_id IS NULL AND
+  id = @id AND (sqlc.narg('version')::bigint IS NULL OR version = sqlc.narg('version'))
+RETURNING version;
+
+-- name: DeleteSubTasks :exec
+DELETE FROM
+  tasks
+WHERE
+  parent_id = @parent_id;
+
+-- name: SelectTasksAfter :many
+SELECT
+  id,
+  description,
+  priority,
+  start_date,
+  due_date,
+  done,
+  version
+FROM
+  tasks
+WHERE
+  parent_id IS NULL AND
+  id > @after
+ORDER BY
+  id
+LIMIT @max_tasks;
+
+-- name: CountTasks :one
+SELECT
+  COUNT(*)
+FROM
+  tasks
+WHERE
+  parent_id IS NULL;
@@ -0,0 +1,214 @@
+package postgresql
+
+import (
+	"context"
+	"encoding/base64"
+	"html"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/MarioCarrion/todo-api-microservice-example/internal"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/postgresql/db"
+)
+
+const (
+	// highlightStart, highlightStop and highlightDelimiter are used by ts_headline for marking the matching words and
+	// separating the fragments, they are replaced after HTML escaping the description.
+	highlightStart     = "\x02"
+	highlightStop      = "\x03"
+	highlightDelimiter = "\x1f"
+)
+
+//nolint:gochecknoglobals
+var headlineOptions = strings.Join([]string{
+	"StartSel=" + highlightStart,
+	"StopSel=" + highlightStop,
+	"FragmentDelimiter=" + highlightDelimiter,
+	"MaxFragments=3",
+	"MaxWords=25",
+	"MinWords=10",
+}, ", ")
+
+// Search returns the tasks, sub-tasks are not searched, matching all the values in the query using full-text search;
+// the results include the number of tasks found per priority and done state.
+//
+// The description is searched using websearch_to_tsquery, supporting quoted phrases, "or" and "-" for excluding
+// words. Cursors encode the offset of the next page, unlike Elasticsearch tasks created or deleted between pages could
+// cause duplicated or skipped results.
+func (t *Task) Search(ctx context.Context, args internal.SearchParams) (internal.SearchResults, error) {
+	if args.IsZero() {
+		return internal.SearchResults{}, nil
+	}
+
+	offset := args.From
+
+	if args.Cursor != "" {
+		var err error
+
+		if offset, err = decodeCursor(args.Cursor); err != nil {
+			return internal.SearchResults{}, err
+		}
+	}
+
+	priority := newNullPriority(args.Priority)
+
+	rows, err := t.q.SearchTasks(ctx, db.SearchTasksParams{
+		Description:     newText(args.Description),
+		HeadlineOptions: headlineOptions,
+		Priority:        priority,
+		Done:            newBool(args.IsDone),
+		StartFrom:       newBound(args.StartDate.From),
+		StartTo:         newBound(args.StartDate.To),
+		DueFrom:         newBound(args.DueDate.From),
+		DueTo:           newBound(args.DueDate.To),
+		Sort:            string(args.Sort),
+		SkipTasks:       int32(offset),    //nolint: gosec
+		MaxTasks:        int32(args.Size), //nolint: gosec
+	})
+	if err != nil {
+		return internal.SearchResults{}, internal.WrapErrorf(err, errorCode(err), "search tasks")
+	}
+
+	facets, err := t.q.SearchTasksFacets(ctx, db.SearchTasksFacetsParams{
+		Description: newText(args.Description),
+		Priority:    priority,
+		Done:        newBool(args.IsDone),
+		StartFrom:   newBound(args.StartDate.From),
+		StartTo:     newBound(args.StartDate.To),
+		DueFrom:     newBound(args.DueDate.From),
+		DueTo:       newBound(args.DueDate.To),
+	})
+	if err != nil {
+		return internal.SearchResults{}, internal.WrapErrorf(err, errorCode(err), "search tasks facets")
+	}
+
+	res := internal.SearchResults{
+		Tasks: make([]internal.Task, len(rows)),
+		Facets: internal.SearchFacets{
+			Priorities: make(map[internal.Priority]int64),
+		},
+	}
+
+	for i, row := range rows {
+		task, err := newTask(row.ID, row.Description, row.Priority, row.StartDate, row.DueDate, row.Done, row.Version)
+		if err != nil {
+			return internal.SearchResults{}, internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "newTask")
+		}
+
+		if res.Tasks[i], err = findTaskTree(ctx, t.q, row.ID, task); err != nil {
+			return internal.SearchResults{}, err
+		}
+
+		if highlights := newHighlights(row.Highlight); len(highlights) > 0 {
+			if res.Highlights == nil {
+				res.Highlights = make(map[string][]string)
+			}
+
+			res.Highlights[task.ID] = highlights
+		}
+	}
+
+	for _, row := range facets {
+		priority, err := convertPriority(row.Priority)
+		if err != nil {
+			return internal.SearchResults{}, internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "convert priority")
+		}
+
+		res.Total += row.Total
+		res.Facets.Priorities[priority] += row.Total
+
+		if row.Done {
+			res.Facets.Done += row.Total
+		} else {
+			res.Facets.NotDone += row.Total
+		}
+	}
+
+	if size := int64(len(rows)); size > 0 && size == args.Size {
+		res.Cursor = base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(offset+size, 10)))
+	}
+
+	return res, nil
+}
+
+// Suggest returns the descriptions of the tasks including words starting with each one of the words in the prefix,
+// sorted by relevance. Words are stemmed, and stop words ignored, the same way the description is.
+func (t *Task) Suggest(ctx context.Context, args internal.SuggestParams) ([]string, error) {
+	words := strings.FieldsFunc(args.Prefix, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	if len(words) == 0 {
+		return []string{}, nil
+	}
+
+	for i, word := range words {
+		words[i] = word + ":*"
+	}
+
+	res, err := t.q.SuggestTasks(ctx, db.SuggestTasksParams{
+		Query:    strings.Join(words, " & "),
+		MaxTasks: int32(args.Size), //nolint: gosec
+	})
+	if err != nil {
+		return nil, internal.WrapErrorf(err, errorCode(err), "suggest tasks")
+	}
+
+	return res, nil
+}
+
+// decodeCursor returns the offset encoded in the cursor.
+func decodeCursor(cursor string) (int64, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "invalid cursor")
+	}
+
+	res, err := strconv.ParseInt(string(raw), 10, 64)
+	if err != nil {
+		return 0, internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "invalid cursor")
+	}
+
+	if res < 0 {
+		return 0, internal.NewErrorf(internal.ErrorCodeInvalidArgument, "invalid cursor")
+	}
+
+	return res, nil
+}
+
+// newHighlights returns the fragments including matching words, the text is HTML escaped and the matching words are
+// wrapped in <em> tags, like Elasticsearch does.
+func newHighlights(headline string) []string {
+	if !strings.Contains(headline, highlightStart) {
+		return nil
+	}
+
+	fragments := strings.Split(headline, highlightDelimiter)
+	res := make([]string, 0, len(fragments))
+
+	replacer := strings.NewReplacer(highlightStart, "<em>", highlightStop, "</em>")
+
+	for _, fragment := range fragments {
+		if fragment = strings.TrimSpace(fragment); fragment != "" {
+			res = append(res, replacer.Replace(html.EscapeString(fragment)))
+		}
+	}
+
+	return res
+}
+
+// newBound returns the timestamp used for comparing the dates, unlike newTimestamp the value is not truncated.
+func newBound(t *time.Time) pgtype.Timestamp {
+	if t == nil {
+		return pgtype.Timestamp{}
+	}
+
+	return pgtype.Timestamp{
+		Time:  *t,
+		Valid: true,
+	}
+}
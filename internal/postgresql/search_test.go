@@ -0,0 +1,141 @@
+package postgresql_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/MarioCarrion/todo-api-microservice-example/internal"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/postgresql"
+)
+
+func TestTask_Search(t *testing.T) {
+	t.Parallel()
+
+	store := postgresql.NewTask(newDB(t))
+
+	due := time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)
+
+	var created []internal.Task
+
+	for _, params := range []internal.CreateParams{
+		{Description: "Buy groceries at the market", Priority: new(internal.PriorityHigh), Dates: &internal.Dates{Due: &due}},
+		{Description: "Buy a birthday present", Priority: new(internal.PriorityLow)},
+		{Description: "Call the plumber", Priority: new(internal.PriorityHigh)},
+		{
+			Description: "Plan the trip",
+			Priority:    new(internal.PriorityMedium),
+			SubTasks:    []internal.CreateParams{{Description: "Buy the tickets", Priority: new(internal.PriorityNone)}},
+		},
+	} {
+		task, err := store.Create(t.Context(), params)
+		if err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+
+		created = append(created, task)
+	}
+
+	t.Run("Search: OK", func(t *testing.T) {
+		t.Parallel()
+
+		res, err := store.Search(t.Context(), internal.SearchParams{
+			Description: new("buying"),
+			Sort:        internal.SearchSortPriority,
+			Size:        10,
+		})
+		if err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+
+		expected := internal.SearchResults{
+			Tasks: []internal.Task{created[0], created[1]},
+			Total: 2,
+			Facets: internal.SearchFacets{
+				Priorities: map[internal.Priority]int64{internal.PriorityHigh: 1, internal.PriorityLow: 1},
+				NotDone:    2,
+			},
+			Highlights: map[string][]string{
+				created[0].ID: {"<em>Buy</em> groceries at the market"},
+				created[1].ID: {"<em>Buy</em> a birthday present"},
+			},
+		}
+
+		if !cmp.Equal(expected, res) {
+			t.Fatalf("expected result does not match: %s", cmp.Diff(expected, res))
+		}
+	})
+
+	t.Run("Search: OK with filters", func(t *testing.T) {
+		t.Parallel()
+
+		res, err := store.Search(t.Context(), internal.SearchParams{
+			Priority: new(internal.PriorityHigh),
+			IsDone:   new(false),
+			DueDate:  internal.TimeRange{To: new(due.Add(time.Hour))},
+			Size:     10,
+		})
+		if err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+
+		if res.Total != 1 || len(res.Tasks) != 1 || res.Tasks[0].ID != created[0].ID {
+			t.Fatalf("expected only %s, got %v", created[0].ID, res.Tasks)
+		}
+	})
+
+	t.Run("Search: OK with cursor", func(t *testing.T) {
+		t.Parallel()
+
+		params := internal.SearchParams{
+			Description: new("buy or plumber"),
+			Size:        2,
+		}
+
+		first, err := store.Search(t.Context(), params)
+		if err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+
+		if len(first.Tasks) != 2 || first.Cursor == "" {
+			t.Fatalf("expected a full page and a cursor, got %d tasks", len(first.Tasks))
+		}
+
+		params.Cursor = first.Cursor
+
+		next, err := store.Search(t.Context(), params)
+		if err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+
+		if len(next.Tasks) != 1 || next.Cursor != "" || next.Total != 3 {
+			t.Fatalf("expected the last task and no cursor, got %d tasks and %q", len(next.Tasks), next.Cursor)
+		}
+	})
+
+	t.Run("Search: ERR invalid cursor", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := store.Search(t.Context(), internal.SearchParams{Description: new("buy"), Cursor: "invalid", Size: 2})
+
+		var ierr *internal.Error
+		if !errors.As(err, &ierr) || ierr.Code() != internal.ErrorCodeInvalidArgument {
+			t.Fatalf("expected %T error, got %T : %v", ierr, err, err)
+		}
+	})
+
+	t.Run("Suggest: OK", func(t *testing.T) {
+		t.Parallel()
+
+		res, err := store.Suggest(t.Context(), internal.SuggestParams{Prefix: "groc mark", Size: 5})
+		if err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+
+		if expected := []string{created[0].Description}; !cmp.Equal(expected, res) {
+			t.Fatalf("expected result does not match: %s", cmp.Diff(expected, res))
+		}
+	})
+}
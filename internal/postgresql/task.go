@@ -13,72 +13,78 @@ import (
 	"github.com/MarioCarrion/todo-api-microservice-example/internal/postgresql/db"
 )
 
+// DBTX represents the database connection used by the repository, it supports starting transactions.
+type DBTX interface {
+	db.DBTX
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
 // Task represents the repository used for interacting with Task records.
 type Task struct {
-	q *db.Queries
+	db DBTX
+	q  *db.Queries
 }
 
 // NewTask instantiates the Task repository.
-func NewTask(d db.DBTX) *Task {
+func NewTask(d DBTX) *Task {
 	return &Task{
-		q: db.New(d),
+		db: d,
+		q:  db.New(d),
 	}
 }
 
-// Create inserts a new task record.
+// Create inserts a new task record, including its sub-tasks and categories, and its Created event.
 func (t *Task) Create(ctx context.Context, params internal.CreateParams) (internal.Task, error) {
-	var (
-		start pgtype.Timestamp
-		due   pgtype.Timestamp
-		dates *internal.Dates
-	)
+	var task internal.Task
 
-	if params.Dates != nil {
-		start = newTimestamp(params.Dates.Start)
-		due = newTimestamp(params.Dates.Due)
+	if err := transaction(ctx, t.db, func(q *db.Queries) error {
+		var err error
 
-		dates = &internal.Dates{
-			Start: params.Dates.Start,
-			Due:   params.Dates.Due,
+		task, err = createTask(ctx, q, uuid.NullUUID{}, 0, params)
+		if err != nil {
+			return err
 		}
-	}
 
-	// We are intentionally NOT SUPPORTING `SubTasks` and `Categories` JUST YET.
-	newID, err := t.q.InsertTask(ctx, db.InsertTaskParams{
-		Description: params.Description,
-		Priority:    newPriority(params.Priority),
-		StartDate:   start,
-		DueDate:     due,
-	})
-	if err != nil {
-		return internal.Task{}, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "insert task")
+		return insertEvent(ctx, q, internal.Event{Type: internal.EventTypeTaskCreated, Task: task})
+	}); err != nil {
+		return internal.Task{}, err
 	}
 
-	return internal.Task{
-		ID:          newID.String(),
-		Description: params.Description,
-		Priority:    params.Priority,
-		Dates:       dates,
-	}, nil
+	return task, nil
 }
 
-// Delete deletes the existing record matching the id.
-func (t *Task) Delete(ctx context.Context, id string) error {
+// Delete deletes the existing record matching the id and inserts its Deleted event, it returns the version of the
+// deleted record.
+func (t *Task) Delete(ctx context.Context, id string, params internal.DeleteParams) (int64, error) {
 	val, err := uuid.Parse(id)
 	if err != nil {
-		return internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "invalid uuid")
+		return 0, internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "invalid uuid")
 	}
 
-	_, err = t.q.DeleteTask(ctx, val)
-	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return internal.WrapErrorf(err, internal.ErrorCodeNotFound, "task not found")
+	var version int64
+
+	if err := transaction(ctx, t.db, func(q *db.Queries) error {
+		version, err = q.DeleteTask(ctx, db.DeleteTaskParams{
+			ID:      val,
+			Version: newInt8(params.Version),
+		})
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return notFoundOrPreconditionFailed(ctx, q, val, params.Version, err)
+			}
+
+			return internal.WrapErrorf(err, errorCode(err), "delete task")
 		}
 
-		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "delete task")
+		return insertEvent(ctx, q, internal.Event{
+			Type: internal.EventTypeTaskDeleted,
+			Task: internal.Task{ID: id, Version: version},
+		})
+	}); err != nil {
+		return 0, err
 	}
 
-	return nil
+	return version, nil
 }
 
 // Find returns the requested task by searching its id.
@@ -88,43 +94,59 @@ func (t *Task) Find(ctx context.Context, id string) (internal.Task, error) {
 		return internal.Task{}, internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "invalid uuid")
 	}
 
-	res, err := t.q.SelectTask(ctx, val)
-	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return internal.Task{}, internal.WrapErrorf(err, internal.ErrorCodeNotFound, "task not found")
-		}
+	return findTask(ctx, t.q, val)
+}
+
+// List returns up to limit tasks, including their sub-tasks and categories, sorted by ID; only the tasks with an ID
+// greater than after are returned, an empty value returns the first ones. Use the ID of the last task to request the
+// next ones.
+func (t *Task) List(ctx context.Context, after string, limit int32) ([]internal.Task, error) {
+	var val uuid.UUID
 
-		return internal.Task{}, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "select task")
+	if after != "" {
+		var err error
+
+		if val, err = uuid.Parse(after); err != nil {
+			return nil, internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "invalid uuid")
+		}
 	}
 
-	priority, err := convertPriority(res.Priority)
+	rows, err := t.q.SelectTasksAfter(ctx, db.SelectTasksAfterParams{
+		After:    val,
+		MaxTasks: limit,
+	})
 	if err != nil {
-		return internal.Task{}, internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "convert priority")
+		return nil, internal.WrapErrorf(err, errorCode(err), "select tasks after")
 	}
 
-	var dates *internal.Dates
-	if res.StartDate.Valid || res.DueDate.Valid {
-		dates = &internal.Dates{}
+	res := make([]internal.Task, len(rows))
 
-		if res.StartDate.Valid {
-			dates.Start = &res.StartDate.Time
+	for i, row := range rows {
+		task, err := newTask(row.ID, row.Description, row.Priority, row.StartDate, row.DueDate, row.Done, row.Version)
+		if err != nil {
+			return nil, internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "newTask")
 		}
 
-		if res.DueDate.Valid {
-			dates.Due = &res.DueDate.Time
+		if res[i], err = findTaskTree(ctx, t.q, row.ID, task); err != nil {
+			return nil, err
 		}
 	}
 
-	return internal.Task{
-		ID:          res.ID.String(),
-		Description: res.Description,
-		Priority:    &priority,
-		Dates:       dates,
-		IsDone:      res.Done,
-	}, nil
+	return res, nil
 }
 
-// Update updates the existing record with new values.
+// Count returns the number of tasks, sub-tasks are not included.
+func (t *Task) Count(ctx context.Context) (int64, error) {
+	res, err := t.q.CountTasks(ctx)
+	if err != nil {
+		return 0, internal.WrapErrorf(err, errorCode(err), "count tasks")
+	}
+
+	return res, nil
+}
+
+// Update updates the existing record, only the values set in params are changed, and inserts its Updated event
+// including the previous values and the fields that changed.
 func (t *Task) Update(ctx context.Context, id string, params internal.UpdateParams) error {
 	// XXX: We will revisit the number of received arguments in future episodes.
 	val, err := uuid.Parse(id)
@@ -132,35 +154,224 @@ func (t *Task) Update(ctx context.Context, id string, params internal.UpdatePara
 		return internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "invalid uuid")
 	}
 
+	if err := transaction(ctx, t.db, func(q *db.Queries) error {
+		// The row is locked to make sure the previous values are the ones the update is applied to.
+		if _, err := q.LockTask(ctx, val); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return internal.WrapErrorf(err, internal.ErrorCodeNotFound, "task not found")
+			}
+
+			return internal.WrapErrorf(err, errorCode(err), "lock task")
+		}
+
+		previous, err := findTask(ctx, q, val)
+		if err != nil {
+			return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "findTask")
+		}
+
+		if _, err := q.UpdateTask(ctx, db.UpdateTaskParams{
+			ID:           val,
+			Description:  newText(params.Description),
+			Priority:     newNullPriority(params.Priority),
+			SetStartDate: params.Dates.Start.Set,
+			StartDate:    newTimestamp(params.Dates.Start.Value),
+			SetDueDate:   params.Dates.Due.Set,
+			DueDate:      newTimestamp(params.Dates.Due.Value),
+			Done:         newBool(params.IsDone),
+			Version:      newInt8(params.Version),
+		}); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return notFoundOrPreconditionFailed(ctx, q, val, params.Version, err)
+			}
+
+			return internal.WrapErrorf(err, errorCode(err), "update task")
+		}
+
+		if params.Categories != nil {
+			if err := q.DeleteTaskCategories(ctx, val); err != nil {
+				return internal.WrapErrorf(err, errorCode(err), "delete task categories")
+			}
+
+			if err := insertCategories(ctx, q, val, *params.Categories); err != nil {
+				return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "insertCategories")
+			}
+		}
+
+		if params.SubTasks != nil {
+			parentID := uuid.NullUUID{UUID: val, Valid: true}
+
+			if err := q.DeleteSubTasks(ctx, parentID); err != nil {
+				return internal.WrapErrorf(err, errorCode(err), "delete sub tasks")
+			}
+
+			for i, subTask := range *params.SubTasks {
+				if _, err := createTask(ctx, q, parentID, int32(i), subTask); err != nil { //nolint: gosec
+					return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "createTask")
+				}
+			}
+		}
+
+		task, err := findTask(ctx, q, val)
+		if err != nil {
+			return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "findTask")
+		}
+
+		changes := internal.NewTaskChanges(previous, task)
+
+		return insertEvent(ctx, q, internal.Event{
+			Type:    internal.EventTypeTaskUpdated,
+			Task:    task,
+			Changes: &changes,
+		})
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// findTask returns the task, including its sub-tasks and categories.
+func findTask(ctx context.Context, q *db.Queries, id uuid.UUID) (internal.Task, error) {
+	res, err := q.SelectTask(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return internal.Task{}, internal.WrapErrorf(err, internal.ErrorCodeNotFound, "task not found")
+		}
+
+		return internal.Task{}, internal.WrapErrorf(err, errorCode(err), "select task")
+	}
+
+	task, err := newTask(res.ID, res.Description, res.Priority, res.StartDate, res.DueDate, res.Done, res.Version)
+	if err != nil {
+		return internal.Task{}, internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "newTask")
+	}
+
+	return findTaskTree(ctx, q, res.ID, task)
+}
+
+// findTaskTree returns the task including its sub-tasks and categories.
+func findTaskTree(ctx context.Context, q *db.Queries, id uuid.UUID, task internal.Task) (internal.Task, error) {
+	subTasks, err := q.SelectSubTasks(ctx, id)
+	if err != nil {
+		return internal.Task{}, internal.WrapErrorf(err, errorCode(err), "select sub tasks")
+	}
+
+	ids := make([]uuid.UUID, 0, len(subTasks)+1)
+	ids = append(ids, id)
+
+	for _, subTask := range subTasks {
+		ids = append(ids, subTask.ID)
+	}
+
+	categories, err := q.SelectTasksCategories(ctx, ids)
+	if err != nil {
+		return internal.Task{}, internal.WrapErrorf(err, errorCode(err), "select tasks categories")
+	}
+
+	task, err = newTaskTree(task, subTasks, categories)
+	if err != nil {
+		return internal.Task{}, internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "newTaskTree")
+	}
+
+	return task, nil
+}
+
+// createTask inserts the task, its categories and recursively its sub-tasks.
+func createTask(ctx context.Context,
+	q *db.Queries,
+	parentID uuid.NullUUID,
+	position int32,
+	params internal.CreateParams,
+) (internal.Task, error) {
 	var (
 		start pgtype.Timestamp
 		due   pgtype.Timestamp
+		dates *internal.Dates
 	)
 
 	if params.Dates != nil {
-		if params.Dates.Start != nil {
-			start = newTimestamp(params.Dates.Start)
-		}
+		start = newTimestamp(params.Dates.Start)
+		due = newTimestamp(params.Dates.Due)
 
-		if params.Dates.Due != nil {
-			due = newTimestamp(params.Dates.Due)
+		dates = &internal.Dates{
+			Start: params.Dates.Start,
+			Due:   params.Dates.Due,
 		}
 	}
 
-	if _, err := t.q.UpdateTask(ctx, db.UpdateTaskParams{
-		ID:          val,
-		Description: internal.PointerToValue(params.Description),
+	row, err := q.InsertTask(ctx, db.InsertTaskParams{
+		ParentID:    parentID,
+		Position:    position,
+		Description: params.Description,
 		Priority:    newPriority(params.Priority),
 		StartDate:   start,
 		DueDate:     due,
-		Done:        internal.PointerToValue(params.IsDone),
-	}); err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return internal.WrapErrorf(err, internal.ErrorCodeNotFound, "task not found")
+	})
+	if err != nil {
+		return internal.Task{}, internal.WrapErrorf(err, errorCode(err), "insert task")
+	}
+
+	if err := insertCategories(ctx, q, row.ID, params.Categories); err != nil {
+		return internal.Task{}, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "insertCategories")
+	}
+
+	task := internal.Task{
+		ID:          row.ID.String(),
+		Description: params.Description,
+		Priority:    params.Priority,
+		Dates:       dates,
+		Categories:  params.Categories,
+		Version:     row.Version,
+	}
+
+	if len(params.SubTasks) > 0 {
+		task.SubTasks = make([]internal.Task, len(params.SubTasks))
+
+		subParentID := uuid.NullUUID{UUID: row.ID, Valid: true}
+
+		for i, subTask := range params.SubTasks {
+			task.SubTasks[i], err = createTask(ctx, q, subParentID, int32(i), subTask) //nolint: gosec
+			if err != nil {
+				return internal.Task{}, err
+			}
+		}
+	}
+
+	return task, nil
+}
+
+func insertCategories(ctx context.Context, q *db.Queries, taskID uuid.UUID, categories []internal.Category) error {
+	for _, category := range categories {
+		categoryID, err := q.InsertCategory(ctx, string(category))
+		if err != nil {
+			return internal.WrapErrorf(err, errorCode(err), "insert category")
 		}
 
-		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "update task")
+		if err := q.InsertTaskCategory(ctx, db.InsertTaskCategoryParams{
+			TaskID:     taskID,
+			CategoryID: categoryID,
+		}); err != nil {
+			return internal.WrapErrorf(err, errorCode(err), "insert task category")
+		}
 	}
 
 	return nil
 }
+
+// notFoundOrPreconditionFailed determines why a conditional statement did not affect any rows, it is either because
+// the record does not exist or because its version does not match the expected one.
+func notFoundOrPreconditionFailed(ctx context.Context, q *db.Queries, id uuid.UUID, version *int64, orig error) error {
+	if version == nil {
+		return internal.WrapErrorf(orig, internal.ErrorCodeNotFound, "task not found")
+	}
+
+	if _, err := q.SelectTask(ctx, id); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return internal.WrapErrorf(orig, internal.ErrorCodeNotFound, "task not found")
+		}
+
+		return internal.WrapErrorf(err, errorCode(err), "select task")
+	}
+
+	return internal.WrapErrorf(orig, internal.ErrorCodePreconditionFailed, "task version does not match")
+}
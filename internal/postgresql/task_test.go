@@ -5,6 +5,8 @@ import (
 	"errors"
 	"os"
 	"path"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
@@ -40,6 +42,48 @@ func TestTask_Create(t *testing.T) {
 		}
 	})
 
+	t.Run("Create: OK with sub-tasks and categories", func(t *testing.T) {
+		t.Parallel()
+
+		store := postgresql.NewTask(newDB(t))
+
+		createdTask, err := store.Create(t.Context(),
+			internal.CreateParams{
+				Description: "parent",
+				Priority:    new(internal.PriorityNone),
+				Categories:  []internal.Category{"home", "work"},
+				SubTasks: []internal.CreateParams{
+					{
+						Description: "first",
+						Priority:    new(internal.PriorityLow),
+						SubTasks: []internal.CreateParams{
+							{
+								Description: "nested",
+								Priority:    new(internal.PriorityHigh),
+								Categories:  []internal.Category{"work"},
+							},
+						},
+					},
+					{
+						Description: "second",
+						Priority:    new(internal.PriorityMedium),
+					},
+				},
+			})
+		if err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+
+		actualTask, err := store.Find(t.Context(), createdTask.ID)
+		if err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+
+		if !cmp.Equal(createdTask, actualTask) {
+			t.Fatalf("expected result does not match: %s", cmp.Diff(createdTask, actualTask))
+		}
+	})
+
 	t.Run("Create: ERR", func(t *testing.T) {
 		t.Parallel()
 
@@ -75,10 +119,15 @@ func TestTask_Delete(t *testing.T) {
 			t.Fatalf("expected no error, got %s", err)
 		}
 
-		if err := store.Delete(t.Context(), createdTask.ID); err != nil {
+		version, err := store.Delete(t.Context(), createdTask.ID, internal.DeleteParams{Version: &createdTask.Version})
+		if err != nil {
 			t.Fatalf("expected no error, got %s", err)
 		}
 
+		if version != createdTask.Version {
+			t.Fatalf("expected version %d, got %d", createdTask.Version, version)
+		}
+
 		if _, err = store.Find(t.Context(), createdTask.ID); !errors.Is(err, pgx.ErrNoRows) {
 			t.Fatalf("expected no error, got %s", err)
 		}
@@ -87,7 +136,7 @@ func TestTask_Delete(t *testing.T) {
 	t.Run("Update: ERR uuid", func(t *testing.T) {
 		t.Parallel()
 
-		err := postgresql.NewTask(newDB(t)).Delete(t.Context(), "x")
+		_, err := postgresql.NewTask(newDB(t)).Delete(t.Context(), "x", internal.DeleteParams{})
 		if err == nil {
 			t.Fatalf("expected error, got not value")
 		}
@@ -101,13 +150,34 @@ func TestTask_Delete(t *testing.T) {
 	t.Run("Delete: ERR not found", func(t *testing.T) {
 		t.Parallel()
 
-		err := postgresql.NewTask(newDB(t)).Delete(t.Context(), "44633fe3-b039-4fb3-a35f-a57fe3c906c7")
+		_, err := postgresql.NewTask(newDB(t)).Delete(t.Context(), "44633fe3-b039-4fb3-a35f-a57fe3c906c7", internal.DeleteParams{})
 
 		var ierr *internal.Error
 		if !errors.As(err, &ierr) || ierr.Code() != internal.ErrorCodeNotFound {
 			t.Fatalf("expected %T error, got %T : %v", ierr, err, err)
 		}
 	})
+
+	t.Run("Delete: ERR precondition failed", func(t *testing.T) {
+		t.Parallel()
+
+		store := postgresql.NewTask(newDB(t))
+
+		createdTask, err := store.Create(t.Context(), internal.CreateParams{
+			Description: "test",
+			Priority:    new(internal.PriorityNone),
+		})
+		if err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+
+		_, err = store.Delete(t.Context(), createdTask.ID, internal.DeleteParams{Version: new(createdTask.Version + 1)})
+
+		var ierr *internal.Error
+		if !errors.As(err, &ierr) || ierr.Code() != internal.ErrorCodePreconditionFailed {
+			t.Fatalf("expected %T error, got %T : %v", ierr, err, err)
+		}
+	})
 }
 
 func TestTask_Find(t *testing.T) {
@@ -169,6 +239,93 @@ func TestTask_Find(t *testing.T) {
 			t.Fatalf("expected %T error, got %T : %v", ierr, err, err)
 		}
 	})
+
+	t.Run("Find, Update, Delete: ERR sub-task not found", func(t *testing.T) {
+		t.Parallel()
+
+		store := postgresql.NewTask(newDB(t))
+
+		createdTask, err := store.Create(t.Context(),
+			internal.CreateParams{
+				Description: "parent",
+				Priority:    new(internal.PriorityNone),
+				SubTasks: []internal.CreateParams{
+					{
+						Description: "child",
+						Priority:    new(internal.PriorityLow),
+					},
+				},
+			})
+		if err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+
+		subTaskID := createdTask.SubTasks[0].ID
+
+		_, findErr := store.Find(t.Context(), subTaskID)
+		updateErr := store.Update(t.Context(), subTaskID, internal.UpdateParams{IsDone: new(true)})
+		_, deleteErr := store.Delete(t.Context(), subTaskID, internal.DeleteParams{})
+
+		for _, err := range []error{findErr, updateErr, deleteErr} {
+			var ierr *internal.Error
+			if !errors.As(err, &ierr) || ierr.Code() != internal.ErrorCodeNotFound {
+				t.Fatalf("expected %T error, got %T : %v", ierr, err, err)
+			}
+		}
+	})
+}
+
+func TestTask_List(t *testing.T) {
+	t.Parallel()
+
+	t.Run("List: OK", func(t *testing.T) {
+		t.Parallel()
+
+		store := postgresql.NewTask(newDB(t))
+
+		var expected []internal.Task
+
+		for _, description := range []string{"one", "two", "three"} {
+			task, err := store.Create(t.Context(), internal.CreateParams{
+				Description: description,
+				Priority:    new(internal.PriorityNone),
+				Categories:  []internal.Category{"work"},
+				SubTasks: []internal.CreateParams{
+					{Description: "sub-task " + description, Priority: new(internal.PriorityLow)},
+				},
+			})
+			if err != nil {
+				t.Fatalf("expected no error, got %s", err)
+			}
+
+			expected = append(expected, task)
+		}
+
+		slices.SortFunc(expected, func(a, b internal.Task) int { return strings.Compare(a.ID, b.ID) })
+
+		count, err := store.Count(t.Context())
+		if err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+
+		if count != 3 {
+			t.Fatalf("expected 3 tasks, got %d", count)
+		}
+
+		first, err := store.List(t.Context(), "", 2)
+		if err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+
+		next, err := store.List(t.Context(), first[len(first)-1].ID, 2)
+		if err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+
+		if actual := append(first, next...); !cmp.Equal(expected, actual) {
+			t.Fatalf("expected result does not match: %s", cmp.Diff(expected, actual))
+		}
+	})
 }
 
 func TestTask_Update(t *testing.T) {
@@ -199,8 +356,11 @@ func TestTask_Update(t *testing.T) {
 		params := internal.UpdateParams{
 			Description: &originalTask.Description,
 			Priority:    originalTask.Priority,
-			Dates:       originalTask.Dates,
-			IsDone:      &originalTask.IsDone,
+			Dates: internal.UpdateDates{
+				Start: internal.NewOptional(&now),
+				Due:   internal.NewOptional(&now),
+			},
+			IsDone: &originalTask.IsDone,
 		}
 
 		if err := store.Update(t.Context(), originalTask.ID, params); err != nil {
@@ -212,6 +372,8 @@ func TestTask_Update(t *testing.T) {
 			t.Fatalf("expected no error, got %s", err)
 		}
 
+		originalTask.Version++
+
 		opts := cmp.Comparer(func(x, y time.Time) bool {
 			return x.Unix() == y.Unix()
 		})
@@ -221,13 +383,147 @@ func TestTask_Update(t *testing.T) {
 		}
 	})
 
+	t.Run("Update: OK partial", func(t *testing.T) {
+		t.Parallel()
+
+		store := postgresql.NewTask(newDB(t))
+
+		now := time.Now().UTC().Truncate(time.Minute)
+
+		originalTask, err := store.Create(t.Context(), internal.CreateParams{
+			Description: "test",
+			Priority:    new(internal.PriorityLow),
+			Dates: &internal.Dates{
+				Start: &now,
+				Due:   &now,
+			},
+		})
+		if err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+
+		params := internal.UpdateParams{
+			IsDone: new(true),
+			Dates: internal.UpdateDates{
+				Due: internal.NewOptional[time.Time](nil),
+			},
+		}
+
+		if err := store.Update(t.Context(), originalTask.ID, params); err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+
+		actualTask, err := store.Find(t.Context(), originalTask.ID)
+		if err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+
+		originalTask.IsDone = true
+		originalTask.Dates.Due = nil
+		originalTask.Version++
+
+		opts := cmp.Comparer(func(x, y time.Time) bool {
+			return x.Unix() == y.Unix()
+		})
+
+		if !cmp.Equal(originalTask, actualTask, opts) {
+			t.Fatalf("expected result does not match: %s", cmp.Diff(originalTask, actualTask))
+		}
+	})
+
+	t.Run("Update: ERR precondition failed", func(t *testing.T) {
+		t.Parallel()
+
+		store := postgresql.NewTask(newDB(t))
+
+		originalTask, err := store.Create(t.Context(), internal.CreateParams{
+			Description: "test",
+			Priority:    new(internal.PriorityNone),
+		})
+		if err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+
+		params := internal.UpdateParams{
+			Description: new("first"),
+			Version:     &originalTask.Version,
+		}
+
+		if err := store.Update(t.Context(), originalTask.ID, params); err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+
+		params.Description = new("second")
+
+		err = store.Update(t.Context(), originalTask.ID, params)
+
+		var ierr *internal.Error
+		if !errors.As(err, &ierr) || ierr.Code() != internal.ErrorCodePreconditionFailed {
+			t.Fatalf("expected %T error, got %T : %v", ierr, err, err)
+		}
+	})
+
+	t.Run("Update: OK replacing sub-tasks and categories", func(t *testing.T) {
+		t.Parallel()
+
+		store := postgresql.NewTask(newDB(t))
+
+		originalTask, err := store.Create(t.Context(), internal.CreateParams{
+			Description: "test",
+			Priority:    new(internal.PriorityNone),
+			Categories:  []internal.Category{"home"},
+			SubTasks: []internal.CreateParams{
+				{
+					Description: "old",
+					Priority:    new(internal.PriorityNone),
+				},
+			},
+		})
+		if err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+
+		params := internal.UpdateParams{
+			Description: &originalTask.Description,
+			Priority:    originalTask.Priority,
+			IsDone:      &originalTask.IsDone,
+			Categories:  &[]internal.Category{"work"},
+			SubTasks: &[]internal.CreateParams{
+				{
+					Description: "new",
+					Priority:    new(internal.PriorityLow),
+				},
+			},
+		}
+
+		if err := store.Update(t.Context(), originalTask.ID, params); err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+
+		actualTask, err := store.Find(t.Context(), originalTask.ID)
+		if err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+
+		if !cmp.Equal(actualTask.Categories, []internal.Category{"work"}) {
+			t.Fatalf("expected categories do not match: %v", actualTask.Categories)
+		}
+
+		if len(actualTask.SubTasks) != 1 || actualTask.SubTasks[0].Description != "new" {
+			t.Fatalf("expected sub-tasks do not match: %v", actualTask.SubTasks)
+		}
+
+		if _, err := store.Find(t.Context(), originalTask.SubTasks[0].ID); err == nil {
+			t.Fatalf("expected replaced sub-task to be deleted")
+		}
+	})
+
 	t.Run("Update: ERR uuid", func(t *testing.T) {
 		t.Parallel()
 
 		params := internal.UpdateParams{
 			Description: new("x"),
 			Priority:    new(internal.PriorityNone),
-			Dates:       &internal.Dates{},
 			IsDone:      new(bool),
 		}
 
@@ -259,7 +555,6 @@ func TestTask_Update(t *testing.T) {
 
 		params := internal.UpdateParams{
 			Priority: new(internal.Priority(-1)),
-			Dates:    &internal.Dates{},
 			IsDone:   new(bool),
 		}
 
@@ -282,7 +577,6 @@ func TestTask_Update(t *testing.T) {
 
 		params := internal.UpdateParams{
 			Priority: new(internal.PriorityNone),
-			Dates:    &internal.Dates{},
 			IsDone:   new(bool),
 		}
 
@@ -0,0 +1,333 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: todo/v1/events.proto
+
+package todov1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// TaskField identifies a Task attribute that changed.
+type TaskField int32
+
+const (
+	TaskField_TASK_FIELD_UNSPECIFIED TaskField = 0
+	TaskField_TASK_FIELD_DESCRIPTION TaskField = 1
+	TaskField_TASK_FIELD_PRIORITY    TaskField = 2
+	// TASK_FIELD_IS_DONE indicates the task was completed or reopened.
+	TaskField_TASK_FIELD_IS_DONE    TaskField = 3
+	TaskField_TASK_FIELD_START_DATE TaskField = 4
+	// TASK_FIELD_DUE_DATE indicates the due date changed, for example the task was rescheduled.
+	TaskField_TASK_FIELD_DUE_DATE   TaskField = 5
+	TaskField_TASK_FIELD_SUB_TASKS  TaskField = 6
+	TaskField_TASK_FIELD_CATEGORIES TaskField = 7
+)
+
+// Enum value maps for TaskField.
+var (
+	TaskField_name = map[int32]string{
+		0: "TASK_FIELD_UNSPECIFIED",
+		1: "TASK_FIELD_DESCRIPTION",
+		2: "TASK_FIELD_PRIORITY",
+		3: "TASK_FIELD_IS_DONE",
+		4: "TASK_FIELD_START_DATE",
+		5: "TASK_FIELD_DUE_DATE",
+		6: "TASK_FIELD_SUB_TASKS",
+		7: "TASK_FIELD_CATEGORIES",
+	}
+	TaskField_value = map[string]int32{
+		"TASK_FIELD_UNSPECIFIED": 0,
+		"TASK_FIELD_DESCRIPTION": 1,
+		"TASK_FIELD_PRIORITY":    2,
+		"TASK_FIELD_IS_DONE":     3,
+		"TASK_FIELD_START_DATE":  4,
+		"TASK_FIELD_DUE_DATE":    5,
+		"TASK_FIELD_SUB_TASKS":   6,
+		"TASK_FIELD_CATEGORIES":  7,
+	}
+)
+
+func (x TaskField) Enum() *TaskField {
+	p := new(TaskField)
+	*p = x
+	return p
+}
+
+func (x TaskField) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TaskField) Descriptor() protoreflect.EnumDescriptor {
+	return file_todo_v1_events_proto_enumTypes[0].Descriptor()
+}
+
+func (TaskField) Type() protoreflect.EnumType {
+	return &file_todo_v1_events_proto_enumTypes[0]
+}
+
+func (x TaskField) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TaskField.Descriptor instead.
+func (TaskField) EnumDescriptor() ([]byte, []int) {
+	return file_todo_v1_events_proto_rawDescGZIP(), []int{0}
+}
+
+// TaskCreated is the data of the "Task.Created" events.
+type TaskCreated struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Task          *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskCreated) Reset() {
+	*x = TaskCreated{}
+	mi := &file_todo_v1_events_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskCreated) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskCreated) ProtoMessage() {}
+
+func (x *TaskCreated) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_v1_events_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskCreated.ProtoReflect.Descriptor instead.
+func (*TaskCreated) Descriptor() ([]byte, []int) {
+	return file_todo_v1_events_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *TaskCreated) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+// TaskUpdated is the data of the "Task.Updated" events.
+type TaskUpdated struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Task  *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	// previous is the task before the change.
+	Previous *Task `protobuf:"bytes,2,opt,name=previous,proto3" json:"previous,omitempty"`
+	// changed_fields lists the attributes that changed.
+	ChangedFields []TaskField `protobuf:"varint,3,rep,packed,name=changed_fields,json=changedFields,proto3,enum=todo.v1.TaskField" json:"changed_fields,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskUpdated) Reset() {
+	*x = TaskUpdated{}
+	mi := &file_todo_v1_events_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskUpdated) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskUpdated) ProtoMessage() {}
+
+func (x *TaskUpdated) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_v1_events_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskUpdated.ProtoReflect.Descriptor instead.
+func (*TaskUpdated) Descriptor() ([]byte, []int) {
+	return file_todo_v1_events_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *TaskUpdated) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+func (x *TaskUpdated) GetPrevious() *Task {
+	if x != nil {
+		return x.Previous
+	}
+	return nil
+}
+
+func (x *TaskUpdated) GetChangedFields() []TaskField {
+	if x != nil {
+		return x.ChangedFields
+	}
+	return nil
+}
+
+// TaskDeleted is the data of the "Task.Deleted" events.
+type TaskDeleted struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Version       int64                  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskDeleted) Reset() {
+	*x = TaskDeleted{}
+	mi := &file_todo_v1_events_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskDeleted) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskDeleted) ProtoMessage() {}
+
+func (x *TaskDeleted) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_v1_events_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskDeleted.ProtoReflect.Descriptor instead.
+func (*TaskDeleted) Descriptor() ([]byte, []int) {
+	return file_todo_v1_events_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *TaskDeleted) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *TaskDeleted) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+var File_todo_v1_events_proto protoreflect.FileDescriptor
+
+const file_todo_v1_events_proto_rawDesc = "" +
+	"\n" +
+	"\x14todo/v1/events.proto\x12\atodo.v1\x1a\x12todo/v1/task.proto\"0\n" +
+	"\vTaskCreated\x12!\n" +
+	"\x04task\x18\x01 \x01(\v2\r.todo.v1.TaskR\x04task\"\x96\x01\n" +
+	"\vTaskUpdated\x12!\n" +
+	"\x04task\x18\x01 \x01(\v2\r.todo.v1.TaskR\x04task\x12)\n" +
+	"\bprevious\x18\x02 \x01(\v2\r.todo.v1.TaskR\bprevious\x129\n" +
+	"\x0echanged_fields\x18\x03 \x03(\x0e2\x12.todo.v1.TaskFieldR\rchangedFields\"7\n" +
+	"\vTaskDeleted\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x03R\aversion*\xdd\x01\n" +
+	"\tTaskField\x12\x1a\n" +
+	"\x16TASK_FIELD_UNSPECIFIED\x10\x00\x12\x1a\n" +
+	"\x16TASK_FIELD_DESCRIPTION\x10\x01\x12\x17\n" +
+	"\x13TASK_FIELD_PRIORITY\x10\x02\x12\x16\n" +
+	"\x12TASK_FIELD_IS_DONE\x10\x03\x12\x19\n" +
+	"\x15TASK_FIELD_START_DATE\x10\x04\x12\x17\n" +
+	"\x13TASK_FIELD_DUE_DATE\x10\x05\x12\x18\n" +
+	"\x14TASK_FIELD_SUB_TASKS\x10\x06\x12\x19\n" +
+	"\x15TASK_FIELD_CATEGORIES\x10\aBPZNgithub.com/MarioCarrion/todo-api-microservice-example/internal/protobuf/todov1b\x06proto3"
+
+var (
+	file_todo_v1_events_proto_rawDescOnce sync.Once
+	file_todo_v1_events_proto_rawDescData []byte
+)
+
+func file_todo_v1_events_proto_rawDescGZIP() []byte {
+	file_todo_v1_events_proto_rawDescOnce.Do(func() {
+		file_todo_v1_events_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_todo_v1_events_proto_rawDesc), len(file_todo_v1_events_proto_rawDesc)))
+	})
+	return file_todo_v1_events_proto_rawDescData
+}
+
+var file_todo_v1_events_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_todo_v1_events_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_todo_v1_events_proto_goTypes = []any{
+	(TaskField)(0),      // 0: todo.v1.TaskField
+	(*TaskCreated)(nil), // 1: todo.v1.TaskCreated
+	(*TaskUpdated)(nil), // 2: todo.v1.TaskUpdated
+	(*TaskDeleted)(nil), // 3: todo.v1.TaskDeleted
+	(*Task)(nil),        // 4: todo.v1.Task
+}
+var file_todo_v1_events_proto_depIdxs = []int32{
+	4, // 0: todo.v1.TaskCreated.task:type_name -> todo.v1.Task
+	4, // 1: todo.v1.TaskUpdated.task:type_name -> todo.v1.Task
+	4, // 2: todo.v1.TaskUpdated.previous:type_name -> todo.v1.Task
+	0, // 3: todo.v1.TaskUpdated.changed_fields:type_name -> todo.v1.TaskField
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_todo_v1_events_proto_init() }
+func file_todo_v1_events_proto_init() {
+	if File_todo_v1_events_proto != nil {
+		return
+	}
+	file_todo_v1_task_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_todo_v1_events_proto_rawDesc), len(file_todo_v1_events_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_todo_v1_events_proto_goTypes,
+		DependencyIndexes: file_todo_v1_events_proto_depIdxs,
+		EnumInfos:         file_todo_v1_events_proto_enumTypes,
+		MessageInfos:      file_todo_v1_events_proto_msgTypes,
+	}.Build()
+	File_todo_v1_events_proto = out.File
+	file_todo_v1_events_proto_goTypes = nil
+	file_todo_v1_events_proto_depIdxs = nil
+}
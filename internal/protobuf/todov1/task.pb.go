@@ -0,0 +1,323 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: todo/v1/task.proto
+
+package todov1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Priority indicates how important a Task is.
+type Priority int32
+
+const (
+	// PRIORITY_UNSPECIFIED indicates the priority is not set.
+	Priority_PRIORITY_UNSPECIFIED Priority = 0
+	// PRIORITY_NONE indicates the task needs to be prioritized.
+	Priority_PRIORITY_NONE Priority = 1
+	// PRIORITY_LOW indicates a non urgent task.
+	Priority_PRIORITY_LOW Priority = 2
+	// PRIORITY_MEDIUM indicates a task that should be completed soon.
+	Priority_PRIORITY_MEDIUM Priority = 3
+	// PRIORITY_HIGH indicates an urgent task that must be completed as soon as possible.
+	Priority_PRIORITY_HIGH Priority = 4
+)
+
+// Enum value maps for Priority.
+var (
+	Priority_name = map[int32]string{
+		0: "PRIORITY_UNSPECIFIED",
+		1: "PRIORITY_NONE",
+		2: "PRIORITY_LOW",
+		3: "PRIORITY_MEDIUM",
+		4: "PRIORITY_HIGH",
+	}
+	Priority_value = map[string]int32{
+		"PRIORITY_UNSPECIFIED": 0,
+		"PRIORITY_NONE":        1,
+		"PRIORITY_LOW":         2,
+		"PRIORITY_MEDIUM":      3,
+		"PRIORITY_HIGH":        4,
+	}
+)
+
+func (x Priority) Enum() *Priority {
+	p := new(Priority)
+	*p = x
+	return p
+}
+
+func (x Priority) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Priority) Descriptor() protoreflect.EnumDescriptor {
+	return file_todo_v1_task_proto_enumTypes[0].Descriptor()
+}
+
+func (Priority) Type() protoreflect.EnumType {
+	return &file_todo_v1_task_proto_enumTypes[0]
+}
+
+func (x Priority) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Priority.Descriptor instead.
+func (Priority) EnumDescriptor() ([]byte, []int) {
+	return file_todo_v1_task_proto_rawDescGZIP(), []int{0}
+}
+
+// Dates indicates a point in time where a task starts or completes.
+type Dates struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Start         *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	Due           *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=due,proto3" json:"due,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Dates) Reset() {
+	*x = Dates{}
+	mi := &file_todo_v1_task_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Dates) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Dates) ProtoMessage() {}
+
+func (x *Dates) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_v1_task_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Dates.ProtoReflect.Descriptor instead.
+func (*Dates) Descriptor() ([]byte, []int) {
+	return file_todo_v1_task_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Dates) GetStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *Dates) GetDue() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Due
+	}
+	return nil
+}
+
+// Task is an activity that needs to be completed within a period of time.
+type Task struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	IsDone      bool                   `protobuf:"varint,2,opt,name=is_done,json=isDone,proto3" json:"is_done,omitempty"`
+	Priority    Priority               `protobuf:"varint,3,opt,name=priority,proto3,enum=todo.v1.Priority" json:"priority,omitempty"`
+	Description string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Dates       *Dates                 `protobuf:"bytes,5,opt,name=dates,proto3" json:"dates,omitempty"`
+	SubTasks    []*Task                `protobuf:"bytes,6,rep,name=sub_tasks,json=subTasks,proto3" json:"sub_tasks,omitempty"`
+	Categories  []string               `protobuf:"bytes,7,rep,name=categories,proto3" json:"categories,omitempty"`
+	// version is increased every time the task changes.
+	Version       int64 `protobuf:"varint,8,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Task) Reset() {
+	*x = Task{}
+	mi := &file_todo_v1_task_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Task) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Task) ProtoMessage() {}
+
+func (x *Task) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_v1_task_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Task.ProtoReflect.Descriptor instead.
+func (*Task) Descriptor() ([]byte, []int) {
+	return file_todo_v1_task_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Task) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Task) GetIsDone() bool {
+	if x != nil {
+		return x.IsDone
+	}
+	return false
+}
+
+func (x *Task) GetPriority() Priority {
+	if x != nil {
+		return x.Priority
+	}
+	return Priority_PRIORITY_UNSPECIFIED
+}
+
+func (x *Task) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Task) GetDates() *Dates {
+	if x != nil {
+		return x.Dates
+	}
+	return nil
+}
+
+func (x *Task) GetSubTasks() []*Task {
+	if x != nil {
+		return x.SubTasks
+	}
+	return nil
+}
+
+func (x *Task) GetCategories() []string {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+func (x *Task) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+var File_todo_v1_task_proto protoreflect.FileDescriptor
+
+const file_todo_v1_task_proto_rawDesc = "" +
+	"\n" +
+	"\x12todo/v1/task.proto\x12\atodo.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"g\n" +
+	"\x05Dates\x120\n" +
+	"\x05start\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x05start\x12,\n" +
+	"\x03due\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x03due\"\x8c\x02\n" +
+	"\x04Task\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\ais_done\x18\x02 \x01(\bR\x06isDone\x12-\n" +
+	"\bpriority\x18\x03 \x01(\x0e2\x11.todo.v1.PriorityR\bpriority\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12$\n" +
+	"\x05dates\x18\x05 \x01(\v2\x0e.todo.v1.DatesR\x05dates\x12*\n" +
+	"\tsub_tasks\x18\x06 \x03(\v2\r.todo.v1.TaskR\bsubTasks\x12\x1e\n" +
+	"\n" +
+	"categories\x18\a \x03(\tR\n" +
+	"categories\x12\x18\n" +
+	"\aversion\x18\b \x01(\x03R\aversion*q\n" +
+	"\bPriority\x12\x18\n" +
+	"\x14PRIORITY_UNSPECIFIED\x10\x00\x12\x11\n" +
+	"\rPRIORITY_NONE\x10\x01\x12\x10\n" +
+	"\fPRIORITY_LOW\x10\x02\x12\x13\n" +
+	"\x0fPRIORITY_MEDIUM\x10\x03\x12\x11\n" +
+	"\rPRIORITY_HIGH\x10\x04BPZNgithub.com/MarioCarrion/todo-api-microservice-example/internal/protobuf/todov1b\x06proto3"
+
+var (
+	file_todo_v1_task_proto_rawDescOnce sync.Once
+	file_todo_v1_task_proto_rawDescData []byte
+)
+
+func file_todo_v1_task_proto_rawDescGZIP() []byte {
+	file_todo_v1_task_proto_rawDescOnce.Do(func() {
+		file_todo_v1_task_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_todo_v1_task_proto_rawDesc), len(file_todo_v1_task_proto_rawDesc)))
+	})
+	return file_todo_v1_task_proto_rawDescData
+}
+
+var file_todo_v1_task_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_todo_v1_task_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_todo_v1_task_proto_goTypes = []any{
+	(Priority)(0),                 // 0: todo.v1.Priority
+	(*Dates)(nil),                 // 1: todo.v1.Dates
+	(*Task)(nil),                  // 2: todo.v1.Task
+	(*timestamppb.Timestamp)(nil), // 3: google.protobuf.Timestamp
+}
+var file_todo_v1_task_proto_depIdxs = []int32{
+	3, // 0: todo.v1.Dates.start:type_name -> google.protobuf.Timestamp
+	3, // 1: todo.v1.Dates.due:type_name -> google.protobuf.Timestamp
+	0, // 2: todo.v1.Task.priority:type_name -> todo.v1.Priority
+	1, // 3: todo.v1.Task.dates:type_name -> todo.v1.Dates
+	2, // 4: todo.v1.Task.sub_tasks:type_name -> todo.v1.Task
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_todo_v1_task_proto_init() }
+func file_todo_v1_task_proto_init() {
+	if File_todo_v1_task_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_todo_v1_task_proto_rawDesc), len(file_todo_v1_task_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_todo_v1_task_proto_goTypes,
+		DependencyIndexes: file_todo_v1_task_proto_depIdxs,
+		EnumInfos:         file_todo_v1_task_proto_enumTypes,
+		MessageInfos:      file_todo_v1_task_proto_msgTypes,
+	}.Build()
+	File_todo_v1_task_proto = out.File
+	file_todo_v1_task_proto_goTypes = nil
+	file_todo_v1_task_proto_depIdxs = nil
+}
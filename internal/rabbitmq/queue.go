@@ -0,0 +1,78 @@
+package rabbitmq
+
+import (
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+const (
+	// HeaderAttempts is the header indicating how many times the message was retried.
+	HeaderAttempts = "x-attempts"
+
+	// HeaderFailureReason is the header indicating why the message was dead lettered.
+	HeaderFailureReason = "x-failure-reason"
+)
+
+// Queue represents the topology used by a consumer of Task events:
+//
+//   - the queue Name, bound to the Tasks exchange,
+//   - one retry queue per delay, bound to the retry exchange, messages in these queues expire after the delay and
+//     are dead lettered back to the queue, and
+//   - the dead letter queue, bound to the dead letter exchange, with the messages that exhausted the retries.
+type Queue struct {
+	Name        string
+	RetryDelays []time.Duration
+}
+
+// NewQueue instantiates the Queue using the default retry delays.
+func NewQueue(name string) Queue {
+	return Queue{
+		Name:        name,
+		RetryDelays: []time.Duration{time.Second, 10 * time.Second, time.Minute},
+	}
+}
+
+// RetryExchangeName returns the name of the exchange used for retrying messages.
+func (q Queue) RetryExchangeName() string {
+	return q.Name + ".retry"
+}
+
+// RetryQueueName returns the name of the queue used for retrying messages after delay.
+func (q Queue) RetryQueueName(delay time.Duration) string {
+	return q.RetryExchangeName() + "." + delay.String()
+}
+
+// DeadLetterExchangeName returns the name of the exchange used for dead lettering messages.
+func (q Queue) DeadLetterExchangeName() string {
+	return q.Name + ".dlx"
+}
+
+// DeadLetterQueueName returns the name of the queue with the dead lettered messages.
+func (q Queue) DeadLetterQueueName() string {
+	return q.Name + ".dlq"
+}
+
+// Attempts returns the number of times the message was retried.
+func Attempts(headers amqp.Table) int {
+	switch val := headers[HeaderAttempts].(type) {
+	case int32:
+		return int(val)
+	case int64:
+		return int(val)
+	case int:
+		return val
+	}
+
+	return 0
+}
+
+// MessageType returns the Task event type of the message, messages routed through the retry queues lose the
+// original routing key so the type property is used instead.
+func MessageType(msg amqp.Delivery) string {
+	if msg.Type != "" {
+		return msg.Type
+	}
+
+	return msg.RoutingKey
+}
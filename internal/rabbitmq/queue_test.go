@@ -0,0 +1,76 @@
+package rabbitmq_test
+
+import (
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+
+	rabbitmqtask "github.com/MarioCarrion/todo-api-microservice-example/internal/rabbitmq"
+)
+
+func TestQueue_Names(t *testing.T) {
+	t.Parallel()
+
+	queue := rabbitmqtask.NewQueue("indexer")
+
+	tests := []struct {
+		name     string
+		actual   string
+		expected string
+	}{
+		{"RetryExchangeName", queue.RetryExchangeName(), "indexer.retry"},
+		{"RetryQueueName", queue.RetryQueueName(10 * time.Second), "indexer.retry.10s"},
+		{"DeadLetterExchangeName", queue.DeadLetterExchangeName(), "indexer.dlx"},
+		{"DeadLetterQueueName", queue.DeadLetterQueueName(), "indexer.dlq"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if tt.actual != tt.expected {
+				t.Fatalf("expected %s, got %s", tt.expected, tt.actual)
+			}
+		})
+	}
+}
+
+func TestAttempts(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		headers  amqp.Table
+		expected int
+	}{
+		{"nil", nil, 0},
+		{"int32", amqp.Table{rabbitmqtask.HeaderAttempts: int32(2)}, 2},
+		{"int64", amqp.Table{rabbitmqtask.HeaderAttempts: int64(3)}, 3},
+		{"invalid", amqp.Table{rabbitmqtask.HeaderAttempts: "3"}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if actual := rabbitmqtask.Attempts(tt.headers); actual != tt.expected {
+				t.Fatalf("expected %d, got %d", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestMessageType(t *testing.T) {
+	t.Parallel()
+
+	if actual := rabbitmqtask.MessageType(amqp.Delivery{RoutingKey: "Task.Created"}); actual != "Task.Created" {
+		t.Fatalf("expected routing key, got %s", actual)
+	}
+
+	msg := amqp.Delivery{RoutingKey: "indexer", Type: "Task.Updated"}
+
+	if actual := rabbitmqtask.MessageType(msg); actual != "Task.Updated" {
+		t.Fatalf("expected type, got %s", actual)
+	}
+}
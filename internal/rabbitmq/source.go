@@ -0,0 +1,154 @@
+package rabbitmq
+
+import (
+	"context"
+	"maps"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+
+	"github.com/MarioCarrion/todo-api-microservice-example/internal"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/consumer"
+)
+
+// TaskSource represents the Message Broker adapter used to receive Task events.
+//
+// Nacked messages are published to the retry queues, once the retries are exhausted, or if the message is invalid,
+// they are published to the dead letter queue including the failure reason.
+type TaskSource struct {
+	ch    *amqp.Channel
+	queue Queue
+}
+
+// NewTaskSource instantiates the Task message broker adapter, the queue must be already declared.
+func NewTaskSource(channel *amqp.Channel, queue Queue) *TaskSource {
+	return &TaskSource{
+		ch:    channel,
+		queue: queue,
+	}
+}
+
+// Receive consumes the queue messages until the context is canceled, the queue name is used as the consumer tag.
+func (s *TaskSource) Receive(ctx context.Context) (<-chan consumer.Delivery, error) {
+	msgs, err := s.ch.ConsumeWithContext(ctx,
+		s.queue.Name, // queue
+		s.queue.Name, // consumer
+		false,        // auto-ack
+		false,        // exclusive
+		false,        // no-local
+		false,        // no-wait
+		nil,          // args
+	)
+	if err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "channel.Consume")
+	}
+
+	res := make(chan consumer.Delivery)
+
+	go func() {
+		defer close(res)
+
+		// msgs is closed after the consumer is canceled, messages already delivered are still received.
+		for msg := range msgs {
+			res <- s.newDelivery(msg)
+		}
+	}()
+
+	return res, nil
+}
+
+func (s *TaskSource) newDelivery(msg amqp.Delivery) *delivery {
+	res := delivery{
+		source: s,
+		msg:    msg,
+	}
+
+	res.event, res.err = Decode(msg)
+
+	return &res
+}
+
+// republish publishes a persistent copy of the message, headers are added to the original ones replacing the existing
+// values.
+func (s *TaskSource) republish(ctx context.Context, exchange, key string, msg amqp.Delivery, overrides amqp.Table) error {
+	headers := make(amqp.Table, len(msg.Headers)+len(overrides))
+
+	maps.Copy(headers, msg.Headers)
+	maps.Copy(headers, overrides)
+
+	err := s.ch.PublishWithContext(ctx,
+		exchange, // exchange
+		key,      // routing key
+		false,    // mandatory
+		false,    // immediate
+		amqp.Publishing{
+			Headers:      headers,
+			AppId:        msg.AppId,
+			ContentType:  msg.ContentType,
+			DeliveryMode: amqp.Persistent,
+			MessageId:    msg.MessageId,
+			Body:         msg.Body,
+			Timestamp:    msg.Timestamp,
+			Type:         MessageType(msg),
+		})
+	if err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "ch.Publish")
+	}
+
+	return nil
+}
+
+type delivery struct {
+	source *TaskSource
+	msg    amqp.Delivery
+	event  internal.Event
+	err    error
+}
+
+// Event returns the decoded message.
+func (d *delivery) Event() (internal.Event, error) {
+	return d.event, d.err
+}
+
+// Ack acknowledges the message.
+func (d *delivery) Ack(_ context.Context) error {
+	if err := d.msg.Ack(false); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "msg.Ack")
+	}
+
+	return nil
+}
+
+// Nack publishes the message to the next retry queue, or to the dead letter queue when the retries are exhausted,
+// and then acknowledges it. If publishing fails the message is rejected and dead lettered without the reason.
+func (d *delivery) Nack(ctx context.Context, reason error) error {
+	queue := d.source.queue
+	attempts := Attempts(d.msg.Headers)
+
+	var err error
+
+	if d.err == nil && consumer.Retryable(reason) && attempts < len(queue.RetryDelays) {
+		err = d.source.republish(ctx,
+			queue.RetryExchangeName(),
+			queue.RetryQueueName(queue.RetryDelays[attempts]),
+			d.msg,
+			amqp.Table{HeaderAttempts: int32(attempts + 1)}) //nolint: gosec
+	} else {
+		err = d.source.republish(ctx,
+			queue.DeadLetterExchangeName(),
+			queue.Name,
+			d.msg,
+			amqp.Table{HeaderAttempts: int32(attempts), HeaderFailureReason: reason.Error()}) //nolint: gosec
+	}
+
+	if err != nil {
+		_ = d.msg.Nack(false, false)
+
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "republish")
+	}
+
+	if err := d.msg.Ack(false); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "msg.Ack")
+	}
+
+	return nil
+}
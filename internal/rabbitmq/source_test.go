@@ -0,0 +1,156 @@
+package rabbitmq_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+
+	"github.com/MarioCarrion/todo-api-microservice-example/internal"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/cloudevents"
+	rabbitmqtask "github.com/MarioCarrion/todo-api-microservice-example/internal/rabbitmq"
+)
+
+func TestTaskSource_Nack(t *testing.T) {
+	t.Parallel()
+
+	client := setupClient()
+	if client.err != nil {
+		t.Fatalf("Failed to setupClient: %v", client.err)
+	}
+
+	channel, err := client.connection.Channel()
+	if err != nil {
+		t.Fatalf("Failed to open channel: %v", err)
+	}
+
+	t.Cleanup(func() { _ = channel.Close() })
+
+	queue := rabbitmqtask.Queue{
+		Name:        "test-source-nack",
+		RetryDelays: []time.Duration{10 * time.Millisecond, 20 * time.Millisecond, 30 * time.Millisecond},
+	}
+
+	declareQueue(t, channel, queue)
+
+	envelope, err := cloudevents.New(internal.Event{
+		Type: rabbitmqtask.TaskCreatedMessageType,
+		Task: internal.Task{ID: "test-123", Description: "Test task", Version: 1},
+	}, cloudevents.ContentTypeJSON)
+	if err != nil {
+		t.Fatalf("Failed to create envelope: %v", err)
+	}
+
+	headers := make(amqp.Table)
+	for name, val := range envelope.Attributes() {
+		headers[rabbitmqtask.HeaderPrefix+name] = val
+	}
+
+	if err := channel.PublishWithContext(t.Context(), "", queue.Name, false, false, amqp.Publishing{
+		Headers:     headers,
+		ContentType: envelope.DataContentType,
+		MessageId:   envelope.ID,
+		Body:        envelope.Data,
+		Type:        envelope.Type,
+	}); err != nil {
+		t.Fatalf("Failed to publish message: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Second)
+	t.Cleanup(cancel)
+
+	deliveries, err := rabbitmqtask.NewTaskSource(channel, queue).Receive(ctx)
+	if err != nil {
+		t.Fatalf("Failed to receive: %v", err)
+	}
+
+	// The message is delivered once plus once per retry, the last Nack dead letters it.
+	for i := range len(queue.RetryDelays) + 1 {
+		select {
+		case delivery := <-deliveries:
+			if _, err := delivery.Event(); err != nil {
+				t.Fatalf("Failed to decode delivery %d: %v", i, err)
+			}
+
+			if err := delivery.Nack(ctx, errors.New("failed")); err != nil {
+				t.Fatalf("Failed to nack delivery %d: %v", i, err)
+			}
+		case <-ctx.Done():
+			t.Fatalf("Did not receive delivery %d in time", i)
+		}
+	}
+
+	for {
+		msg, ok, err := channel.Get(queue.DeadLetterQueueName(), true)
+		if err != nil {
+			t.Fatalf("Failed to get dead lettered message: %v", err)
+		}
+
+		if ok {
+			if attempts := rabbitmqtask.Attempts(msg.Headers); attempts != len(queue.RetryDelays) {
+				t.Fatalf("Expected %d attempts, got %d", len(queue.RetryDelays), attempts)
+			}
+
+			if reason := msg.Headers[rabbitmqtask.HeaderFailureReason]; reason != "failed" {
+				t.Fatalf("Expected failure reason, got %v", reason)
+			}
+
+			if msg.DeliveryMode != amqp.Persistent {
+				t.Fatalf("Expected persistent message, got %d", msg.DeliveryMode)
+			}
+
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			t.Fatal("Did not dead letter message in time")
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+}
+
+// declareQueue declares the queue topology the same way `cmd/internal.RabbitMQ.DeclareQueue` does.
+func declareQueue(t *testing.T, channel *amqp.Channel, queue rabbitmqtask.Queue) {
+	t.Helper()
+
+	declare := func(name string, args amqp.Table) {
+		if _, err := channel.QueueDeclare(name, false, true, false, false, args); err != nil {
+			t.Fatalf("Failed to declare queue %s: %v", name, err)
+		}
+	}
+
+	declareExchange := func(name, kind string) {
+		if err := channel.ExchangeDeclare(name, kind, false, true, false, false, nil); err != nil {
+			t.Fatalf("Failed to declare exchange %s: %v", name, err)
+		}
+	}
+
+	declareExchange(queue.DeadLetterExchangeName(), amqp.ExchangeFanout)
+
+	declare(queue.DeadLetterQueueName(), nil)
+
+	if err := channel.QueueBind(queue.DeadLetterQueueName(), "", queue.DeadLetterExchangeName(), false, nil); err != nil {
+		t.Fatalf("Failed to bind dead letter queue: %v", err)
+	}
+
+	declare(queue.Name, nil)
+
+	declareExchange(queue.RetryExchangeName(), amqp.ExchangeDirect)
+
+	for _, delay := range queue.RetryDelays {
+		name := queue.RetryQueueName(delay)
+
+		declare(name, amqp.Table{
+			"x-message-ttl":             delay.Milliseconds(),
+			"x-dead-letter-exchange":    "",
+			"x-dead-letter-routing-key": queue.Name,
+		})
+
+		if err := channel.QueueBind(name, name, queue.RetryExchangeName(), false, nil); err != nil {
+			t.Fatalf("Failed to bind retry queue: %v", err)
+		}
+	}
+}
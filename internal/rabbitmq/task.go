@@ -2,25 +2,27 @@
 package rabbitmq
 
 import (
-	"bytes"
 	"context"
-	"encoding/gob"
-	"time"
+	"strings"
 
 	amqp "github.com/rabbitmq/amqp091-go"
 
 	"github.com/MarioCarrion/todo-api-microservice-example/internal"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/cloudevents"
 )
 
 const (
 	// TaskCreatedMessageType is the routing key used when a Task is created.
-	TaskCreatedMessageType = "Task.Created"
+	TaskCreatedMessageType = internal.EventTypeTaskCreated
 
 	// TaskDeletedMessageType is the routing key used when a Task is deleted.
-	TaskDeletedMessageType = "Task.Deleted"
+	TaskDeletedMessageType = internal.EventTypeTaskDeleted
 
 	// TaskUpdatedMessageType is the routing key used when a Task is updated.
-	TaskUpdatedMessageType = "Task.Updated"
+	TaskUpdatedMessageType = internal.EventTypeTaskUpdated
+
+	// HeaderPrefix is the prefix of the headers used for the CloudEvents context attributes.
+	HeaderPrefix = "cloudEvents:"
 
 	// ExchangeName is the name of the exchange used for Task messages.
 	ExchangeName = "Tasks"
@@ -31,48 +33,65 @@ const (
 
 // Task represents the repository used for publishing Task records.
 type Task struct {
-	ch *amqp.Channel
+	ch          *amqp.Channel
+	contentType string
 }
 
-// NewTask instantiates the Task repository.
-func NewTask(channel *amqp.Channel) *Task {
+// NewTask instantiates the Task repository, the content type is used for encoding the event data.
+func NewTask(channel *amqp.Channel, contentType string) *Task {
 	return &Task{
-		ch: channel,
+		ch:          channel,
+		contentType: contentType,
 	}
 }
 
 // Created publishes a message indicating a task was created.
 func (t *Task) Created(ctx context.Context, task internal.Task) error {
-	return t.publish(ctx, TaskCreatedMessageType, task)
+	return t.publish(ctx, TaskCreatedMessageType, task, nil)
 }
 
-// Deleted publishes a message indicating a task was deleted.
-func (t *Task) Deleted(ctx context.Context, id string) error {
-	return t.publish(ctx, TaskDeletedMessageType, id)
+// Deleted publishes a message indicating a task was deleted, the message includes the ID and the version of the
+// deleted task.
+func (t *Task) Deleted(ctx context.Context, id string, version int64) error {
+	return t.publish(ctx, TaskDeletedMessageType, internal.Task{ID: id, Version: version}, nil)
 }
 
-// Updated publishes a message indicating a task was updated.
-func (t *Task) Updated(ctx context.Context, task internal.Task) error {
-	return t.publish(ctx, TaskUpdatedMessageType, task)
+// Updated publishes a message indicating a task was updated, the message includes the changes.
+func (t *Task) Updated(ctx context.Context, task internal.Task, changes internal.TaskChanges) error {
+	return t.publish(ctx, TaskUpdatedMessageType, task, &changes)
 }
 
-func (t *Task) publish(ctx context.Context, routingKey string, event any) error {
-	var b bytes.Buffer
+// publish uses the CloudEvents AMQP binding in binary content mode: context attributes are sent as headers, and
+// matching properties, and the data as the message body.
+func (t *Task) publish(ctx context.Context,
+	routingKey internal.EventType,
+	task internal.Task,
+	changes *internal.TaskChanges,
+) error {
+	envelope, err := cloudevents.New(internal.Event{Type: routingKey, Task: task, Changes: changes}, t.contentType)
+	if err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "cloudevents.New")
+	}
+
+	headers := make(amqp.Table)
 
-	if err := gob.NewEncoder(&b).Encode(event); err != nil {
-		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "gob.Encode")
+	for name, val := range envelope.Attributes() {
+		headers[HeaderPrefix+name] = val
 	}
 
-	err := t.ch.PublishWithContext(ctx,
-		ExchangeName, // exchange
-		routingKey,   // routing key
-		false,        // mandatory
-		false,        // immediate
+	err = t.ch.PublishWithContext(ctx,
+		ExchangeName,       // exchange
+		string(routingKey), // routing key
+		false,              // mandatory
+		false,              // immediate
 		amqp.Publishing{
+			Headers:     headers,
 			AppId:       "tasks-rest-server",
-			ContentType: "application/x-encoding-gob", // XXX: We will revisit this in future episodes
-			Body:        b.Bytes(),
-			Timestamp:   time.Now(),
+			ContentType: envelope.DataContentType,
+			MessageId:   envelope.ID,
+			Body:        envelope.Data,
+			Timestamp:   envelope.Time,
+			Type:        envelope.Type,
 		})
 	if err != nil {
 		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "ch.Publish")
@@ -80,3 +99,31 @@ func (t *Task) publish(ctx context.Context, routingKey string, event any) error
 
 	return nil
 }
+
+// Decode returns the event in the message using the CloudEvents AMQP binding in binary content mode.
+func Decode(msg amqp.Delivery) (internal.Event, error) {
+	attrs := make(map[string]string)
+
+	for key, val := range msg.Headers {
+		name, ok := strings.CutPrefix(key, HeaderPrefix)
+		if !ok {
+			continue
+		}
+
+		if str, ok := val.(string); ok {
+			attrs[name] = str
+		}
+	}
+
+	envelope, err := cloudevents.FromAttributes(attrs, msg.ContentType, msg.Body)
+	if err != nil {
+		return internal.Event{}, internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "cloudevents.FromAttributes")
+	}
+
+	event, err := envelope.Event()
+	if err != nil {
+		return internal.Event{}, internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "envelope.Event")
+	}
+
+	return event, nil
+}
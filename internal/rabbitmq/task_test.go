@@ -1,9 +1,7 @@
 package rabbitmq_test
 
 import (
-	"bytes"
 	"context"
-	"encoding/gob"
 	"errors"
 	"fmt"
 	"os"
@@ -17,6 +15,7 @@ import (
 	"github.com/testcontainers/testcontainers-go/modules/rabbitmq"
 
 	"github.com/MarioCarrion/todo-api-microservice-example/internal"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/cloudevents"
 	rabbitmqtask "github.com/MarioCarrion/todo-api-microservice-example/internal/rabbitmq"
 )
 
@@ -55,14 +54,14 @@ func TestTask_All(t *testing.T) { //nolint: tparallel
 	tests := []struct {
 		name   string
 		call   func(t *testing.T, channel *amqp.Channel)
-		verify func(t *testing.T, routingKey string, body []byte)
+		verify func(t *testing.T, msg amqp.Delivery)
 	}{
 		{
 			name: "Created",
 			call: func(t *testing.T, channel *amqp.Channel) {
 				t.Helper()
 
-				taskPub := rabbitmqtask.NewTask(channel)
+				taskPub := rabbitmqtask.NewTask(channel, cloudevents.ContentTypeJSON)
 
 				task := internal.Task{
 					ID:          "test-123",
@@ -75,19 +74,24 @@ func TestTask_All(t *testing.T) { //nolint: tparallel
 					t.Fatalf("Failed to publish created event: %v", err)
 				}
 			},
-			verify: func(t *testing.T, routingKey string, body []byte) {
+			verify: func(t *testing.T, msg amqp.Delivery) {
 				t.Helper()
 
-				if routingKey != rabbitmqtask.TaskCreatedMessageType {
-					t.Fatalf("Expected routing key %s, got %s", rabbitmqtask.TaskCreatedMessageType, routingKey)
+				if msg.RoutingKey != string(rabbitmqtask.TaskCreatedMessageType) {
+					t.Fatalf("Expected routing key %s, got %s", rabbitmqtask.TaskCreatedMessageType, msg.RoutingKey)
 				}
 
-				var got internal.Task
+				event, err := rabbitmqtask.Decode(msg)
+				if err != nil {
+					t.Fatalf("Failed to decode message: %v", err)
+				}
 
-				if err := gob.NewDecoder(bytes.NewReader(body)).Decode(&got); err != nil {
-					t.Fatalf("Failed to decode body: %v", err)
+				if event.Type != rabbitmqtask.TaskCreatedMessageType {
+					t.Fatalf("Expected event type %s, got %s", rabbitmqtask.TaskCreatedMessageType, event.Type)
 				}
 
+				got := event.Task
+
 				expected := internal.Task{
 					ID:          "test-123",
 					Description: "Test task",
@@ -105,7 +109,7 @@ func TestTask_All(t *testing.T) { //nolint: tparallel
 			call: func(t *testing.T, channel *amqp.Channel) {
 				t.Helper()
 
-				taskPub := rabbitmqtask.NewTask(channel)
+				taskPub := rabbitmqtask.NewTask(channel, cloudevents.ContentTypeJSON)
 
 				task := internal.Task{
 					ID:          "test-123",
@@ -114,23 +118,31 @@ func TestTask_All(t *testing.T) { //nolint: tparallel
 					IsDone:      true,
 				}
 
-				if err := taskPub.Updated(ctx, task); err != nil {
+				previous := task
+				previous.Description = "Previous task"
+
+				if err := taskPub.Updated(ctx, task, internal.NewTaskChanges(previous, task)); err != nil {
 					t.Fatalf("Failed to publish created event: %v", err)
 				}
 			},
-			verify: func(t *testing.T, routingKey string, body []byte) {
+			verify: func(t *testing.T, msg amqp.Delivery) {
 				t.Helper()
 
-				if routingKey != rabbitmqtask.TaskUpdatedMessageType {
-					t.Fatalf("Expected routing key %s, got %s", rabbitmqtask.TaskUpdatedMessageType, routingKey)
+				if msg.RoutingKey != string(rabbitmqtask.TaskUpdatedMessageType) {
+					t.Fatalf("Expected routing key %s, got %s", rabbitmqtask.TaskUpdatedMessageType, msg.RoutingKey)
 				}
 
-				var got internal.Task
+				event, err := rabbitmqtask.Decode(msg)
+				if err != nil {
+					t.Fatalf("Failed to decode message: %v", err)
+				}
 
-				if err := gob.NewDecoder(bytes.NewReader(body)).Decode(&got); err != nil {
-					t.Fatalf("Failed to decode body: %v", err)
+				if event.Type != rabbitmqtask.TaskUpdatedMessageType {
+					t.Fatalf("Expected event type %s, got %s", rabbitmqtask.TaskUpdatedMessageType, event.Type)
 				}
 
+				got := event.Task
+
 				expected := internal.Task{
 					ID:          "test-123",
 					Description: "Test task",
@@ -141,6 +153,11 @@ func TestTask_All(t *testing.T) { //nolint: tparallel
 				if diff := cmp.Diff(got, expected); diff != "" {
 					t.Fatalf("Received task is not the same as the created one: %s", diff)
 				}
+
+				if event.Changes == nil || event.Changes.Previous.Description != "Previous task" ||
+					!event.Changes.Has(internal.TaskFieldDescription) {
+					t.Fatalf("Received changes do not match: %+v", event.Changes)
+				}
 			},
 		},
 		{
@@ -148,26 +165,34 @@ func TestTask_All(t *testing.T) { //nolint: tparallel
 			call: func(t *testing.T, channel *amqp.Channel) {
 				t.Helper()
 
-				taskPub := rabbitmqtask.NewTask(channel)
+				taskPub := rabbitmqtask.NewTask(channel, cloudevents.ContentTypeProtobuf)
 
-				if err := taskPub.Deleted(ctx, "test-123"); err != nil {
+				if err := taskPub.Deleted(ctx, "test-123", 2); err != nil {
 					t.Fatalf("Failed to publish created event: %v", err)
 				}
 			},
-			verify: func(t *testing.T, routingKey string, body []byte) {
+			verify: func(t *testing.T, msg amqp.Delivery) {
 				t.Helper()
 
-				if routingKey != rabbitmqtask.TaskDeletedMessageType {
-					t.Fatalf("Expected routing key %s, got %s", rabbitmqtask.TaskDeletedMessageType, routingKey)
+				if msg.RoutingKey != string(rabbitmqtask.TaskDeletedMessageType) {
+					t.Fatalf("Expected routing key %s, got %s", rabbitmqtask.TaskDeletedMessageType, msg.RoutingKey)
 				}
 
-				var got string
+				event, err := rabbitmqtask.Decode(msg)
+				if err != nil {
+					t.Fatalf("Failed to decode message: %v", err)
+				}
 
-				if err := gob.NewDecoder(bytes.NewReader(body)).Decode(&got); err != nil {
-					t.Fatalf("Failed to decode body: %v", err)
+				if event.Type != rabbitmqtask.TaskDeletedMessageType {
+					t.Fatalf("Expected event type %s, got %s", rabbitmqtask.TaskDeletedMessageType, event.Type)
 				}
 
-				expected := "test-123"
+				got := event.Task
+
+				expected := internal.Task{
+					ID:      "test-123",
+					Version: 2,
+				}
 
 				if diff := cmp.Diff(got, expected); diff != "" {
 					t.Fatalf("Received task is not the same as the created one: %s", diff)
@@ -185,7 +210,7 @@ func TestTask_All(t *testing.T) { //nolint: tparallel
 
 			select {
 			case msg := <-client.msgs:
-				tt.verify(t, msg.RoutingKey, msg.Body)
+				tt.verify(t, msg)
 			case <-ctx.Done():
 				t.Fatal("Did not receive message in time")
 			}
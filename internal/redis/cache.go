@@ -0,0 +1,94 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+
+	"github.com/MarioCarrion/todo-api-microservice-example/internal"
+)
+
+// incrementScript increases the number stored in KEYS[1] by ARGV[1], unlike INCRBY missing keys are not created.
+//
+//nolint:gochecknoglobals
+var incrementScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 0 then
+	return false
+end
+
+return redis.call('INCRBY', KEYS[1], ARGV[1])
+`)
+
+// Cache represents the repository used for caching values, implementing cache.Cache.
+type Cache struct {
+	client *redis.Client
+}
+
+// NewCache instantiates the Cache repository.
+func NewCache(client *redis.Client) *Cache {
+	return &Cache{
+		client: client,
+	}
+}
+
+// Get returns the value stored in key.
+func (c *Cache) Get(ctx context.Context, key string) ([]byte, error) {
+	res, err := c.client.Get(ctx, key).Bytes()
+	if err != nil {
+		return nil, internal.WrapErrorf(err, errorCode(err), "client.Get")
+	}
+
+	return res, nil
+}
+
+// Set stores the value in key, it expires after expiration.
+func (c *Cache) Set(ctx context.Context, key string, value []byte, expiration time.Duration) error {
+	if err := c.client.Set(ctx, key, value, expiration).Err(); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "client.Set")
+	}
+
+	return nil
+}
+
+// Add stores the value in key without expiring, unless it already exists.
+func (c *Cache) Add(ctx context.Context, key string, value []byte) error {
+	ok, err := c.client.SetNX(ctx, key, value, 0).Result()
+	if err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "client.SetNX")
+	}
+
+	if !ok {
+		return internal.NewErrorf(internal.ErrorCodeConflict, "already exists")
+	}
+
+	return nil
+}
+
+// Increment increases the number stored in key by delta.
+func (c *Cache) Increment(ctx context.Context, key string, delta uint64) (uint64, error) {
+	res, err := incrementScript.Run(ctx, c.client, []string{key}, delta).Uint64()
+	if err != nil {
+		return 0, internal.WrapErrorf(err, errorCode(err), "incrementScript.Run")
+	}
+
+	return res, nil
+}
+
+// Delete deletes the value stored in key.
+func (c *Cache) Delete(ctx context.Context, key string) error {
+	if err := c.client.Del(ctx, key).Err(); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "client.Del")
+	}
+
+	return nil
+}
+
+func errorCode(err error) internal.ErrorCode {
+	if errors.Is(err, redis.Nil) {
+		return internal.ErrorCodeNotFound
+	}
+
+	return internal.ErrorCodeUnknown
+}
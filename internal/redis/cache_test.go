@@ -0,0 +1,79 @@
+package redis_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/MarioCarrion/todo-api-microservice-example/internal"
+	redistask "github.com/MarioCarrion/todo-api-microservice-example/internal/redis"
+)
+
+func TestCache_All(t *testing.T) {
+	t.Parallel()
+
+	client := setupClient()
+	if client.err != nil {
+		t.Fatalf("Failed to setupClient: %v", client.err)
+	}
+
+	cache := redistask.NewCache(client.redis)
+
+	key := "cache-" + t.Name()
+
+	assertCode := func(t *testing.T, err error, code internal.ErrorCode) {
+		t.Helper()
+
+		var ierr *internal.Error
+		if !errors.As(err, &ierr) || ierr.Code() != code {
+			t.Fatalf("Expected error code %d, got %v", code, err)
+		}
+	}
+
+	if _, err := cache.Get(t.Context(), key); err == nil {
+		t.Fatalf("Expected missing value")
+	} else {
+		assertCode(t, err, internal.ErrorCodeNotFound)
+	}
+
+	if _, err := cache.Increment(t.Context(), key, 1); err == nil {
+		t.Fatalf("Expected missing value")
+	} else {
+		assertCode(t, err, internal.ErrorCodeNotFound)
+	}
+
+	if err := cache.Add(t.Context(), key, []byte("10")); err != nil {
+		t.Fatalf("Failed to add value: %v", err)
+	}
+
+	assertCode(t, cache.Add(t.Context(), key, []byte("20")), internal.ErrorCodeConflict)
+
+	res, err := cache.Increment(t.Context(), key, 2)
+	if err != nil {
+		t.Fatalf("Failed to increment value: %v", err)
+	}
+
+	if res != 12 {
+		t.Fatalf("Expected 12, got %d", res)
+	}
+
+	if err := cache.Set(t.Context(), key, []byte("value"), time.Minute); err != nil {
+		t.Fatalf("Failed to set value: %v", err)
+	}
+
+	value, err := cache.Get(t.Context(), key)
+	if err != nil {
+		t.Fatalf("Failed to get value: %v", err)
+	}
+
+	if string(value) != "value" {
+		t.Fatalf("Expected value, got %s", value)
+	}
+
+	if err := cache.Delete(t.Context(), key); err != nil {
+		t.Fatalf("Failed to delete value: %v", err)
+	}
+
+	_, err = cache.Get(t.Context(), key)
+	assertCode(t, err, internal.ErrorCodeNotFound)
+}
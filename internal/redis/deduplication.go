@@ -0,0 +1,89 @@
+package redis
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+
+	"github.com/MarioCarrion/todo-api-microservice-example/internal"
+)
+
+// processedScript returns 1 if the event ID, KEYS[1], exists or the version recorded for the task, ARGV[1], in the
+// hash KEYS[2] is greater than or equal to ARGV[2].
+//
+//nolint:gochecknoglobals
+var processedScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 1 then
+	return 1
+end
+
+local version = tonumber(redis.call('HGET', KEYS[2], ARGV[1]) or '0')
+if version >= tonumber(ARGV[2]) then
+	return 1
+end
+
+return 0
+`)
+
+// markProcessedScript records the event ID, KEYS[1], expiring after ARGV[3] milliseconds and the version, ARGV[2],
+// of the task, ARGV[1], in the hash KEYS[2] unless a newer version is already recorded.
+//
+//nolint:gochecknoglobals
+var markProcessedScript = redis.NewScript(`
+redis.call('SET', KEYS[1], 1, 'PX', ARGV[3])
+
+local version = tonumber(redis.call('HGET', KEYS[2], ARGV[1]) or '0')
+if tonumber(ARGV[2]) > version then
+	redis.call('HSET', KEYS[2], ARGV[1], ARGV[2])
+end
+
+return 0
+`)
+
+// DeduplicationStore represents the repository used for tracking the events processed by a consumer. Event IDs
+// expire after the configured TTL, Task versions are kept in a hash per consumer.
+type DeduplicationStore struct {
+	client   *redis.Client
+	consumer string
+	ttl      time.Duration
+}
+
+// NewDeduplicationStore instantiates the DeduplicationStore repository, consumer identifies the events processed by
+// different consumers and ttl indicates how long the event IDs are kept.
+func NewDeduplicationStore(client *redis.Client, consumer string, ttl time.Duration) *DeduplicationStore {
+	return &DeduplicationStore{
+		client:   client,
+		consumer: consumer,
+		ttl:      ttl,
+	}
+}
+
+// Processed indicates whether the event was already processed.
+func (d *DeduplicationStore) Processed(ctx context.Context, event internal.Event) (bool, error) {
+	res, err := processedScript.Run(ctx, d.client, d.keys(event), event.Task.ID, event.Version()).Int()
+	if err != nil {
+		return false, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "processedScript.Run")
+	}
+
+	return res == 1, nil
+}
+
+// MarkProcessed records the event ID and the version of the Task.
+func (d *DeduplicationStore) MarkProcessed(ctx context.Context, event internal.Event) error {
+	args := []any{event.Task.ID, event.Version(), strconv.FormatInt(d.ttl.Milliseconds(), 10)}
+
+	if err := markProcessedScript.Run(ctx, d.client, d.keys(event), args...).Err(); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "markProcessedScript.Run")
+	}
+
+	return nil
+}
+
+func (d *DeduplicationStore) keys(event internal.Event) []string {
+	return []string{
+		"processed:" + d.consumer + ":events:" + event.ID,
+		"processed:" + d.consumer + ":versions",
+	}
+}
@@ -0,0 +1,80 @@
+package redis_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/MarioCarrion/todo-api-microservice-example/internal"
+	redistask "github.com/MarioCarrion/todo-api-microservice-example/internal/redis"
+)
+
+func TestDeduplicationStore_All(t *testing.T) {
+	t.Parallel()
+
+	client := setupClient()
+	if client.err != nil {
+		t.Fatalf("Failed to setupClient: %v", client.err)
+	}
+
+	store := redistask.NewDeduplicationStore(client.redis, "consumer-"+t.Name(), time.Minute)
+
+	updated := internal.Event{
+		ID:   "test-123/2/Task.Updated",
+		Type: internal.EventTypeTaskUpdated,
+		Task: internal.Task{ID: "test-123", Version: 2},
+	}
+
+	processed, err := store.Processed(t.Context(), updated)
+	if err != nil {
+		t.Fatalf("Failed to check event: %v", err)
+	}
+
+	if processed {
+		t.Fatalf("Expected event not to be processed")
+	}
+
+	if err := store.MarkProcessed(t.Context(), updated); err != nil {
+		t.Fatalf("Failed to mark event: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		event    internal.Event
+		expected bool
+	}{
+		{
+			name:     "duplicated",
+			event:    updated,
+			expected: true,
+		},
+		{
+			name: "older version",
+			event: internal.Event{
+				ID:   "test-123/1/Task.Created",
+				Type: internal.EventTypeTaskCreated,
+				Task: internal.Task{ID: "test-123", Version: 1},
+			},
+			expected: true,
+		},
+		{
+			name: "deleted",
+			event: internal.Event{
+				ID:   "test-123/2/Task.Deleted",
+				Type: internal.EventTypeTaskDeleted,
+				Task: internal.Task{ID: "test-123", Version: 2},
+			},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		processed, err := store.Processed(t.Context(), tt.event)
+		if err != nil {
+			t.Fatalf("Failed to check %s event: %v", tt.name, err)
+		}
+
+		if processed != tt.expected {
+			t.Fatalf("Expected %s event processed to be %t, got %t", tt.name, tt.expected, processed)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package redis
+
+import (
+	"context"
+
+	"github.com/go-redis/redis/v8"
+
+	"github.com/MarioCarrion/todo-api-microservice-example/internal"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/consumer"
+)
+
+// TaskSource represents the Message Broker adapter used to receive Task events.
+//
+// Pub/Sub does not support acknowledging messages, messages published while not subscribed are lost and nacked
+// messages are not received again.
+type TaskSource struct {
+	client *redis.Client
+}
+
+// NewTaskSource instantiates the Task message broker adapter.
+func NewTaskSource(client *redis.Client) *TaskSource {
+	return &TaskSource{
+		client: client,
+	}
+}
+
+// Receive subscribes to the Task channels until the context is canceled.
+func (s *TaskSource) Receive(ctx context.Context) (<-chan consumer.Delivery, error) {
+	pubsub := s.client.PSubscribe(ctx, ChannelsWildcard)
+
+	if _, err := pubsub.Receive(ctx); err != nil {
+		_ = pubsub.Close()
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "pubsub.Receive")
+	}
+
+	msgs := pubsub.Channel()
+	res := make(chan consumer.Delivery)
+
+	go func() {
+		defer func() {
+			_ = pubsub.Close()
+
+			close(res)
+		}()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-msgs:
+				if !ok {
+					return
+				}
+
+				res <- newDelivery(msg)
+			}
+		}
+	}()
+
+	return res, nil
+}
+
+type delivery struct {
+	event internal.Event
+	err   error
+}
+
+func newDelivery(msg *redis.Message) *delivery {
+	var res delivery
+
+	res.event, res.err = decode(msg.Payload)
+
+	return &res
+}
+
+// Event returns the decoded message.
+func (d *delivery) Event() (internal.Event, error) {
+	return d.event, d.err
+}
+
+// Ack does nothing, Pub/Sub messages are not acknowledged.
+func (d *delivery) Ack(_ context.Context) error {
+	return nil
+}
+
+// Nack does nothing, Pub/Sub messages are not acknowledged.
+func (d *delivery) Nack(_ context.Context, _ error) error {
+	return nil
+}
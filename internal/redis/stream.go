@@ -0,0 +1,79 @@
+package redis
+
+import (
+	"context"
+
+	"github.com/go-redis/redis/v8"
+
+	"github.com/MarioCarrion/todo-api-microservice-example/internal"
+)
+
+const (
+	// TaskStream is the stream used for Task events.
+	TaskStream = "Tasks"
+
+	// TaskStreamMaxLen is the approximate number of events kept in the stream.
+	TaskStreamMaxLen = 10_000
+
+	streamFieldType   = "type"
+	streamFieldEvent  = "event"
+	streamFieldID     = "id"
+	streamFieldReason = "reason"
+)
+
+// DeadLetterStreamName returns the name of the stream with the messages dead lettered by the consumer group.
+func DeadLetterStreamName(stream, group string) string {
+	return stream + "." + group + ".dlq"
+}
+
+// StreamTask represents the repository used for publishing Task records using Redis Streams.
+type StreamTask struct {
+	client      *redis.Client
+	stream      string
+	contentType string
+}
+
+// NewStreamTask instantiates the StreamTask repository, the content type is used for encoding the event data.
+func NewStreamTask(client *redis.Client, stream, contentType string) *StreamTask {
+	return &StreamTask{
+		client:      client,
+		stream:      stream,
+		contentType: contentType,
+	}
+}
+
+// Created publishes a message indicating a task was created.
+func (t *StreamTask) Created(ctx context.Context, task internal.Task) error {
+	return t.publish(ctx, TaskCreatedChannel, task, nil)
+}
+
+// Deleted publishes a message indicating a task was deleted, the message includes the ID and the version of the
+// deleted task.
+func (t *StreamTask) Deleted(ctx context.Context, id string, version int64) error {
+	return t.publish(ctx, TaskDeletedChannel, internal.Task{ID: id, Version: version}, nil)
+}
+
+// Updated publishes a message indicating a task was updated, the message includes the changes.
+func (t *StreamTask) Updated(ctx context.Context, task internal.Task, changes internal.TaskChanges) error {
+	return t.publish(ctx, TaskUpdatedChannel, task, &changes)
+}
+
+// publish adds the event, using the CloudEvents JSON structured content mode, to the stream. The type is also included
+// as a separate field to make inspecting the stream easier.
+func (t *StreamTask) publish(ctx context.Context, msgType string, task internal.Task, changes *internal.TaskChanges) error {
+	payload, err := encode(internal.Event{Type: internal.EventType(msgType), Task: task, Changes: changes}, t.contentType)
+	if err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "encode")
+	}
+
+	if err := t.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: t.stream,
+		MaxLen: TaskStreamMaxLen,
+		Approx: true,
+		Values: []string{streamFieldType, msgType, streamFieldEvent, string(payload)},
+	}).Err(); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "client.XAdd")
+	}
+
+	return nil
+}
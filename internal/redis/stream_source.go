@@ -0,0 +1,339 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"go.uber.org/zap"
+
+	"github.com/MarioCarrion/todo-api-microservice-example/internal"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/consumer"
+)
+
+const (
+	streamBatchSize     = 10
+	streamBlock         = time.Second
+	streamClaimInterval = 30 * time.Second
+	streamClaimMinIdle  = time.Minute
+	streamErrorDelay    = time.Second
+	streamMaxDeliveries = 5
+)
+
+// StreamTaskSource represents the Message Broker adapter used to receive Task events using Redis Streams and
+// consumer groups.
+//
+// Acked messages are removed from the pending entries list. Nacked messages stay pending, they are claimed again,
+// using XAUTOCLAIM, after being idle for a while; the same happens to the messages of consumers that died before
+// acking them. Messages nacked after being delivered streamMaxDeliveries times, invalid messages and messages that
+// failed with a non retryable error are copied to the dead letter stream, including the reason, and then acked.
+type StreamTaskSource struct {
+	logger   *zap.Logger
+	client   *redis.Client
+	stream   string
+	group    string
+	consumer string
+}
+
+// NewStreamTaskSource instantiates the Task message broker adapter, name must be unique for each consumer in the
+// group.
+func NewStreamTaskSource(logger *zap.Logger, client *redis.Client, stream, group, name string) *StreamTaskSource {
+	return &StreamTaskSource{
+		logger:   logger,
+		client:   client,
+		stream:   stream,
+		group:    group,
+		consumer: name,
+	}
+}
+
+// Receive creates the consumer group, if needed, and reads the stream until the context is canceled.
+func (s *StreamTaskSource) Receive(ctx context.Context) (<-chan consumer.Delivery, error) {
+	if err := s.client.XGroupCreateMkStream(ctx, s.stream, s.group, "0").Err(); err != nil &&
+		!strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "client.XGroupCreateMkStream")
+	}
+
+	res := make(chan consumer.Delivery)
+
+	go func() {
+		defer close(res)
+
+		var (
+			claimStart = "0-0"
+			claimedAt  time.Time
+		)
+
+		for ctx.Err() == nil {
+			var (
+				msgs []redis.XMessage
+				// Messages read for the first time were delivered once.
+				deliveries map[string]int64
+			)
+
+			if time.Since(claimedAt) >= streamClaimInterval {
+				claimed, next, err := s.claim(ctx, claimStart)
+				if err != nil {
+					s.wait(ctx, "Couldn't claim pending messages", err)
+
+					continue
+				}
+
+				// "0-0" indicates the whole pending entries list was scanned.
+				if claimStart = next; next == "0-0" {
+					claimedAt = time.Now()
+				}
+
+				if deliveries, err = s.deliveries(ctx, claimed); err != nil {
+					s.wait(ctx, "Couldn't read the delivery count of claimed messages", err)
+
+					continue
+				}
+
+				msgs = claimed
+			}
+
+			if len(msgs) == 0 {
+				read, err := s.read(ctx)
+				if err != nil {
+					s.wait(ctx, "Couldn't read messages", err)
+
+					continue
+				}
+
+				msgs = read
+			}
+
+			for _, msg := range msgs {
+				select {
+				case <-ctx.Done():
+					// Still pending, the message will be claimed again.
+					return
+				case res <- s.newDelivery(msg, max(deliveries[msg.ID], 1)):
+				}
+			}
+		}
+	}()
+
+	return res, nil
+}
+
+func (s *StreamTaskSource) read(ctx context.Context) ([]redis.XMessage, error) {
+	streams, err := s.client.XReadGroup(ctx, &redis.XReadGroupArgs{
+		Group:    s.group,
+		Consumer: s.consumer,
+		Streams:  []string{s.stream, ">"},
+		Count:    streamBatchSize,
+		Block:    streamBlock,
+	}).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "client.XReadGroup")
+	}
+
+	var res []redis.XMessage
+
+	for _, stream := range streams {
+		res = append(res, stream.Messages...)
+	}
+
+	return res, nil
+}
+
+// claim transfers the messages pending for longer than streamClaimMinIdle to this consumer.
+//
+// XAUTOCLAIM is sent using Do because the go-redis v8 implementation doesn't support the reply used by Redis 7.
+func (s *StreamTaskSource) claim(ctx context.Context, start string) ([]redis.XMessage, string, error) {
+	val, err := s.client.Do(ctx,
+		"XAUTOCLAIM", s.stream, s.group, s.consumer, streamClaimMinIdle.Milliseconds(), start,
+		"COUNT", streamBatchSize).Result()
+	if err != nil {
+		return nil, "", internal.WrapErrorf(err, internal.ErrorCodeUnknown, "client.XAutoClaim")
+	}
+
+	msgs, next, err := parseXAutoClaim(val)
+	if err != nil {
+		return nil, "", internal.WrapErrorf(err, internal.ErrorCodeUnknown, "parseXAutoClaim")
+	}
+
+	return msgs, next, nil
+}
+
+// deliveries returns the number of times each message was delivered, using XPENDING.
+func (s *StreamTaskSource) deliveries(ctx context.Context, msgs []redis.XMessage) (map[string]int64, error) {
+	if len(msgs) == 0 {
+		return nil, nil
+	}
+
+	cmds := make([]*redis.XPendingExtCmd, len(msgs))
+
+	pipe := s.client.Pipeline()
+
+	for i, msg := range msgs {
+		cmds[i] = pipe.XPendingExt(ctx, &redis.XPendingExtArgs{
+			Stream: s.stream,
+			Group:  s.group,
+			Start:  msg.ID,
+			End:    msg.ID,
+			Count:  1,
+		})
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "client.XPendingExt")
+	}
+
+	res := make(map[string]int64, len(msgs))
+
+	for _, cmd := range cmds {
+		for _, pending := range cmd.Val() {
+			res[pending.ID] = pending.RetryCount
+		}
+	}
+
+	return res, nil
+}
+
+func (s *StreamTaskSource) wait(ctx context.Context, msg string, err error) {
+	if ctx.Err() != nil {
+		return
+	}
+
+	s.logger.Warn(msg, zap.Error(err))
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(streamErrorDelay):
+	}
+}
+
+func (s *StreamTaskSource) newDelivery(msg redis.XMessage, deliveries int64) *streamDelivery {
+	res := streamDelivery{
+		source:     s,
+		msg:        msg,
+		deliveries: deliveries,
+	}
+
+	payload, _ := msg.Values[streamFieldEvent].(string)
+
+	res.event, res.err = decode(payload)
+
+	return &res
+}
+
+func (s *StreamTaskSource) ack(ctx context.Context, id string) error {
+	if err := s.client.XAck(ctx, s.stream, s.group, id).Err(); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "client.XAck")
+	}
+
+	return nil
+}
+
+// deadLetter copies the message, including the original ID and the reason, to the dead letter stream and then acks
+// it; the message stays pending if copying it fails.
+func (s *StreamTaskSource) deadLetter(ctx context.Context, msg redis.XMessage, reason error) error {
+	values := make(map[string]any, len(msg.Values)+2)
+
+	maps.Copy(values, msg.Values)
+
+	values[streamFieldID] = msg.ID
+	values[streamFieldReason] = reason.Error()
+
+	if err := s.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: DeadLetterStreamName(s.stream, s.group),
+		MaxLen: TaskStreamMaxLen,
+		Approx: true,
+		Values: values,
+	}).Err(); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "client.XAdd")
+	}
+
+	return s.ack(ctx, msg.ID)
+}
+
+// parseXAutoClaim parses the XAUTOCLAIM reply: the next start ID, the claimed messages and, since Redis 7, the IDs
+// of the messages that no longer exist.
+func parseXAutoClaim(val any) ([]redis.XMessage, string, error) {
+	reply, ok := val.([]any)
+	if !ok || len(reply) < 2 {
+		return nil, "", internal.NewErrorf(internal.ErrorCodeUnknown, "invalid reply")
+	}
+
+	next, ok := reply[0].(string)
+	if !ok {
+		return nil, "", internal.NewErrorf(internal.ErrorCodeUnknown, "invalid start id")
+	}
+
+	entries, ok := reply[1].([]any)
+	if !ok {
+		return nil, "", internal.NewErrorf(internal.ErrorCodeUnknown, "invalid entries")
+	}
+
+	res := make([]redis.XMessage, 0, len(entries))
+
+	for _, entry := range entries {
+		// Entries deleted from the stream are nil in Redis 6.2.
+		fields, ok := entry.([]any)
+		if !ok || len(fields) != 2 {
+			continue
+		}
+
+		id, ok := fields[0].(string)
+		if !ok {
+			return nil, "", internal.NewErrorf(internal.ErrorCodeUnknown, "invalid entry id")
+		}
+
+		pairs, _ := fields[1].([]any)
+		values := make(map[string]any, len(pairs)/2)
+
+		for i := 0; i+1 < len(pairs); i += 2 {
+			if key, ok := pairs[i].(string); ok {
+				values[key] = pairs[i+1]
+			}
+		}
+
+		res = append(res, redis.XMessage{ID: id, Values: values})
+	}
+
+	return res, next, nil
+}
+
+type streamDelivery struct {
+	source     *StreamTaskSource
+	msg        redis.XMessage
+	deliveries int64
+	event      internal.Event
+	err        error
+}
+
+// Event returns the decoded message.
+func (d *streamDelivery) Event() (internal.Event, error) {
+	return d.event, d.err
+}
+
+// Ack acknowledges the message.
+func (d *streamDelivery) Ack(ctx context.Context) error {
+	return d.source.ack(ctx, d.msg.ID)
+}
+
+// Nack leaves the message pending so it's claimed again later, or dead letters it when the deliveries are exhausted
+// or retrying it would fail again.
+func (d *streamDelivery) Nack(ctx context.Context, reason error) error {
+	if d.err == nil && consumer.Retryable(reason) && d.deliveries < streamMaxDeliveries {
+		return nil
+	}
+
+	d.source.logger.Warn("Dead lettering message",
+		zap.String("id", d.msg.ID),
+		zap.Int64("deliveries", d.deliveries),
+		zap.Error(reason),
+	)
+
+	return d.source.deadLetter(ctx, d.msg, reason)
+}
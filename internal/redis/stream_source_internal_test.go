@@ -0,0 +1,70 @@
+package redis
+
+import (
+	"testing"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_parseXAutoClaim(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		input        any
+		expected     []redis.XMessage
+		expectedNext string
+		expectErr    bool
+	}{
+		{
+			name: "OK: Redis 7",
+			input: []any{
+				"0-0",
+				[]any{
+					[]any{"1-0", []any{"type", "Task.Created", "task", "{}"}},
+				},
+				[]any{"2-0"},
+			},
+			expected: []redis.XMessage{
+				{ID: "1-0", Values: map[string]any{"type": "Task.Created", "task": "{}"}},
+			},
+			expectedNext: "0-0",
+		},
+		{
+			name:         "OK: Redis 6.2 deleted entries",
+			input:        []any{"3-0", []any{nil}},
+			expected:     []redis.XMessage{},
+			expectedNext: "3-0",
+		},
+		{
+			name:      "ERR: invalid reply",
+			input:     "OK",
+			expectErr: true,
+		},
+		{
+			name:      "ERR: invalid entries",
+			input:     []any{"0-0", "entries"},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			actual, next, err := parseXAutoClaim(tt.input)
+			if (err != nil) != tt.expectErr {
+				t.Fatalf("expected error %t, got %v", tt.expectErr, err)
+			}
+
+			if next != tt.expectedNext {
+				t.Fatalf("expected next %s, got %s", tt.expectedNext, next)
+			}
+
+			if diff := cmp.Diff(tt.expected, actual); diff != "" {
+				t.Fatalf("expected messages do not match: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package redis_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"go.uber.org/zap"
+
+	"github.com/MarioCarrion/todo-api-microservice-example/internal"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/cloudevents"
+	redistask "github.com/MarioCarrion/todo-api-microservice-example/internal/redis"
+)
+
+func TestStreamTask_All(t *testing.T) {
+	t.Parallel()
+
+	client := setupClient()
+	if client.err != nil {
+		t.Fatalf("Failed to setupClient: %v", client.err)
+	}
+
+	stream := "stream-" + t.Name()
+
+	pub := redistask.NewStreamTask(client.redis, stream, cloudevents.ContentTypeProtobuf)
+
+	task := internal.Task{
+		ID:          "test-123",
+		Description: "Test task",
+		Version:     1,
+	}
+
+	// Published before the consumer group exists, the group reads the whole stream.
+	if err := pub.Created(t.Context(), task); err != nil {
+		t.Fatalf("Failed to publish created event: %v", err)
+	}
+
+	if err := pub.Deleted(t.Context(), task.ID, 2); err != nil {
+		t.Fatalf("Failed to publish deleted event: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Second)
+	defer cancel()
+
+	source := redistask.NewStreamTaskSource(zap.NewNop(), client.redis, stream, "indexer", "consumer-1")
+
+	deliveries, err := source.Receive(ctx)
+	if err != nil {
+		t.Fatalf("Failed to receive: %v", err)
+	}
+
+	expected := []internal.Event{
+		{ID: "test-123/1/Task.Created", Type: internal.EventTypeTaskCreated, Task: task},
+		{ID: "test-123/2/Task.Deleted", Type: internal.EventTypeTaskDeleted, Task: internal.Task{ID: task.ID, Version: 2}},
+	}
+
+	var actual []internal.Event
+
+	for delivery := range deliveries {
+		event, err := delivery.Event()
+		if err != nil {
+			t.Fatalf("Failed to decode event: %v", err)
+		}
+
+		if err := delivery.Ack(ctx); err != nil {
+			t.Fatalf("Failed to ack: %v", err)
+		}
+
+		actual = append(actual, event)
+
+		if len(actual) == len(expected) {
+			cancel()
+		}
+	}
+
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Fatalf("Received events do not match: %s", diff)
+	}
+
+	pending, err := client.redis.XPending(t.Context(), stream, "indexer").Result()
+	if err != nil {
+		t.Fatalf("Failed to get pending messages: %v", err)
+	}
+
+	if pending.Count != 0 {
+		t.Fatalf("expected no pending messages, got %d", pending.Count)
+	}
+}
+
+func TestStreamTaskSource_Nack(t *testing.T) {
+	t.Parallel()
+
+	client := setupClient()
+	if client.err != nil {
+		t.Fatalf("Failed to setupClient: %v", client.err)
+	}
+
+	stream := "stream-" + t.Name()
+
+	pub := redistask.NewStreamTask(client.redis, stream, cloudevents.ContentTypeProtobuf)
+
+	if err := pub.Created(t.Context(), internal.Task{ID: "test-123", Description: "Test task", Version: 1}); err != nil {
+		t.Fatalf("Failed to publish created event: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Second)
+	defer cancel()
+
+	source := redistask.NewStreamTaskSource(zap.NewNop(), client.redis, stream, "indexer", "consumer-1")
+
+	deliveries, err := source.Receive(ctx)
+	if err != nil {
+		t.Fatalf("Failed to receive: %v", err)
+	}
+
+	delivery := <-deliveries
+
+	reason := internal.NewErrorf(internal.ErrorCodeInvalidArgument, "invalid task")
+
+	if err := delivery.Nack(ctx, reason); err != nil {
+		t.Fatalf("Failed to nack: %v", err)
+	}
+
+	cancel()
+
+	msgs, err := client.redis.XRange(t.Context(), redistask.DeadLetterStreamName(stream, "indexer"), "-", "+").Result()
+	if err != nil {
+		t.Fatalf("Failed to read dead letter stream: %v", err)
+	}
+
+	if len(msgs) != 1 || msgs[0].Values["reason"] != reason.Error() {
+		t.Fatalf("expected one dead lettered message, got %v", msgs)
+	}
+
+	pending, err := client.redis.XPending(t.Context(), stream, "indexer").Result()
+	if err != nil {
+		t.Fatalf("Failed to get pending messages: %v", err)
+	}
+
+	if pending.Count != 0 {
+		t.Fatalf("expected no pending messages, got %d", pending.Count)
+	}
+}
@@ -2,13 +2,13 @@
 package redis
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 
 	"github.com/go-redis/redis/v8"
 
 	"github.com/MarioCarrion/todo-api-microservice-example/internal"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/cloudevents"
 )
 
 const (
@@ -27,42 +27,75 @@ const (
 
 // Task represents the repository used for publishing Task records.
 type Task struct {
-	client *redis.Client
+	client      *redis.Client
+	contentType string
 }
 
-// NewTask instantiates the Task repository.
-func NewTask(client *redis.Client) *Task {
+// NewTask instantiates the Task repository, the content type is used for encoding the event data.
+func NewTask(client *redis.Client, contentType string) *Task {
 	return &Task{
-		client: client,
+		client:      client,
+		contentType: contentType,
 	}
 }
 
 // Created publishes a message indicating a task was created.
 func (t *Task) Created(ctx context.Context, task internal.Task) error {
-	return t.publish(ctx, TaskCreatedChannel, task)
+	return t.publish(ctx, TaskCreatedChannel, task, nil)
 }
 
-// Deleted publishes a message indicating a task was deleted.
-func (t *Task) Deleted(ctx context.Context, id string) error {
-	return t.publish(ctx, TaskDeletedChannel, id)
+// Deleted publishes a message indicating a task was deleted, the message includes the ID and the version of the
+// deleted task.
+func (t *Task) Deleted(ctx context.Context, id string, version int64) error {
+	return t.publish(ctx, TaskDeletedChannel, internal.Task{ID: id, Version: version}, nil)
 }
 
-// Updated publishes a message indicating a task was updated.
-func (t *Task) Updated(ctx context.Context, task internal.Task) error {
-	return t.publish(ctx, TaskUpdatedChannel, task)
+// Updated publishes a message indicating a task was updated, the message includes the changes.
+func (t *Task) Updated(ctx context.Context, task internal.Task, changes internal.TaskChanges) error {
+	return t.publish(ctx, TaskUpdatedChannel, task, &changes)
 }
 
-func (t *Task) publish(ctx context.Context, channel string, event any) error {
-	var b bytes.Buffer
-
-	if err := json.NewEncoder(&b).Encode(event); err != nil {
-		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "json.Encode")
+func (t *Task) publish(ctx context.Context, channel string, task internal.Task, changes *internal.TaskChanges) error {
+	payload, err := encode(internal.Event{Type: internal.EventType(channel), Task: task, Changes: changes}, t.contentType)
+	if err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "encode")
 	}
 
-	res := t.client.Publish(ctx, channel, b.Bytes())
+	res := t.client.Publish(ctx, channel, payload)
 	if err := res.Err(); err != nil {
 		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "client.Publish")
 	}
 
 	return nil
 }
+
+// encode returns the event using the CloudEvents JSON structured content mode, both Pub/Sub and Streams use it.
+func encode(event internal.Event, contentType string) ([]byte, error) {
+	envelope, err := cloudevents.New(event, contentType)
+	if err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "cloudevents.New")
+	}
+
+	res, err := json.Marshal(envelope)
+	if err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "json.Marshal")
+	}
+
+	return res, nil
+}
+
+// decode returns the event encoded using the CloudEvents JSON structured content mode.
+func decode(payload string) (internal.Event, error) {
+	var envelope cloudevents.Envelope
+
+	if err := json.Unmarshal([]byte(payload), &envelope); err != nil {
+		return internal.Event{}, internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "json.Unmarshal")
+	}
+
+	event, err := envelope.Event()
+	if err != nil {
+		return internal.Event{}, internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "envelope.Event")
+	}
+
+	return event, nil
+}
@@ -6,7 +6,6 @@ import (
 	"errors"
 	"fmt"
 	"os"
-	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -17,6 +16,7 @@ import (
 	redistest "github.com/testcontainers/testcontainers-go/modules/redis"
 
 	"github.com/MarioCarrion/todo-api-microservice-example/internal"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/cloudevents"
 	redistask "github.com/MarioCarrion/todo-api-microservice-example/internal/redis"
 )
 
@@ -67,7 +67,7 @@ func TestTask_All(t *testing.T) {
 			call: func(t *testing.T, client *redis.Client) {
 				t.Helper()
 
-				taskPub := redistask.NewTask(client)
+				taskPub := redistask.NewTask(client, cloudevents.ContentTypeJSON)
 
 				task := internal.Task{
 					ID:          "test-123",
@@ -82,12 +82,19 @@ func TestTask_All(t *testing.T) {
 			verify: func(t *testing.T, msg *redis.Message) {
 				t.Helper()
 
-				var got internal.Task
+				var envelope cloudevents.Envelope
 
-				if err := json.NewDecoder(strings.NewReader(msg.Payload)).Decode(&got); err != nil {
+				if err := json.Unmarshal([]byte(msg.Payload), &envelope); err != nil {
 					t.Fatalf("Failed to decode message payload: %v", err)
 				}
 
+				event, err := envelope.Event()
+				if err != nil {
+					t.Fatalf("Failed to decode event: %v", err)
+				}
+
+				got := event.Task
+
 				task := internal.Task{
 					ID:          "test-123",
 					IsDone:      false,
@@ -119,7 +126,7 @@ func TestTask_All(t *testing.T) {
 			call: func(t *testing.T, client *redis.Client) {
 				t.Helper()
 
-				taskPub := redistask.NewTask(client)
+				taskPub := redistask.NewTask(client, cloudevents.ContentTypeJSON)
 
 				task := internal.Task{
 					ID:          "test-123",
@@ -127,19 +134,26 @@ func TestTask_All(t *testing.T) {
 					Description: "Update description",
 				}
 
-				if err := taskPub.Updated(t.Context(), task); err != nil {
+				if err := taskPub.Updated(t.Context(), task, internal.TaskChanges{}); err != nil {
 					t.Fatalf("Failed to publish created event: %v", err)
 				}
 			},
 			verify: func(t *testing.T, msg *redis.Message) {
 				t.Helper()
 
-				var got internal.Task
+				var envelope cloudevents.Envelope
 
-				if err := json.NewDecoder(strings.NewReader(msg.Payload)).Decode(&got); err != nil {
+				if err := json.Unmarshal([]byte(msg.Payload), &envelope); err != nil {
 					t.Fatalf("Failed to decode message payload: %v", err)
 				}
 
+				event, err := envelope.Event()
+				if err != nil {
+					t.Fatalf("Failed to decode event: %v", err)
+				}
+
+				got := event.Task
+
 				task := internal.Task{
 					ID:          "test-123",
 					IsDone:      true,
@@ -171,25 +185,35 @@ func TestTask_All(t *testing.T) {
 			call: func(t *testing.T, client *redis.Client) {
 				t.Helper()
 
-				taskPub := redistask.NewTask(client)
+				taskPub := redistask.NewTask(client, cloudevents.ContentTypeJSON)
 
-				if err := taskPub.Deleted(t.Context(), "test-delete"); err != nil {
+				if err := taskPub.Deleted(t.Context(), "test-delete", 2); err != nil {
 					t.Fatalf("Failed to publish deleted event: %v", err)
 				}
 			},
 			verify: func(t *testing.T, msg *redis.Message) {
 				t.Helper()
 
-				var got string
+				var envelope cloudevents.Envelope
 
-				if err := json.NewDecoder(strings.NewReader(msg.Payload)).Decode(&got); err != nil {
+				if err := json.Unmarshal([]byte(msg.Payload), &envelope); err != nil {
 					t.Fatalf("Failed to decode message payload: %v", err)
 				}
 
-				id := "test-delete"
+				event, err := envelope.Event()
+				if err != nil {
+					t.Fatalf("Failed to decode event: %v", err)
+				}
+
+				got := event.Task
+
+				expected := internal.Task{
+					ID:      "test-delete",
+					Version: 2,
+				}
 
-				if got != id {
-					t.Fatalf("Received task ID is not the same as the deleted one: %s, actual: %s", id, got)
+				if diff := cmp.Diff(got, expected); diff != "" {
+					t.Fatalf("Received task is not the same as the deleted one: %s", diff)
 				}
 			},
 		},
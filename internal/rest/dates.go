@@ -1,6 +1,10 @@
 package rest
 
 import (
+	"time"
+
+	"github.com/oapi-codegen/nullable"
+
 	"github.com/MarioCarrion/todo-api-microservice-example/internal"
 )
 
@@ -19,3 +23,40 @@ func (d Dates) ToDomain() internal.Dates {
 		Due:   d.Due,
 	}
 }
+
+// newUpdateDates converts the merge patch dates to the domain type, a null value clears both dates.
+func newUpdateDates(d nullable.Nullable[DatesPatch]) internal.UpdateDates {
+	if !d.IsSpecified() {
+		return internal.UpdateDates{}
+	}
+
+	if d.IsNull() {
+		return internal.UpdateDates{
+			Start: internal.NewOptional[time.Time](nil),
+			Due:   internal.NewOptional[time.Time](nil),
+		}
+	}
+
+	return d.MustGet().ToDomain()
+}
+
+// ToDomain returns the domain type defining the internal representation.
+func (d DatesPatch) ToDomain() internal.UpdateDates {
+	return internal.UpdateDates{
+		Start: newOptional(d.Start),
+		Due:   newOptional(d.Due),
+	}
+}
+
+// newOptional converts the merge patch value to the domain type.
+func newOptional[T any](n nullable.Nullable[T]) internal.Optional[T] {
+	if !n.IsSpecified() {
+		return internal.Optional[T]{}
+	}
+
+	if n.IsNull() {
+		return internal.NewOptional[T](nil)
+	}
+
+	return internal.NewOptional(new(n.MustGet()))
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/oapi-codegen/nullable"
 
 	"github.com/MarioCarrion/todo-api-microservice-example/internal"
 )
@@ -75,3 +76,51 @@ func TestDates_ToDomain(t *testing.T) {
 		})
 	}
 }
+
+func Test_newUpdateDates(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2009, 11, 10, 23, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		input  nullable.Nullable[DatesPatch]
+		output internal.UpdateDates
+	}{
+		{
+			"OK: unset",
+			nullable.Nullable[DatesPatch]{},
+			internal.UpdateDates{},
+		},
+		{
+			"OK: null",
+			nullable.NewNullNullable[DatesPatch](),
+			internal.UpdateDates{
+				Start: internal.NewOptional[time.Time](nil),
+				Due:   internal.NewOptional[time.Time](nil),
+			},
+		},
+		{
+			"OK: values",
+			nullable.NewNullableWithValue(DatesPatch{
+				Start: nullable.NewNullableWithValue(start),
+				Due:   nullable.NewNullNullable[time.Time](),
+			}),
+			internal.UpdateDates{
+				Start: internal.NewOptional(&start),
+				Due:   internal.NewOptional[time.Time](nil),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			actualRes := newUpdateDates(tt.input)
+			if !cmp.Equal(tt.output, actualRes, cmpopts.IgnoreUnexported(time.Time{})) {
+				t.Fatalf("expected output do not match\n%s", cmp.Diff(tt.output, actualRes, cmpopts.IgnoreUnexported(time.Time{})))
+			}
+		})
+	}
+}
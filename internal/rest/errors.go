@@ -0,0 +1,154 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+
+	"github.com/MarioCarrion/todo-api-microservice-example/internal"
+)
+
+const (
+	// ProblemContentType is the media type used for responses describing errors, see RFC 7807.
+	ProblemContentType = "application/problem+json"
+
+	problemTypeUnknown            = "urn:problem-type:todo:unknown"
+	problemTypeNotFound           = "urn:problem-type:todo:not-found"
+	problemTypeInvalidArgument    = "urn:problem-type:todo:invalid-argument"
+	problemTypeConflict           = "urn:problem-type:todo:conflict"
+	problemTypeUnauthorized       = "urn:problem-type:todo:unauthorized"
+	problemTypeForbidden          = "urn:problem-type:todo:forbidden"
+	problemTypeUnavailable        = "urn:problem-type:todo:unavailable"
+	problemTypePreconditionFailed = "urn:problem-type:todo:precondition-failed"
+)
+
+// newProblem returns the problem details describing the received error, the code of the first *internal.Error
+// found in the chain determines the status code. The error message is not included in the details to avoid
+// leaking internal values, instead a generic detail per code is used.
+func newProblem(err error, instance string) Problem {
+	code := internal.ErrorCodeUnknown
+
+	var ierr *internal.Error
+	if errors.As(err, &ierr) {
+		code = ierr.Code()
+	}
+
+	var (
+		status  int
+		typ     string
+		detail  string
+		invalid []ProblemFieldError
+	)
+
+	switch code {
+	case internal.ErrorCodeNotFound:
+		status, typ, detail = http.StatusNotFound, problemTypeNotFound, "The requested task does not exist."
+	case internal.ErrorCodeInvalidArgument:
+		status, typ, detail = http.StatusBadRequest, problemTypeInvalidArgument, "One or more values are invalid."
+
+		var verrs validation.Errors
+		if errors.As(err, &verrs) {
+			invalid = newProblemFieldErrors("", verrs)
+		}
+	case internal.ErrorCodeConflict:
+		status, typ, detail = http.StatusConflict, problemTypeConflict,
+			"The request conflicts with the current state of the task."
+	case internal.ErrorCodeUnauthorized:
+		status, typ, detail = http.StatusUnauthorized, problemTypeUnauthorized, "Authentication is required."
+	case internal.ErrorCodeForbidden:
+		status, typ, detail = http.StatusForbidden, problemTypeForbidden, "The operation is not allowed."
+	case internal.ErrorCodeUnavailable:
+		status, typ, detail = http.StatusServiceUnavailable, problemTypeUnavailable,
+			"The service is temporarily unavailable, try again later."
+	case internal.ErrorCodePreconditionFailed:
+		status, typ, detail = http.StatusPreconditionFailed, problemTypePreconditionFailed,
+			"The task was modified, read it again to get its current version."
+	case internal.ErrorCodeUnknown:
+		fallthrough
+	default:
+		status, typ, detail = http.StatusInternalServerError, problemTypeUnknown, "An unexpected error occurred."
+	}
+
+	res := Problem{
+		Type:     typ,
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   &detail,
+		Instance: &instance,
+	}
+
+	if len(invalid) > 0 {
+		res.Errors = &invalid
+	}
+
+	return res
+}
+
+// newProblemFieldErrors flattens the validation errors, including nested ones, field names are converted to
+// the names used by the API, for example "SubTasks.0.Description" becomes "subTasks.0.description".
+func newProblemFieldErrors(prefix string, errs validation.Errors) []ProblemFieldError {
+	keys := make([]string, 0, len(errs))
+	for key := range errs {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	res := make([]ProblemFieldError, 0, len(errs))
+
+	for _, key := range keys {
+		field := lowerFirst(key)
+		if prefix != "" {
+			field = prefix + "." + field
+		}
+
+		var nested validation.Errors
+		if errors.As(errs[key], &nested) {
+			res = append(res, newProblemFieldErrors(field, nested)...)
+
+			continue
+		}
+
+		res = append(res, ProblemFieldError{
+			Field:   field,
+			Message: errs[key].Error(),
+		})
+	}
+
+	return res
+}
+
+func lowerFirst(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+
+	return strings.ToLower(string(r)) + s[size:]
+}
+
+// WriteProblem writes the problem details using the received status code, it is meant to be used for errors
+// happening outside the TaskHandler, for example when the request can't be decoded.
+func WriteProblem(w http.ResponseWriter, r *http.Request, status int, detail string) {
+	res := Problem{
+		Type:     problemTypeUnknown,
+		Title:    http.StatusText(status),
+		Status:   status,
+		Instance: &r.URL.Path,
+	}
+
+	if status == http.StatusBadRequest {
+		res.Type = problemTypeInvalidArgument
+	}
+
+	if detail != "" {
+		res.Detail = &detail
+	}
+
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(status)
+
+	_ = json.NewEncoder(w).Encode(res)
+}
@@ -0,0 +1,136 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/MarioCarrion/todo-api-microservice-example/internal"
+)
+
+func Test_newProblem(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		input  error
+		output Problem
+	}{
+		{
+			"Unknown",
+			errors.New("repo.Create: insert task: connection refused"),
+			Problem{
+				Type:     problemTypeUnknown,
+				Title:    "Internal Server Error",
+				Status:   http.StatusInternalServerError,
+				Detail:   new("An unexpected error occurred."),
+				Instance: new("/tasks"),
+			},
+		},
+		{
+			"NotFound",
+			internal.WrapErrorf(internal.NewErrorf(internal.ErrorCodeNotFound, "task not found"),
+				internal.ErrorCodeUnknown, "Find"),
+			Problem{
+				Type:     problemTypeNotFound,
+				Title:    "Not Found",
+				Status:   http.StatusNotFound,
+				Detail:   new("The requested task does not exist."),
+				Instance: new("/tasks"),
+			},
+		},
+		{
+			"InvalidArgument",
+			internal.CreateParams{
+				Priority: new(internal.Priority(-1)),
+				SubTasks: []internal.CreateParams{
+					{Description: "sub-task"},
+					{},
+				},
+			}.Validate(),
+			Problem{
+				Type:     problemTypeInvalidArgument,
+				Title:    "Bad Request",
+				Status:   http.StatusBadRequest,
+				Detail:   new("One or more values are invalid."),
+				Instance: new("/tasks"),
+				Errors: &[]ProblemFieldError{
+					{Field: "description", Message: "cannot be blank"},
+					{Field: "priority", Message: "unknown value"},
+					{Field: "subTasks.1.description", Message: "cannot be blank"},
+				},
+			},
+		},
+		{
+			"Unavailable",
+			internal.NewErrorf(internal.ErrorCodeUnavailable, "service not available"),
+			Problem{
+				Type:     problemTypeUnavailable,
+				Title:    "Service Unavailable",
+				Status:   http.StatusServiceUnavailable,
+				Detail:   new("The service is temporarily unavailable, try again later."),
+				Instance: new("/tasks"),
+			},
+		},
+		{
+			"PreconditionFailed",
+			internal.NewErrorf(internal.ErrorCodePreconditionFailed, "version does not match"),
+			Problem{
+				Type:     problemTypePreconditionFailed,
+				Title:    "Precondition Failed",
+				Status:   http.StatusPreconditionFailed,
+				Detail:   new("The task was modified, read it again to get its current version."),
+				Instance: new("/tasks"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			actualRes := newProblem(tt.input, "/tasks")
+			if !cmp.Equal(tt.output, actualRes) {
+				t.Fatalf("expected output do not match\n%s", cmp.Diff(tt.output, actualRes))
+			}
+		})
+	}
+}
+
+func TestWriteProblem(t *testing.T) {
+	t.Parallel()
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/tasks/x", nil)
+
+	WriteProblem(rec, req, http.StatusBadRequest, "Invalid format for parameter id")
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected status %d, got %d", http.StatusBadRequest, rec.Code)
+	}
+
+	if ct := rec.Header().Get("Content-Type"); ct != ProblemContentType {
+		t.Fatalf("expected content type %s, got %s", ProblemContentType, ct)
+	}
+
+	var actualRes Problem
+	if err := json.NewDecoder(rec.Body).Decode(&actualRes); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := Problem{
+		Type:     problemTypeInvalidArgument,
+		Title:    "Bad Request",
+		Status:   http.StatusBadRequest,
+		Detail:   new("Invalid format for parameter id"),
+		Instance: new("/tasks/x"),
+	}
+
+	if !cmp.Equal(expected, actualRes) {
+		t.Fatalf("expected output do not match\n%s", cmp.Diff(expected, actualRes))
+	}
+}
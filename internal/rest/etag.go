@@ -0,0 +1,82 @@
+package rest
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/MarioCarrion/todo-api-microservice-example/internal"
+)
+
+const (
+	etagAny        = "*"
+	etagWeakPrefix = "W/"
+)
+
+// newETag returns the strong entity tag representing the version of a task, see RFC 9110.
+func newETag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// parseIfMatch returns the version indicated by the If-Match header, nil means any version matches.
+//
+// Only one entity tag is supported and because If-Match uses the strong comparison function, weak entity tags
+// never match.
+func parseIfMatch(header *string) (*int64, error) {
+	if header == nil {
+		return nil, nil //nolint: nilnil
+	}
+
+	val := strings.TrimSpace(*header)
+	if val == etagAny {
+		return nil, nil //nolint: nilnil
+	}
+
+	if strings.HasPrefix(val, etagWeakPrefix) {
+		return nil, internal.NewErrorf(internal.ErrorCodePreconditionFailed, "weak entity tags do not match")
+	}
+
+	version, err := parseETag(val)
+	if err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "parseETag")
+	}
+
+	return &version, nil
+}
+
+// matchesIfNoneMatch indicates whether the If-None-Match header matches the entity tag, using the weak comparison
+// function.
+func matchesIfNoneMatch(header *string, etag string) bool {
+	if header == nil {
+		return false
+	}
+
+	for val := range strings.SplitSeq(*header, ",") {
+		val = strings.TrimSpace(val)
+		if val == etagAny || strings.TrimPrefix(val, etagWeakPrefix) == etag {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseETag returns the version represented by the strong entity tag.
+func parseETag(val string) (int64, error) {
+	unquoted, ok := strings.CutPrefix(val, `"`)
+	if !ok {
+		return 0, internal.NewErrorf(internal.ErrorCodeInvalidArgument, "entity tag must be quoted")
+	}
+
+	unquoted, ok = strings.CutSuffix(unquoted, `"`)
+	if !ok {
+		return 0, internal.NewErrorf(internal.ErrorCodeInvalidArgument, "entity tag must be quoted")
+	}
+
+	// Entity tags not generated by newETag can't match any version.
+	version, err := strconv.ParseInt(unquoted, 10, 64)
+	if err != nil {
+		return 0, internal.WrapErrorf(err, internal.ErrorCodePreconditionFailed, "strconv.ParseInt")
+	}
+
+	return version, nil
+}
@@ -0,0 +1,126 @@
+package rest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/MarioCarrion/todo-api-microservice-example/internal"
+)
+
+func Test_parseIfMatch(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		input  *string
+		output *int64
+		code   *internal.ErrorCode
+	}{
+		{
+			"OK: missing",
+			nil,
+			nil,
+			nil,
+		},
+		{
+			"OK: any",
+			new("*"),
+			nil,
+			nil,
+		},
+		{
+			"OK: version",
+			new(`"12"`),
+			new(int64(12)),
+			nil,
+		},
+		{
+			"ERR: weak",
+			new(`W/"12"`),
+			nil,
+			new(internal.ErrorCodePreconditionFailed),
+		},
+		{
+			"ERR: unknown entity tag",
+			new(`"abc"`),
+			nil,
+			new(internal.ErrorCodePreconditionFailed),
+		},
+		{
+			"ERR: not quoted",
+			new("12"),
+			nil,
+			new(internal.ErrorCodeInvalidArgument),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			actualRes, err := parseIfMatch(tt.input)
+			if tt.code == nil && err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			if tt.code != nil {
+				var ierr *internal.Error
+				if !errors.As(err, &ierr) || ierr.Code() != *tt.code {
+					t.Fatalf("expected error code %d, got %v", *tt.code, err)
+				}
+			}
+
+			if !cmp.Equal(tt.output, actualRes) {
+				t.Fatalf("expected output do not match\n%s", cmp.Diff(tt.output, actualRes))
+			}
+		})
+	}
+}
+
+func Test_matchesIfNoneMatch(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		input  *string
+		output bool
+	}{
+		{
+			"missing",
+			nil,
+			false,
+		},
+		{
+			"any",
+			new("*"),
+			true,
+		},
+		{
+			"strong",
+			new(`"3"`),
+			true,
+		},
+		{
+			"weak in list",
+			new(`"1", W/"3"`),
+			true,
+		},
+		{
+			"different",
+			new(`"2"`),
+			false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if actualRes := matchesIfNoneMatch(tt.input, newETag(3)); actualRes != tt.output {
+				t.Fatalf("expected %t, got %t", tt.output, actualRes)
+			}
+		})
+	}
+}
@@ -52,11 +52,12 @@ type FakeTaskService struct {
 		result1 internal.Task
 		result2 error
 	}
-	DeleteStub        func(context.Context, string) error
+	DeleteStub        func(context.Context, string, internal.DeleteParams) error
 	deleteMutex       sync.RWMutex
 	deleteArgsForCall []struct {
 		arg1 context.Context
 		arg2 string
+		arg3 internal.DeleteParams
 	}
 	deleteReturns struct {
 		result1 error
@@ -64,6 +65,20 @@ type FakeTaskService struct {
 	deleteReturnsOnCall map[int]struct {
 		result1 error
 	}
+	SuggestStub        func(context.Context, internal.SuggestParams) ([]string, error)
+	suggestMutex       sync.RWMutex
+	suggestArgsForCall []struct {
+		arg1 context.Context
+		arg2 internal.SuggestParams
+	}
+	suggestReturns struct {
+		result1 []string
+		result2 error
+	}
+	suggestReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
 	UpdateStub        func(context.Context, string, internal.UpdateParams) error
 	updateMutex       sync.RWMutex
 	updateArgsForCall []struct {
@@ -276,19 +291,20 @@ func (fake *FakeTaskService) CreateReturnsOnCall(i int, result1 internal.Task, r
 	}{result1, result2}
 }
 
-func (fake *FakeTaskService) Delete(arg1 context.Context, arg2 string) error {
+func (fake *FakeTaskService) Delete(arg1 context.Context, arg2 string, arg3 internal.DeleteParams) error {
 	fake.deleteMutex.Lock()
 	ret, specificReturn := fake.deleteReturnsOnCall[len(fake.deleteArgsForCall)]
 	fake.deleteArgsForCall = append(fake.deleteArgsForCall, struct {
 		arg1 context.Context
 		arg2 string
-	}{arg1, arg2})
+		arg3 internal.DeleteParams
+	}{arg1, arg2, arg3})
 	stub := fake.DeleteStub
 	fakeReturns := fake.deleteReturns
-	fake.recordInvocation("Delete", []interface{}{arg1, arg2})
+	fake.recordInvocation("Delete", []interface{}{arg1, arg2, arg3})
 	fake.deleteMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2)
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1
@@ -302,17 +318,17 @@ func (fake *FakeTaskService) DeleteCallCount() int {
 	return len(fake.deleteArgsForCall)
 }
 
-func (fake *FakeTaskService) DeleteCalls(stub func(context.Context, string) error) {
+func (fake *FakeTaskService) DeleteCalls(stub func(context.Context, string, internal.DeleteParams) error) {
 	fake.deleteMutex.Lock()
 	defer fake.deleteMutex.Unlock()
 	fake.DeleteStub = stub
 }
 
-func (fake *FakeTaskService) DeleteArgsForCall(i int) (context.Context, string) {
+func (fake *FakeTaskService) DeleteArgsForCall(i int) (context.Context, string, internal.DeleteParams) {
 	fake.deleteMutex.RLock()
 	defer fake.deleteMutex.RUnlock()
 	argsForCall := fake.deleteArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *FakeTaskService) DeleteReturns(result1 error) {
@@ -338,6 +354,71 @@ func (fake *FakeTaskService) DeleteReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeTaskService) Suggest(arg1 context.Context, arg2 internal.SuggestParams) ([]string, error) {
+	fake.suggestMutex.Lock()
+	ret, specificReturn := fake.suggestReturnsOnCall[len(fake.suggestArgsForCall)]
+	fake.suggestArgsForCall = append(fake.suggestArgsForCall, struct {
+		arg1 context.Context
+		arg2 internal.SuggestParams
+	}{arg1, arg2})
+	stub := fake.SuggestStub
+	fakeReturns := fake.suggestReturns
+	fake.recordInvocation("Suggest", []interface{}{arg1, arg2})
+	fake.suggestMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTaskService) SuggestCallCount() int {
+	fake.suggestMutex.RLock()
+	defer fake.suggestMutex.RUnlock()
+	return len(fake.suggestArgsForCall)
+}
+
+func (fake *FakeTaskService) SuggestCalls(stub func(context.Context, internal.SuggestParams) ([]string, error)) {
+	fake.suggestMutex.Lock()
+	defer fake.suggestMutex.Unlock()
+	fake.SuggestStub = stub
+}
+
+func (fake *FakeTaskService) SuggestArgsForCall(i int) (context.Context, internal.SuggestParams) {
+	fake.suggestMutex.RLock()
+	defer fake.suggestMutex.RUnlock()
+	argsForCall := fake.suggestArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeTaskService) SuggestReturns(result1 []string, result2 error) {
+	fake.suggestMutex.Lock()
+	defer fake.suggestMutex.Unlock()
+	fake.SuggestStub = nil
+	fake.suggestReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTaskService) SuggestReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.suggestMutex.Lock()
+	defer fake.suggestMutex.Unlock()
+	fake.SuggestStub = nil
+	if fake.suggestReturnsOnCall == nil {
+		fake.suggestReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.suggestReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTaskService) Update(arg1 context.Context, arg2 string, arg3 internal.UpdateParams) error {
 	fake.updateMutex.Lock()
 	ret, specificReturn := fake.updateReturnsOnCall[len(fake.updateArgsForCall)]
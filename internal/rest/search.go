@@ -0,0 +1,76 @@
+package rest
+
+import (
+	"github.com/MarioCarrion/todo-api-microservice-example/internal"
+)
+
+// ToDomain returns the domain type defining the internal representation, when SearchSort is nil "relevance" is
+// used; unknown values are kept so they are rejected when validating the search arguments.
+func (s *SearchSort) ToDomain() internal.SearchSort {
+	if s == nil {
+		return internal.SearchSortRelevance
+	}
+
+	switch *s {
+	case SearchSortRelevance:
+		return internal.SearchSortRelevance
+	case SearchSortDueDate:
+		return internal.SearchSortDueDate
+	case SearchSortPriority:
+		return internal.SearchSortPriority
+	}
+
+	return internal.SearchSort(*s)
+}
+
+// ToDomain returns the domain type defining the internal representation, nil values are unbounded.
+func (r *DateRange) ToDomain() internal.TimeRange {
+	if r == nil {
+		return internal.TimeRange{}
+	}
+
+	return internal.TimeRange{
+		From: r.From,
+		To:   r.To,
+	}
+}
+
+// NewSearchFacets converts the received domain type to a rest type.
+func NewSearchFacets(f internal.SearchFacets) SearchFacets {
+	res := SearchFacets{
+		Priority: make(map[string]int64, len(f.Priorities)),
+		Done:     f.Done,
+		NotDone:  f.NotDone,
+	}
+
+	for priority, count := range f.Priorities {
+		res.Priority[string(NewPriority(priority))] = count
+	}
+
+	return res
+}
+
+// newSearchTaskHit converts the received domain type to a rest type, highlights are only included when not empty.
+func newSearchTaskHit(t internal.Task, highlights []string) (SearchTaskHit, error) {
+	task, err := newTask(t)
+	if err != nil {
+		return SearchTaskHit{}, err
+	}
+
+	res := SearchTaskHit{
+		Categories:  task.Categories,
+		Dates:       task.Dates,
+		Description: task.Description,
+		ID:          task.ID,
+		IsDone:      task.IsDone,
+		Priority:    task.Priority,
+		SubTasks:    task.SubTasks,
+		Version:     task.Version,
+	}
+
+	if len(highlights) > 0 {
+		res.Highlights = &SearchHighlights{Description: &highlights}
+	}
+
+	return res, nil
+}
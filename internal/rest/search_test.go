@@ -0,0 +1,96 @@
+package rest_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/MarioCarrion/todo-api-microservice-example/internal"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/rest"
+)
+
+func TestSearchSort_ToDomain(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		input  *rest.SearchSort
+		output internal.SearchSort
+	}{
+		{
+			"OK: nil",
+			nil,
+			internal.SearchSortRelevance,
+		},
+		{
+			"OK: relevance",
+			new(rest.SearchSortRelevance),
+			internal.SearchSortRelevance,
+		},
+		{
+			"OK: dueDate",
+			new(rest.SearchSortDueDate),
+			internal.SearchSortDueDate,
+		},
+		{
+			"OK: priority",
+			new(rest.SearchSortPriority),
+			internal.SearchSortPriority,
+		},
+		{
+			"OK: unknown",
+			new(rest.SearchSort("description")),
+			internal.SearchSort("description"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if actual := tt.input.ToDomain(); actual != tt.output {
+				t.Fatalf("expected %s, got %s", tt.output, actual)
+			}
+		})
+	}
+}
+
+func TestDateRange_ToDomain(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	if actual := (*rest.DateRange)(nil).ToDomain(); !actual.IsZero() {
+		t.Fatalf("expected zero range, got %v", actual)
+	}
+
+	expected := internal.TimeRange{From: &now}
+
+	if actual := (&rest.DateRange{From: &now}).ToDomain(); !cmp.Equal(expected, actual) {
+		t.Fatalf("expected output do not match\n%s", cmp.Diff(expected, actual))
+	}
+}
+
+func TestNewSearchFacets(t *testing.T) {
+	t.Parallel()
+
+	input := internal.SearchFacets{
+		Priorities: map[internal.Priority]int64{
+			internal.PriorityNone: 1,
+			internal.PriorityHigh: 3,
+		},
+		Done:    2,
+		NotDone: 2,
+	}
+
+	expected := rest.SearchFacets{
+		Priority: map[string]int64{"none": 1, "high": 3},
+		Done:     2,
+		NotDone:  2,
+	}
+
+	if actual := rest.NewSearchFacets(input); !cmp.Equal(expected, actual) {
+		t.Fatalf("expected output do not match\n%s", cmp.Diff(expected, actual))
+	}
+}
@@ -2,19 +2,21 @@
 
 // Package rest provides primitives to interact with the openapi HTTP API.
 //
-// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.5.0 DO NOT EDIT.
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.7.2 DO NOT EDIT.
 package rest
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
 
 	googleuuid "github.com/google/uuid"
+	"github.com/oapi-codegen/nullable"
 	"github.com/oapi-codegen/runtime"
-	strictnethttp "github.com/oapi-codegen/runtime/strictmiddleware/nethttp"
 )
 
 // Defines values for Priority.
@@ -25,25 +27,171 @@ const (
 	PriorityNone   Priority = "none"
 )
 
+// Valid indicates whether the value is a known member of the Priority enum.
+func (e Priority) Valid() bool {
+	switch e {
+	case PriorityHigh:
+		return true
+	case PriorityLow:
+		return true
+	case PriorityMedium:
+		return true
+	case PriorityNone:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for SearchSort.
+const (
+	SearchSortDueDate   SearchSort = "dueDate"
+	SearchSortPriority  SearchSort = "priority"
+	SearchSortRelevance SearchSort = "relevance"
+)
+
+// Valid indicates whether the value is a known member of the SearchSort enum.
+func (e SearchSort) Valid() bool {
+	switch e {
+	case SearchSortDueDate:
+		return true
+	case SearchSortPriority:
+		return true
+	case SearchSortRelevance:
+		return true
+	default:
+		return false
+	}
+}
+
+// Category Human readable value used to organize tasks, values are unique.
+type Category = string
+
+// DateRange Period of time, "from" is inclusive and "to" is exclusive; omitted values are unbounded.
+type DateRange struct {
+	From *time.Time `json:"from,omitempty"`
+	To   *time.Time `json:"to,omitempty"`
+}
+
 // Dates defines model for Dates.
 type Dates struct {
 	// Due When the task is expected to be due, seconds are dropped.
-	Due *time.Time `json:"due"`
+	Due *time.Time `json:"due,omitempty"`
 
 	// Start When the task is expected to begin, seconds are dropped.
-	Start *time.Time `json:"start"`
+	Start *time.Time `json:"start,omitempty"`
+}
+
+// DatesPatch Dates to update, omitted values are kept and null values are cleared.
+type DatesPatch struct {
+	// Due When the task is expected to be due, seconds are dropped.
+	Due nullable.Nullable[time.Time] `json:"due,omitempty"`
+
+	// Start When the task is expected to begin, seconds are dropped.
+	Start nullable.Nullable[time.Time] `json:"start,omitempty"`
+}
+
+// NewSubTask Values used for creating a sub-task.
+type NewSubTask struct {
+	Categories  *[]Category   `json:"categories,omitempty"`
+	Dates       *Dates        `json:"dates,omitempty"`
+	Description string        `json:"description"`
+	Priority    *Priority     `json:"priority,omitempty"`
+	SubTasks    *[]NewSubTask `json:"subTasks,omitempty"`
 }
 
 // Priority defines model for Priority.
 type Priority string
 
+// Problem Details about an error, see RFC 7807.
+type Problem struct {
+	// Detail Human-readable explanation specific to this occurrence of the problem.
+	Detail *string `json:"detail,omitempty"`
+
+	// Errors Invalid fields found in the request.
+	Errors *[]ProblemFieldError `json:"errors,omitempty"`
+
+	// Instance URI reference that identifies the specific occurrence of the problem.
+	Instance *string `json:"instance,omitempty"`
+
+	// Status HTTP status code generated for this occurrence of the problem.
+	Status int `json:"status"`
+
+	// Title Short, human-readable summary of the problem type.
+	Title string `json:"title"`
+
+	// Type URI reference that identifies the problem type.
+	Type string `json:"type"`
+}
+
+// ProblemFieldError Invalid field found in the request.
+type ProblemFieldError struct {
+	// Field Path to the field, for example "subTasks.0.description".
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// SearchFacets Number of tasks found per value, including the ones not returned because of pagination.
+type SearchFacets struct {
+	Done    int64 `json:"done"`
+	NotDone int64 `json:"notDone"`
+
+	// Priority Number of tasks per priority, for example "high".
+	Priority map[string]int64 `json:"priority"`
+}
+
+// SearchHighlights Fragments of the values matching the search, matching terms are wrapped in <em> tags and the rest of the text is HTML escaped.
+type SearchHighlights struct {
+	Description *[]string `json:"description,omitempty"`
+}
+
+// SearchSort How to sort the results: "relevance" uses how well the description matches, "dueDate" returns the tasks due first and "priority" the most urgent ones first.
+type SearchSort string
+
+// SearchTaskHit defines model for SearchTaskHit.
+type SearchTaskHit struct {
+	Categories  *[]Category `json:"categories,omitempty"`
+	Dates       *Dates      `json:"dates,omitempty"`
+	Description string      `json:"description"`
+
+	// Highlights Fragments of the values matching the search, matching terms are wrapped in <em> tags and the rest of the text is HTML escaped.
+	Highlights *SearchHighlights `json:"highlights,omitempty"`
+	ID         googleuuid.UUID   `json:"id"`
+	IsDone     *bool             `json:"isDone,omitempty"`
+	Priority   *Priority         `json:"priority,omitempty"`
+	SubTasks   *[]Task           `json:"subTasks,omitempty"`
+
+	// Version Current version of the task, changes every time the task is updated.
+	Version *int64 `json:"version,omitempty"`
+}
+
 // Task defines model for Task.
 type Task struct {
+	Categories  *[]Category     `json:"categories,omitempty"`
 	Dates       *Dates          `json:"dates,omitempty"`
 	Description string          `json:"description"`
 	ID          googleuuid.UUID `json:"id"`
 	IsDone      *bool           `json:"isDone,omitempty"`
 	Priority    *Priority       `json:"priority,omitempty"`
+	SubTasks    *[]Task         `json:"subTasks,omitempty"`
+
+	// Version Current version of the task, changes every time the task is updated.
+	Version *int64 `json:"version,omitempty"`
+}
+
+// TaskPatch Values used for partially updating a task, omitted values are kept.
+type TaskPatch struct {
+	// Categories When included, replaces all the existing categories.
+	Categories *[]Category `json:"categories,omitempty"`
+
+	// Dates When null, clears both dates.
+	Dates       nullable.Nullable[DatesPatch] `json:"dates,omitempty"`
+	Description *string                       `json:"description,omitempty"`
+	IsDone      *bool                         `json:"isDone,omitempty"`
+	Priority    *Priority                     `json:"priority,omitempty"`
+
+	// SubTasks When included, replaces all the existing sub-tasks.
+	SubTasks *[]NewSubTask `json:"subTasks,omitempty"`
 }
 
 // CreateTasksResponse defines model for CreateTasksResponse.
@@ -51,10 +199,8 @@ type CreateTasksResponse struct {
 	Task Task `json:"task"`
 }
 
-// ErrorResponse defines model for ErrorResponse.
-type ErrorResponse struct {
-	Error string `json:"error"`
-}
+// ErrorResponse Details about an error, see RFC 7807.
+type ErrorResponse = Problem
 
 // ReadTasksResponse defines model for ReadTasksResponse.
 type ReadTasksResponse struct {
@@ -63,56 +209,139 @@ type ReadTasksResponse struct {
 
 // SearchTasksResponse defines model for SearchTasksResponse.
 type SearchTasksResponse struct {
-	Tasks *[]Task `json:"tasks,omitempty"`
-	Total *int64  `json:"total,omitempty"`
+	// Cursor Value used for getting the next page, omitted when there are no more results.
+	Cursor *string `json:"cursor,omitempty"`
+
+	// Degraded Indicates the results were returned by a fallback search engine, because the primary one was unavailable.
+	Degraded *bool `json:"degraded,omitempty"`
+
+	// Facets Number of tasks found per value, including the ones not returned because of pagination.
+	Facets *SearchFacets    `json:"facets,omitempty"`
+	Tasks  *[]SearchTaskHit `json:"tasks,omitempty"`
+	Total  *int64           `json:"total,omitempty"`
+}
+
+// SuggestTasksResponse defines model for SuggestTasksResponse.
+type SuggestTasksResponse struct {
+	Suggestions []string `json:"suggestions"`
 }
 
 // CreateTasksRequest defines model for CreateTasksRequest.
 type CreateTasksRequest struct {
-	Dates       *Dates    `json:"dates,omitempty"`
-	Description string    `json:"description"`
-	Priority    *Priority `json:"priority,omitempty"`
+	Categories  *[]Category   `json:"categories,omitempty"`
+	Dates       *Dates        `json:"dates,omitempty"`
+	Description string        `json:"description"`
+	Priority    *Priority     `json:"priority,omitempty"`
+	SubTasks    *[]NewSubTask `json:"subTasks,omitempty"`
 }
 
+// PatchTasksRequest Values used for partially updating a task, omitted values are kept.
+type PatchTasksRequest = TaskPatch
+
 // SearchTasksRequest defines model for SearchTasksRequest.
 type SearchTasksRequest struct {
-	Description *string   `json:"description"`
-	From        int64     `json:"from"`
-	IsDone      *bool     `json:"isDone"`
-	Priority    *Priority `json:"priority,omitempty"`
-	Size        int64     `json:"size"`
+	// Cursor Value returned by the previous search for getting the next page, "from" must be 0 when used.
+	Cursor      *string `json:"cursor,omitempty"`
+	Description *string `json:"description,omitempty"`
+
+	// DueDate Period of time, "from" is inclusive and "to" is exclusive; omitted values are unbounded.
+	DueDate  *DateRange `json:"dueDate,omitempty"`
+	From     int64      `json:"from"`
+	IsDone   *bool      `json:"isDone,omitempty"`
+	Priority *Priority  `json:"priority,omitempty"`
+	Size     int64      `json:"size"`
+
+	// Sort How to sort the results: "relevance" uses how well the description matches, "dueDate" returns the tasks due first and "priority" the most urgent ones first.
+	Sort *SearchSort `json:"sort,omitempty"`
+
+	// StartDate Period of time, "from" is inclusive and "to" is exclusive; omitted values are unbounded.
+	StartDate *DateRange `json:"startDate,omitempty"`
 }
 
 // UpdateTasksRequest defines model for UpdateTasksRequest.
 type UpdateTasksRequest struct {
-	Dates       *Dates    `json:"dates,omitempty"`
-	Description *string   `json:"description,omitempty"`
-	IsDone      *bool     `json:"isDone,omitempty"`
-	Priority    *Priority `json:"priority,omitempty"`
+	// Categories When included, replaces all the existing categories.
+	Categories  *[]Category `json:"categories,omitempty"`
+	Dates       *Dates      `json:"dates,omitempty"`
+	Description *string     `json:"description,omitempty"`
+	IsDone      *bool       `json:"isDone,omitempty"`
+	Priority    *Priority   `json:"priority,omitempty"`
+
+	// SubTasks When included, replaces all the existing sub-tasks.
+	SubTasks *[]NewSubTask `json:"subTasks,omitempty"`
 }
 
 // CreateTaskJSONBody defines parameters for CreateTask.
 type CreateTaskJSONBody struct {
-	Dates       *Dates    `json:"dates,omitempty"`
-	Description string    `json:"description"`
-	Priority    *Priority `json:"priority,omitempty"`
+	Categories  *[]Category   `json:"categories,omitempty"`
+	Dates       *Dates        `json:"dates,omitempty"`
+	Description string        `json:"description"`
+	Priority    *Priority     `json:"priority,omitempty"`
+	SubTasks    *[]NewSubTask `json:"subTasks,omitempty"`
 }
 
 // SearchTaskJSONBody defines parameters for SearchTask.
 type SearchTaskJSONBody struct {
-	Description *string   `json:"description"`
-	From        int64     `json:"from"`
-	IsDone      *bool     `json:"isDone"`
-	Priority    *Priority `json:"priority,omitempty"`
-	Size        int64     `json:"size"`
+	// Cursor Value returned by the previous search for getting the next page, "from" must be 0 when used.
+	Cursor      *string `json:"cursor,omitempty"`
+	Description *string `json:"description,omitempty"`
+
+	// DueDate Period of time, "from" is inclusive and "to" is exclusive; omitted values are unbounded.
+	DueDate  *DateRange `json:"dueDate,omitempty"`
+	From     int64      `json:"from"`
+	IsDone   *bool      `json:"isDone,omitempty"`
+	Priority *Priority  `json:"priority,omitempty"`
+	Size     int64      `json:"size"`
+
+	// Sort How to sort the results: "relevance" uses how well the description matches, "dueDate" returns the tasks due first and "priority" the most urgent ones first.
+	Sort *SearchSort `json:"sort,omitempty"`
+
+	// StartDate Period of time, "from" is inclusive and "to" is exclusive; omitted values are unbounded.
+	StartDate *DateRange `json:"startDate,omitempty"`
+}
+
+// SuggestTaskParams defines parameters for SuggestTask.
+type SuggestTaskParams struct {
+	// Q Text typed so far, descriptions including words starting with each one of its words are suggested.
+	Q    string `form:"q" json:"q"`
+	Size *int64 `form:"size,omitempty" json:"size,omitempty"`
+}
+
+// DeleteTaskParams defines parameters for DeleteTask.
+type DeleteTaskParams struct {
+	// IfMatch When included, the operation only happens when the ETag of the task matches.
+	IfMatch *string `json:"If-Match,omitempty"`
+}
+
+// ReadTaskParams defines parameters for ReadTask.
+type ReadTaskParams struct {
+	// IfNoneMatch When included and it matches the ETag of the task, the task is not returned.
+	IfNoneMatch *string `json:"If-None-Match,omitempty"`
+}
+
+// PatchTaskParams defines parameters for PatchTask.
+type PatchTaskParams struct {
+	// IfMatch When included, the operation only happens when the ETag of the task matches.
+	IfMatch *string `json:"If-Match,omitempty"`
 }
 
 // UpdateTaskJSONBody defines parameters for UpdateTask.
 type UpdateTaskJSONBody struct {
-	Dates       *Dates    `json:"dates,omitempty"`
-	Description *string   `json:"description,omitempty"`
-	IsDone      *bool     `json:"isDone,omitempty"`
-	Priority    *Priority `json:"priority,omitempty"`
+	// Categories When included, replaces all the existing categories.
+	Categories  *[]Category `json:"categories,omitempty"`
+	Dates       *Dates      `json:"dates,omitempty"`
+	Description *string     `json:"description,omitempty"`
+	IsDone      *bool       `json:"isDone,omitempty"`
+	Priority    *Priority   `json:"priority,omitempty"`
+
+	// SubTasks When included, replaces all the existing sub-tasks.
+	SubTasks *[]NewSubTask `json:"subTasks,omitempty"`
+}
+
+// UpdateTaskParams defines parameters for UpdateTask.
+type UpdateTaskParams struct {
+	// IfMatch When included, the operation only happens when the ETag of the task matches.
+	IfMatch *string `json:"If-Match,omitempty"`
 }
 
 // CreateTaskJSONRequestBody defines body for CreateTask for application/json ContentType.
@@ -121,6 +350,9 @@ type CreateTaskJSONRequestBody CreateTaskJSONBody
 // SearchTaskJSONRequestBody defines body for SearchTask for application/json ContentType.
 type SearchTaskJSONRequestBody SearchTaskJSONBody
 
+// PatchTaskApplicationMergePatchPlusJSONRequestBody defines body for PatchTask for application/merge-patch+json ContentType.
+type PatchTaskApplicationMergePatchPlusJSONRequestBody = TaskPatch
+
 // UpdateTaskJSONRequestBody defines body for UpdateTask for application/json ContentType.
 type UpdateTaskJSONRequestBody UpdateTaskJSONBody
 
@@ -133,14 +365,20 @@ type ServerInterface interface {
 	// (POST /tasks/search)
 	SearchTask(w http.ResponseWriter, r *http.Request)
 
+	// (GET /tasks/suggest)
+	SuggestTask(w http.ResponseWriter, r *http.Request, params SuggestTaskParams)
+
 	// (DELETE /tasks/{id})
-	DeleteTask(w http.ResponseWriter, r *http.Request, id googleuuid.UUID)
+	DeleteTask(w http.ResponseWriter, r *http.Request, id googleuuid.UUID, params DeleteTaskParams)
 
 	// (GET /tasks/{id})
-	ReadTask(w http.ResponseWriter, r *http.Request, id googleuuid.UUID)
+	ReadTask(w http.ResponseWriter, r *http.Request, id googleuuid.UUID, params ReadTaskParams)
+
+	// (PATCH /tasks/{id})
+	PatchTask(w http.ResponseWriter, r *http.Request, id googleuuid.UUID, params PatchTaskParams)
 
 	// (PUT /tasks/{id})
-	UpdateTask(w http.ResponseWriter, r *http.Request, id googleuuid.UUID)
+	UpdateTask(w http.ResponseWriter, r *http.Request, id googleuuid.UUID, params UpdateTaskParams)
 }
 
 // ServerInterfaceWrapper converts contexts to parameters.
@@ -180,22 +418,93 @@ func (siw *ServerInterfaceWrapper) SearchTask(w http.ResponseWriter, r *http.Req
 	handler.ServeHTTP(w, r)
 }
 
+// SuggestTask operation middleware
+func (siw *ServerInterfaceWrapper) SuggestTask(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+	_ = err
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SuggestTaskParams
+
+	// ------------- Required query parameter "q" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, true, "q", r.URL.Query(), &params.Q, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		var requiredError *runtime.RequiredParameterError
+		if errors.As(err, &requiredError) {
+			siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "q"})
+		} else {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "q", Err: err})
+		}
+		return
+	}
+
+	// ------------- Optional query parameter "size" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "size", r.URL.Query(), &params.Size, runtime.BindQueryParameterOptions{Type: "integer", Format: "int64"})
+	if err != nil {
+		var requiredError *runtime.RequiredParameterError
+		if errors.As(err, &requiredError) {
+			siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "size"})
+		} else {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "size", Err: err})
+		}
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SuggestTask(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // DeleteTask operation middleware
 func (siw *ServerInterfaceWrapper) DeleteTask(w http.ResponseWriter, r *http.Request) {
 
 	var err error
+	_ = err
 
 	// ------------- Path parameter "id" -------------
 	var id googleuuid.UUID
 
-	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: "uuid"})
 	if err != nil {
 		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
 		return
 	}
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteTaskParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false, Type: "string", Format: ""})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.DeleteTask(w, r, id)
+		siw.Handler.DeleteTask(w, r, id, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -209,18 +518,93 @@ func (siw *ServerInterfaceWrapper) DeleteTask(w http.ResponseWriter, r *http.Req
 func (siw *ServerInterfaceWrapper) ReadTask(w http.ResponseWriter, r *http.Request) {
 
 	var err error
+	_ = err
 
 	// ------------- Path parameter "id" -------------
 	var id googleuuid.UUID
 
-	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: "uuid"})
 	if err != nil {
 		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
 		return
 	}
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ReadTaskParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-None-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-None-Match")]; found {
+		var IfNoneMatch string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-None-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-None-Match", valueList[0], &IfNoneMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false, Type: "string", Format: ""})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-None-Match", Err: err})
+			return
+		}
+
+		params.IfNoneMatch = &IfNoneMatch
+
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ReadTask(w, r, id)
+		siw.Handler.ReadTask(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PatchTask operation middleware
+func (siw *ServerInterfaceWrapper) PatchTask(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+	_ = err
+
+	// ------------- Path parameter "id" -------------
+	var id googleuuid.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PatchTaskParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false, Type: "string", Format: ""})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PatchTask(w, r, id, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -234,18 +618,43 @@ func (siw *ServerInterfaceWrapper) ReadTask(w http.ResponseWriter, r *http.Reque
 func (siw *ServerInterfaceWrapper) UpdateTask(w http.ResponseWriter, r *http.Request) {
 
 	var err error
+	_ = err
 
 	// ------------- Path parameter "id" -------------
 	var id googleuuid.UUID
 
-	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: "uuid"})
 	if err != nil {
 		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
 		return
 	}
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params UpdateTaskParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false, Type: "string", Format: ""})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.UpdateTask(w, r, id)
+		siw.Handler.UpdateTask(w, r, id, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -329,10 +738,10 @@ func Handler(si ServerInterface) http.Handler {
 	return HandlerWithOptions(si, StdHTTPServerOptions{})
 }
 
-// ServeMux is an abstraction of http.ServeMux.
+// ServeMux is an abstraction of [http.ServeMux].
 type ServeMux interface {
 	HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request))
-	ServeHTTP(w http.ResponseWriter, r *http.Request)
+	http.Handler
 }
 
 type StdHTTPServerOptions struct {
@@ -375,11 +784,13 @@ func HandlerWithOptions(si ServerInterface, options StdHTTPServerOptions) http.H
 		ErrorHandlerFunc:   options.ErrorHandlerFunc,
 	}
 
-	m.HandleFunc("POST "+options.BaseURL+"/tasks", wrapper.CreateTask)
-	m.HandleFunc("POST "+options.BaseURL+"/tasks/search", wrapper.SearchTask)
-	m.HandleFunc("DELETE "+options.BaseURL+"/tasks/{id}", wrapper.DeleteTask)
-	m.HandleFunc("GET "+options.BaseURL+"/tasks/{id}", wrapper.ReadTask)
-	m.HandleFunc("PUT "+options.BaseURL+"/tasks/{id}", wrapper.UpdateTask)
+	m.HandleFunc(http.MethodPost+" "+options.BaseURL+"/tasks", wrapper.CreateTask)
+	m.HandleFunc(http.MethodPost+" "+options.BaseURL+"/tasks/search", wrapper.SearchTask)
+	m.HandleFunc(http.MethodGet+" "+options.BaseURL+"/tasks/suggest", wrapper.SuggestTask)
+	m.HandleFunc(http.MethodDelete+" "+options.BaseURL+"/tasks/{id}", wrapper.DeleteTask)
+	m.HandleFunc(http.MethodGet+" "+options.BaseURL+"/tasks/{id}", wrapper.ReadTask)
+	m.HandleFunc(http.MethodPatch+" "+options.BaseURL+"/tasks/{id}", wrapper.PatchTask)
+	m.HandleFunc(http.MethodPut+" "+options.BaseURL+"/tasks/{id}", wrapper.UpdateTask)
 
 	return m
 }
@@ -388,17 +799,34 @@ type CreateTasksResponseJSONResponse struct {
 	Task Task `json:"task"`
 }
 
-type ErrorResponseJSONResponse struct {
-	Error string `json:"error"`
-}
+type ErrorResponseApplicationProblemPlusJSONResponse Problem
 
+type ReadTasksResponseResponseHeaders struct {
+	ETag *string
+}
 type ReadTasksResponseJSONResponse struct {
-	Task *Task `json:"task,omitempty"`
+	Body struct {
+		Task *Task `json:"task,omitempty"`
+	}
+
+	Headers ReadTasksResponseResponseHeaders
 }
 
 type SearchTasksResponseJSONResponse struct {
-	Tasks *[]Task `json:"tasks,omitempty"`
-	Total *int64  `json:"total,omitempty"`
+	// Cursor Value used for getting the next page, omitted when there are no more results.
+	Cursor *string `json:"cursor,omitempty"`
+
+	// Degraded Indicates the results were returned by a fallback search engine, because the primary one was unavailable.
+	Degraded *bool `json:"degraded,omitempty"`
+
+	// Facets Number of tasks found per value, including the ones not returned because of pagination.
+	Facets *SearchFacets    `json:"facets,omitempty"`
+	Tasks  *[]SearchTaskHit `json:"tasks,omitempty"`
+	Total  *int64           `json:"total,omitempty"`
+}
+
+type SuggestTasksResponseJSONResponse struct {
+	Suggestions []string `json:"suggestions"`
 }
 
 type CreateTaskRequestObject struct {
@@ -414,30 +842,62 @@ type CreateTask201JSONResponse struct {
 }
 
 func (response CreateTask201JSONResponse) VisitCreateTaskResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response); err != nil {
+		return err
+	}
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(201)
+	_, err := buf.WriteTo(w)
+	return err
+}
 
-	return json.NewEncoder(w).Encode(response)
+type CreateTask400ApplicationProblemPlusJSONResponse struct {
+	ErrorResponseApplicationProblemPlusJSONResponse
 }
 
-type CreateTask400JSONResponse struct{ ErrorResponseJSONResponse }
+func (response CreateTask400ApplicationProblemPlusJSONResponse) VisitCreateTaskResponse(w http.ResponseWriter) error {
 
-func (response CreateTask400JSONResponse) VisitCreateTaskResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/problem+json")
 	w.WriteHeader(400)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type CreateTask500ApplicationProblemPlusJSONResponse Problem
+
+func (response CreateTask500ApplicationProblemPlusJSONResponse) VisitCreateTaskResponse(w http.ResponseWriter) error {
 
-	return json.NewEncoder(w).Encode(response)
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+	_, err := buf.WriteTo(w)
+	return err
 }
 
-type CreateTask500JSONResponse struct {
-	Error string `json:"error"`
+type CreateTaskdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
 }
 
-func (response CreateTask500JSONResponse) VisitCreateTaskResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+func (response CreateTaskdefaultApplicationProblemPlusJSONResponse) VisitCreateTaskResponse(w http.ResponseWriter) error {
 
-	return json.NewEncoder(w).Encode(response)
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response.Body); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+	_, err := buf.WriteTo(w)
+	return err
 }
 
 type SearchTaskRequestObject struct {
@@ -453,34 +913,138 @@ type SearchTask200JSONResponse struct {
 }
 
 func (response SearchTask200JSONResponse) VisitSearchTaskResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response); err != nil {
+		return err
+	}
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
+	_, err := buf.WriteTo(w)
+	return err
+}
 
-	return json.NewEncoder(w).Encode(response)
+type SearchTask400ApplicationProblemPlusJSONResponse struct {
+	ErrorResponseApplicationProblemPlusJSONResponse
 }
 
-type SearchTask400JSONResponse struct{ ErrorResponseJSONResponse }
+func (response SearchTask400ApplicationProblemPlusJSONResponse) VisitSearchTaskResponse(w http.ResponseWriter) error {
 
-func (response SearchTask400JSONResponse) VisitSearchTaskResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/problem+json")
 	w.WriteHeader(400)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type SearchTask500ApplicationProblemPlusJSONResponse Problem
+
+func (response SearchTask500ApplicationProblemPlusJSONResponse) VisitSearchTaskResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+	_, err := buf.WriteTo(w)
+	return err
+}
 
-	return json.NewEncoder(w).Encode(response)
+type SearchTaskdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
 }
 
-type SearchTask500JSONResponse struct {
-	Error string `json:"error"`
+func (response SearchTaskdefaultApplicationProblemPlusJSONResponse) VisitSearchTaskResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response.Body); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+	_, err := buf.WriteTo(w)
+	return err
 }
 
-func (response SearchTask500JSONResponse) VisitSearchTaskResponse(w http.ResponseWriter) error {
+type SuggestTaskRequestObject struct {
+	Params SuggestTaskParams
+}
+
+type SuggestTaskResponseObject interface {
+	VisitSuggestTaskResponse(w http.ResponseWriter) error
+}
+
+type SuggestTask200JSONResponse struct {
+	SuggestTasksResponseJSONResponse
+}
+
+func (response SuggestTask200JSONResponse) VisitSuggestTaskResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response); err != nil {
+		return err
+	}
 	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type SuggestTask400ApplicationProblemPlusJSONResponse struct {
+	ErrorResponseApplicationProblemPlusJSONResponse
+}
+
+func (response SuggestTask400ApplicationProblemPlusJSONResponse) VisitSuggestTaskResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type SuggestTask500ApplicationProblemPlusJSONResponse Problem
+
+func (response SuggestTask500ApplicationProblemPlusJSONResponse) VisitSuggestTaskResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/problem+json")
 	w.WriteHeader(500)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type SuggestTaskdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response SuggestTaskdefaultApplicationProblemPlusJSONResponse) VisitSuggestTaskResponse(w http.ResponseWriter) error {
 
-	return json.NewEncoder(w).Encode(response)
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response.Body); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+	_, err := buf.WriteTo(w)
+	return err
 }
 
 type DeleteTaskRequestObject struct {
-	Id googleuuid.UUID `json:"id"`
+	Id     googleuuid.UUID `json:"id"`
+	Params DeleteTaskParams
 }
 
 type DeleteTaskResponseObject interface {
@@ -495,25 +1059,70 @@ func (response DeleteTask200Response) VisitDeleteTaskResponse(w http.ResponseWri
 	return nil
 }
 
-type DeleteTask404Response struct {
+type DeleteTask404ApplicationProblemPlusJSONResponse struct {
+	ErrorResponseApplicationProblemPlusJSONResponse
 }
 
-func (response DeleteTask404Response) VisitDeleteTaskResponse(w http.ResponseWriter) error {
+func (response DeleteTask404ApplicationProblemPlusJSONResponse) VisitDeleteTaskResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/problem+json")
 	w.WriteHeader(404)
-	return nil
+	_, err := buf.WriteTo(w)
+	return err
 }
 
-type DeleteTask500JSONResponse struct{ ErrorResponseJSONResponse }
+type DeleteTask412ApplicationProblemPlusJSONResponse Problem
 
-func (response DeleteTask500JSONResponse) VisitDeleteTaskResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
+func (response DeleteTask412ApplicationProblemPlusJSONResponse) VisitDeleteTaskResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(412)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type DeleteTask500ApplicationProblemPlusJSONResponse Problem
+
+func (response DeleteTask500ApplicationProblemPlusJSONResponse) VisitDeleteTaskResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/problem+json")
 	w.WriteHeader(500)
+	_, err := buf.WriteTo(w)
+	return err
+}
 
-	return json.NewEncoder(w).Encode(response)
+type DeleteTaskdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response DeleteTaskdefaultApplicationProblemPlusJSONResponse) VisitDeleteTaskResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response.Body); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+	_, err := buf.WriteTo(w)
+	return err
 }
 
 type ReadTaskRequestObject struct {
-	Id googleuuid.UUID `json:"id"`
+	Id     googleuuid.UUID `json:"id"`
+	Params ReadTaskParams
 }
 
 type ReadTaskResponseObject interface {
@@ -523,32 +1132,180 @@ type ReadTaskResponseObject interface {
 type ReadTask200JSONResponse struct{ ReadTasksResponseJSONResponse }
 
 func (response ReadTask200JSONResponse) VisitReadTaskResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response.Body); err != nil {
+		return err
+	}
 	w.Header().Set("Content-Type", "application/json")
+	if response.Headers.ETag != nil {
+		w.Header().Set("ETag", fmt.Sprint(*response.Headers.ETag))
+	}
 	w.WriteHeader(200)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type ReadTask304ResponseHeaders struct {
+	ETag *string
+}
 
-	return json.NewEncoder(w).Encode(response)
+type ReadTask304Response struct {
+	Headers ReadTask304ResponseHeaders
 }
 
-type ReadTask404Response struct {
+func (response ReadTask304Response) VisitReadTaskResponse(w http.ResponseWriter) error {
+	if response.Headers.ETag != nil {
+		w.Header().Set("ETag", fmt.Sprint(*response.Headers.ETag))
+	}
+	w.WriteHeader(304)
+	return nil
 }
 
-func (response ReadTask404Response) VisitReadTaskResponse(w http.ResponseWriter) error {
+type ReadTask404ApplicationProblemPlusJSONResponse struct {
+	ErrorResponseApplicationProblemPlusJSONResponse
+}
+
+func (response ReadTask404ApplicationProblemPlusJSONResponse) VisitReadTaskResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/problem+json")
 	w.WriteHeader(404)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type ReadTask500ApplicationProblemPlusJSONResponse Problem
+
+func (response ReadTask500ApplicationProblemPlusJSONResponse) VisitReadTaskResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type ReadTaskdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response ReadTaskdefaultApplicationProblemPlusJSONResponse) VisitReadTaskResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response.Body); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type PatchTaskRequestObject struct {
+	Id     googleuuid.UUID `json:"id"`
+	Params PatchTaskParams
+	Body   *PatchTaskApplicationMergePatchPlusJSONRequestBody
+}
+
+type PatchTaskResponseObject interface {
+	VisitPatchTaskResponse(w http.ResponseWriter) error
+}
+
+type PatchTask200Response struct {
+}
+
+func (response PatchTask200Response) VisitPatchTaskResponse(w http.ResponseWriter) error {
+	w.WriteHeader(200)
 	return nil
 }
 
-type ReadTask500JSONResponse struct{ ErrorResponseJSONResponse }
+type PatchTask400ApplicationProblemPlusJSONResponse struct {
+	ErrorResponseApplicationProblemPlusJSONResponse
+}
 
-func (response ReadTask500JSONResponse) VisitReadTaskResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
+func (response PatchTask400ApplicationProblemPlusJSONResponse) VisitPatchTaskResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type PatchTask404ApplicationProblemPlusJSONResponse Problem
+
+func (response PatchTask404ApplicationProblemPlusJSONResponse) VisitPatchTaskResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type PatchTask412ApplicationProblemPlusJSONResponse Problem
+
+func (response PatchTask412ApplicationProblemPlusJSONResponse) VisitPatchTaskResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(412)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type PatchTask500ApplicationProblemPlusJSONResponse Problem
+
+func (response PatchTask500ApplicationProblemPlusJSONResponse) VisitPatchTaskResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/problem+json")
 	w.WriteHeader(500)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type PatchTaskdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
 
-	return json.NewEncoder(w).Encode(response)
+func (response PatchTaskdefaultApplicationProblemPlusJSONResponse) VisitPatchTaskResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response.Body); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+	_, err := buf.WriteTo(w)
+	return err
 }
 
 type UpdateTaskRequestObject struct {
-	Id   googleuuid.UUID `json:"id"`
-	Body *UpdateTaskJSONRequestBody
+	Id     googleuuid.UUID `json:"id"`
+	Params UpdateTaskParams
+	Body   *UpdateTaskJSONRequestBody
 }
 
 type UpdateTaskResponseObject interface {
@@ -563,32 +1320,79 @@ func (response UpdateTask200Response) VisitUpdateTaskResponse(w http.ResponseWri
 	return nil
 }
 
-type UpdateTask400JSONResponse struct{ ErrorResponseJSONResponse }
+type UpdateTask400ApplicationProblemPlusJSONResponse struct {
+	ErrorResponseApplicationProblemPlusJSONResponse
+}
 
-func (response UpdateTask400JSONResponse) VisitUpdateTaskResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+func (response UpdateTask400ApplicationProblemPlusJSONResponse) VisitUpdateTaskResponse(w http.ResponseWriter) error {
 
-	return json.NewEncoder(w).Encode(response)
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+	_, err := buf.WriteTo(w)
+	return err
 }
 
-type UpdateTask404Response struct {
-}
+type UpdateTask404ApplicationProblemPlusJSONResponse Problem
 
-func (response UpdateTask404Response) VisitUpdateTaskResponse(w http.ResponseWriter) error {
+func (response UpdateTask404ApplicationProblemPlusJSONResponse) VisitUpdateTaskResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/problem+json")
 	w.WriteHeader(404)
-	return nil
+	_, err := buf.WriteTo(w)
+	return err
 }
 
-type UpdateTask500JSONResponse struct {
-	Error string `json:"error"`
+type UpdateTask412ApplicationProblemPlusJSONResponse Problem
+
+func (response UpdateTask412ApplicationProblemPlusJSONResponse) VisitUpdateTaskResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(412)
+	_, err := buf.WriteTo(w)
+	return err
 }
 
-func (response UpdateTask500JSONResponse) VisitUpdateTaskResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
+type UpdateTask500ApplicationProblemPlusJSONResponse Problem
+
+func (response UpdateTask500ApplicationProblemPlusJSONResponse) VisitUpdateTaskResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/problem+json")
 	w.WriteHeader(500)
+	_, err := buf.WriteTo(w)
+	return err
+}
 
-	return json.NewEncoder(w).Encode(response)
+type UpdateTaskdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response UpdateTaskdefaultApplicationProblemPlusJSONResponse) VisitUpdateTaskResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response.Body); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+	_, err := buf.WriteTo(w)
+	return err
 }
 
 // StrictServerInterface represents all server handlers.
@@ -600,18 +1404,24 @@ type StrictServerInterface interface {
 	// (POST /tasks/search)
 	SearchTask(ctx context.Context, request SearchTaskRequestObject) (SearchTaskResponseObject, error)
 
+	// (GET /tasks/suggest)
+	SuggestTask(ctx context.Context, request SuggestTaskRequestObject) (SuggestTaskResponseObject, error)
+
 	// (DELETE /tasks/{id})
 	DeleteTask(ctx context.Context, request DeleteTaskRequestObject) (DeleteTaskResponseObject, error)
 
 	// (GET /tasks/{id})
 	ReadTask(ctx context.Context, request ReadTaskRequestObject) (ReadTaskResponseObject, error)
 
+	// (PATCH /tasks/{id})
+	PatchTask(ctx context.Context, request PatchTaskRequestObject) (PatchTaskResponseObject, error)
+
 	// (PUT /tasks/{id})
 	UpdateTask(ctx context.Context, request UpdateTaskRequestObject) (UpdateTaskResponseObject, error)
 }
 
-type StrictHandlerFunc = strictnethttp.StrictHTTPHandlerFunc
-type StrictMiddlewareFunc = strictnethttp.StrictHTTPMiddlewareFunc
+type StrictHandlerFunc func(ctx context.Context, w http.ResponseWriter, r *http.Request, request any) (any, error)
+type StrictMiddlewareFunc func(f StrictHandlerFunc, operationID string) StrictHandlerFunc
 
 type StrictHTTPServerOptions struct {
 	RequestErrorHandlerFunc  func(w http.ResponseWriter, r *http.Request, err error)
@@ -701,11 +1511,38 @@ func (sh *strictHandler) SearchTask(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// SuggestTask operation middleware
+func (sh *strictHandler) SuggestTask(w http.ResponseWriter, r *http.Request, params SuggestTaskParams) {
+	var request SuggestTaskRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.SuggestTask(ctx, request.(SuggestTaskRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "SuggestTask")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(SuggestTaskResponseObject); ok {
+		if err := validResponse.VisitSuggestTaskResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // DeleteTask operation middleware
-func (sh *strictHandler) DeleteTask(w http.ResponseWriter, r *http.Request, id googleuuid.UUID) {
+func (sh *strictHandler) DeleteTask(w http.ResponseWriter, r *http.Request, id googleuuid.UUID, params DeleteTaskParams) {
 	var request DeleteTaskRequestObject
 
 	request.Id = id
+	request.Params = params
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
 		return sh.ssi.DeleteTask(ctx, request.(DeleteTaskRequestObject))
@@ -728,10 +1565,11 @@ func (sh *strictHandler) DeleteTask(w http.ResponseWriter, r *http.Request, id g
 }
 
 // ReadTask operation middleware
-func (sh *strictHandler) ReadTask(w http.ResponseWriter, r *http.Request, id googleuuid.UUID) {
+func (sh *strictHandler) ReadTask(w http.ResponseWriter, r *http.Request, id googleuuid.UUID, params ReadTaskParams) {
 	var request ReadTaskRequestObject
 
 	request.Id = id
+	request.Params = params
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
 		return sh.ssi.ReadTask(ctx, request.(ReadTaskRequestObject))
@@ -753,11 +1591,46 @@ func (sh *strictHandler) ReadTask(w http.ResponseWriter, r *http.Request, id goo
 	}
 }
 
+// PatchTask operation middleware
+func (sh *strictHandler) PatchTask(w http.ResponseWriter, r *http.Request, id googleuuid.UUID, params PatchTaskParams) {
+	var request PatchTaskRequestObject
+
+	request.Id = id
+	request.Params = params
+
+	var body PatchTaskApplicationMergePatchPlusJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PatchTask(ctx, request.(PatchTaskRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PatchTask")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PatchTaskResponseObject); ok {
+		if err := validResponse.VisitPatchTaskResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // UpdateTask operation middleware
-func (sh *strictHandler) UpdateTask(w http.ResponseWriter, r *http.Request, id googleuuid.UUID) {
+func (sh *strictHandler) UpdateTask(w http.ResponseWriter, r *http.Request, id googleuuid.UUID, params UpdateTaskParams) {
 	var request UpdateTaskRequestObject
 
 	request.Id = id
+	request.Params = params
 
 	var body UpdateTaskJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
@@ -0,0 +1,106 @@
+package rest
+
+import (
+	"github.com/google/uuid"
+
+	"github.com/MarioCarrion/todo-api-microservice-example/internal"
+)
+
+// newTask converts the received domain type, including its sub-tasks, to a rest type.
+func newTask(t internal.Task) (Task, error) {
+	id, err := uuid.Parse(t.ID)
+	if err != nil {
+		return Task{}, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "uuid.Parse")
+	}
+
+	res := Task{
+		ID:          id,
+		Description: t.Description,
+		IsDone:      &t.IsDone,
+		Categories:  newCategories(t.Categories),
+		Version:     &t.Version,
+	}
+
+	if t.Priority != nil {
+		res.Priority = new(NewPriority(*t.Priority))
+	}
+
+	if t.Dates != nil {
+		res.Dates = new(newDates(*t.Dates))
+	}
+
+	if len(t.SubTasks) > 0 {
+		subTasks := make([]Task, len(t.SubTasks))
+
+		for i, subTask := range t.SubTasks {
+			if subTasks[i], err = newTask(subTask); err != nil {
+				return Task{}, err
+			}
+		}
+
+		res.SubTasks = &subTasks
+	}
+
+	return res, nil
+}
+
+// ToDomain returns the domain type defining the internal representation.
+func (s NewSubTask) ToDomain() internal.CreateParams {
+	res := internal.CreateParams{
+		Description: s.Description,
+		Categories:  categoriesToDomain(s.Categories),
+		SubTasks:    subTasksToDomain(s.SubTasks),
+	}
+
+	if s.Priority != nil {
+		res.Priority = s.Priority.ToDomain()
+	}
+
+	if s.Dates != nil {
+		res.Dates = new(s.Dates.ToDomain())
+	}
+
+	return res
+}
+
+func newCategories(categories []internal.Category) *[]Category {
+	if len(categories) == 0 {
+		return nil
+	}
+
+	res := make([]Category, len(categories))
+
+	for i, category := range categories {
+		res[i] = string(category)
+	}
+
+	return &res
+}
+
+func categoriesToDomain(categories *[]Category) []internal.Category {
+	if categories == nil {
+		return nil
+	}
+
+	res := make([]internal.Category, len(*categories))
+
+	for i, category := range *categories {
+		res[i] = internal.Category(category)
+	}
+
+	return res
+}
+
+func subTasksToDomain(subTasks *[]NewSubTask) []internal.CreateParams {
+	if subTasks == nil {
+		return nil
+	}
+
+	res := make([]internal.CreateParams, len(*subTasks))
+
+	for i, subTask := range *subTasks {
+		res[i] = subTask.ToDomain()
+	}
+
+	return res
+}
@@ -2,8 +2,9 @@ package rest
 
 import (
 	"context"
+	"net/http"
 
-	"github.com/google/uuid"
+	"go.uber.org/zap"
 
 	"github.com/MarioCarrion/todo-api-microservice-example/internal"
 )
@@ -15,21 +16,24 @@ import (
 // TaskService ...
 type TaskService interface {
 	By(ctx context.Context, args internal.SearchParams) (internal.SearchResults, error)
+	Suggest(ctx context.Context, args internal.SuggestParams) ([]string, error)
 	Create(ctx context.Context, params internal.CreateParams) (internal.Task, error)
-	Delete(ctx context.Context, id string) error
+	Delete(ctx context.Context, id string, params internal.DeleteParams) error
 	ByID(ctx context.Context, id string) (internal.Task, error)
 	Update(ctx context.Context, id string, args internal.UpdateParams) error
 }
 
 // TaskHandler ...
 type TaskHandler struct {
-	svc TaskService
+	svc    TaskService
+	logger *zap.Logger
 }
 
 // NewTaskHandler ...
-func NewTaskHandler(svc TaskService) *TaskHandler {
+func NewTaskHandler(svc TaskService, logger *zap.Logger) *TaskHandler {
 	return &TaskHandler{
-		svc: svc,
+		svc:    svc,
+		logger: logger,
 	}
 }
 
@@ -48,158 +52,247 @@ func (t *TaskHandler) CreateTask(ctx context.Context, req CreateTaskRequestObjec
 		Description: req.Body.Description,
 		Priority:    priority,
 		Dates:       dates,
+		SubTasks:    subTasksToDomain(req.Body.SubTasks),
+		Categories:  categoriesToDomain(req.Body.Categories),
 	})
-
-	// TODO: determine if this is a validation error or a different kind of error, and use "CreateTask400JSONResponse"
 	if err != nil {
-		return CreateTask500JSONResponse{ //nolint: nilerr
-			Error: err.Error(),
-		}, nil
-	}
+		problem := t.newProblem(err, "/tasks")
 
-	id, err := uuid.Parse(task.ID)
-	if err != nil {
-		return CreateTask500JSONResponse{ //nolint: nilerr
-			Error: err.Error(),
-		}, nil
+		return CreateTaskdefaultApplicationProblemPlusJSONResponse{Body: problem, StatusCode: problem.Status}, nil //nolint: nilerr
 	}
 
 	resp := CreateTask201JSONResponse{}
-	resp.Task = Task{
-		ID:          id,
-		Description: task.Description,
-	}
 
-	if task.Dates != nil {
-		resp.Task.Dates = new(newDates(*task.Dates))
-	}
+	resp.Task, err = newTask(task)
+	if err != nil {
+		problem := t.newProblem(err, "/tasks")
 
-	if task.Priority != nil {
-		resp.Task.Priority = new(NewPriority(*task.Priority))
+		return CreateTaskdefaultApplicationProblemPlusJSONResponse{Body: problem, StatusCode: problem.Status}, nil //nolint: nilerr
 	}
 
 	return resp, nil
 }
 
 func (t *TaskHandler) DeleteTask(ctx context.Context, request DeleteTaskRequestObject) (DeleteTaskResponseObject, error) {
-	if err := t.svc.Delete(ctx, request.Id.String()); err != nil {
-		resp := DeleteTask500JSONResponse{}
-		resp.Error = err.Error()
+	version, err := parseIfMatch(request.Params.IfMatch)
+	if err != nil {
+		problem := t.newProblem(err, "/tasks/"+request.Id.String())
 
-		return resp, nil //nolint: nilerr
+		return DeleteTaskdefaultApplicationProblemPlusJSONResponse{Body: problem, StatusCode: problem.Status}, nil //nolint: nilerr
 	}
 
-	// TODO: Consider "DeleteTask404Response"
+	if err := t.svc.Delete(ctx, request.Id.String(), internal.DeleteParams{Version: version}); err != nil {
+		problem := t.newProblem(err, "/tasks/"+request.Id.String())
+
+		return DeleteTaskdefaultApplicationProblemPlusJSONResponse{Body: problem, StatusCode: problem.Status}, nil //nolint: nilerr
+	}
 
 	return DeleteTask200Response{}, nil
 }
 
 func (t *TaskHandler) ReadTask(ctx context.Context, request ReadTaskRequestObject) (ReadTaskResponseObject, error) {
 	task, err := t.svc.ByID(ctx, request.Id.String())
-	// TODO: determine if this is a validation error or a different kind of error, and use "CreateTask400JSONResponse"
 	if err != nil {
-		resp := ReadTask500JSONResponse{}
-		resp.Error = err.Error()
+		problem := t.newProblem(err, "/tasks/"+request.Id.String())
 
-		return resp, nil //nolint: nilerr
+		return ReadTaskdefaultApplicationProblemPlusJSONResponse{Body: problem, StatusCode: problem.Status}, nil //nolint: nilerr
 	}
 
-	id, err := uuid.Parse(task.ID)
+	res, err := newTask(task)
 	if err != nil {
-		resp := ReadTask500JSONResponse{}
-		resp.Error = err.Error()
+		problem := t.newProblem(err, "/tasks/"+request.Id.String())
 
-		return resp, nil //nolint: nilerr
+		return ReadTaskdefaultApplicationProblemPlusJSONResponse{Body: problem, StatusCode: problem.Status}, nil //nolint: nilerr
+	}
+
+	etag := newETag(task.Version)
+
+	if matchesIfNoneMatch(request.Params.IfNoneMatch, etag) {
+		return ReadTask304Response{Headers: ReadTask304ResponseHeaders{ETag: &etag}}, nil
 	}
 
 	resp := ReadTask200JSONResponse{}
-	resp.Task = &Task{
-		ID:          id,
-		Description: task.Description,
+	resp.Body.Task = &res
+	resp.Headers.ETag = &etag
+
+	return resp, nil
+}
+
+func (t *TaskHandler) UpdateTask(ctx context.Context, req UpdateTaskRequestObject) (UpdateTaskResponseObject, error) {
+	version, err := parseIfMatch(req.Params.IfMatch)
+	if err != nil {
+		problem := t.newProblem(err, "/tasks/"+req.Id.String())
+
+		return UpdateTaskdefaultApplicationProblemPlusJSONResponse{Body: problem, StatusCode: problem.Status}, nil //nolint: nilerr
 	}
 
-	if task.Dates != nil {
-		resp.Task.Dates = new(newDates(*task.Dates))
+	priority := new(internal.PriorityNone)
+	if req.Body.Priority != nil {
+		priority = req.Body.Priority.ToDomain()
 	}
 
-	if task.Priority != nil {
-		resp.Task.Priority = new(NewPriority(*task.Priority))
+	var dates internal.Dates
+	if req.Body.Dates != nil {
+		dates = req.Body.Dates.ToDomain()
 	}
 
-	return resp, nil
+	var subTasks *[]internal.CreateParams
+	if req.Body.SubTasks != nil {
+		subTasks = new(subTasksToDomain(req.Body.SubTasks))
+	}
+
+	var categories *[]internal.Category
+	if req.Body.Categories != nil {
+		categories = new(categoriesToDomain(req.Body.Categories))
+	}
+
+	// PUT replaces the record, omitted values are set to their defaults.
+	if err := t.svc.Update(ctx, req.Id.String(), internal.UpdateParams{
+		Description: new(internal.PointerToValue(req.Body.Description)),
+		Priority:    priority,
+		Dates: internal.UpdateDates{
+			Start: internal.NewOptional(dates.Start),
+			Due:   internal.NewOptional(dates.Due),
+		},
+		IsDone:     new(internal.PointerToValue(req.Body.IsDone)),
+		SubTasks:   subTasks,
+		Categories: categories,
+		Version:    version,
+	}); err != nil {
+		problem := t.newProblem(err, "/tasks/"+req.Id.String())
+
+		return UpdateTaskdefaultApplicationProblemPlusJSONResponse{Body: problem, StatusCode: problem.Status}, nil //nolint: nilerr
+	}
+
+	return UpdateTask200Response{}, nil
 }
 
-func (t *TaskHandler) UpdateTask(ctx context.Context, req UpdateTaskRequestObject) (UpdateTaskResponseObject, error) {
+func (t *TaskHandler) PatchTask(ctx context.Context, req PatchTaskRequestObject) (PatchTaskResponseObject, error) {
+	version, err := parseIfMatch(req.Params.IfMatch)
+	if err != nil {
+		problem := t.newProblem(err, "/tasks/"+req.Id.String())
+
+		return PatchTaskdefaultApplicationProblemPlusJSONResponse{Body: problem, StatusCode: problem.Status}, nil //nolint: nilerr
+	}
+
 	var priority *internal.Priority
 	if req.Body.Priority != nil {
 		priority = req.Body.Priority.ToDomain()
 	}
 
-	var dates *internal.Dates
-	if req.Body.Dates != nil {
-		dates = new(req.Body.Dates.ToDomain())
+	var subTasks *[]internal.CreateParams
+	if req.Body.SubTasks != nil {
+		subTasks = new(subTasksToDomain(req.Body.SubTasks))
+	}
+
+	var categories *[]internal.Category
+	if req.Body.Categories != nil {
+		categories = new(categoriesToDomain(req.Body.Categories))
 	}
 
 	if err := t.svc.Update(ctx, req.Id.String(), internal.UpdateParams{
 		Description: req.Body.Description,
 		Priority:    priority,
-		Dates:       dates,
+		Dates:       newUpdateDates(req.Body.Dates),
 		IsDone:      req.Body.IsDone,
+		SubTasks:    subTasks,
+		Categories:  categories,
+		Version:     version,
 	}); err != nil {
-		// TODO: determine if this is a validation error or a different kind of error, and use "CreateTask400JSONResponse"
-		return UpdateTask500JSONResponse{Error: err.Error()}, nil //nolint: nilerr
+		problem := t.newProblem(err, "/tasks/"+req.Id.String())
+
+		return PatchTaskdefaultApplicationProblemPlusJSONResponse{Body: problem, StatusCode: problem.Status}, nil //nolint: nilerr
 	}
 
-	return UpdateTask200Response{}, nil
+	return PatchTask200Response{}, nil
 }
 
 func (t *TaskHandler) SearchTask(ctx context.Context, req SearchTaskRequestObject) (SearchTaskResponseObject, error) {
-	var priority *internal.Priority
+	var (
+		priority *internal.Priority
+		cursor   string
+	)
 
 	if req.Body.Priority != nil {
 		priority = req.Body.Priority.ToDomain()
 	}
 
+	if req.Body.Cursor != nil {
+		cursor = *req.Body.Cursor
+	}
+
 	res, err := t.svc.By(ctx, internal.SearchParams{
 		Description: req.Body.Description,
 		Priority:    priority,
 		IsDone:      req.Body.IsDone,
+		StartDate:   req.Body.StartDate.ToDomain(),
+		DueDate:     req.Body.DueDate.ToDomain(),
+		Sort:        req.Body.Sort.ToDomain(),
+		Cursor:      cursor,
 		From:        req.Body.From,
 		Size:        req.Body.Size,
 	})
-	// TODO: determine if this is a validation error or a different kind of error, and use "CreateTask400JSONResponse"
 	if err != nil {
-		return SearchTask500JSONResponse{ //nolint: nilerr
-			Error: err.Error(),
-		}, nil
+		problem := t.newProblem(err, "/tasks/search")
+
+		return SearchTaskdefaultApplicationProblemPlusJSONResponse{Body: problem, StatusCode: problem.Status}, nil //nolint: nilerr
 	}
 
-	tasks := make([]Task, len(res.Tasks))
+	tasks := make([]SearchTaskHit, len(res.Tasks))
 
-	for i, task := range res.Tasks { //nolint: varnamelen
-		id, err := uuid.Parse(task.ID)
+	for i, task := range res.Tasks {
+		tasks[i], err = newSearchTaskHit(task, res.Highlights[task.ID])
 		if err != nil {
-			return SearchTask500JSONResponse{ //nolint: nilerr
-				Error: err.Error(),
-			}, nil
+			problem := t.newProblem(err, "/tasks/search")
+
+			return SearchTaskdefaultApplicationProblemPlusJSONResponse{Body: problem, StatusCode: problem.Status}, nil //nolint: nilerr
 		}
+	}
+
+	resp := SearchTask200JSONResponse{}
+	resp.Tasks = &tasks
+	resp.Total = &res.Total
+	resp.Facets = new(NewSearchFacets(res.Facets))
+	resp.Degraded = &res.Degraded
 
-		tasks[i].ID = id
-		tasks[i].Description = task.Description
+	if res.Cursor != "" {
+		resp.Cursor = &res.Cursor
+	}
 
-		if task.Priority != nil {
-			tasks[i].Priority = new(NewPriority(*task.Priority))
-		}
+	return resp, nil
+}
 
-		if task.Dates != nil {
-			tasks[i].Dates = new(newDates(*task.Dates))
-		}
+func (t *TaskHandler) SuggestTask(ctx context.Context, req SuggestTaskRequestObject) (SuggestTaskResponseObject, error) {
+	size := int64(5)
 
-		tasks[i].IsDone = &task.IsDone
+	if req.Params.Size != nil {
+		size = *req.Params.Size
 	}
 
-	resp := SearchTask200JSONResponse{}
-	resp.Tasks = &tasks
+	res, err := t.svc.Suggest(ctx, internal.SuggestParams{
+		Prefix: req.Params.Q,
+		Size:   size,
+	})
+	if err != nil {
+		problem := t.newProblem(err, "/tasks/suggest")
+
+		return SuggestTaskdefaultApplicationProblemPlusJSONResponse{Body: problem, StatusCode: problem.Status}, nil //nolint: nilerr
+	}
+
+	resp := SuggestTask200JSONResponse{}
+	resp.Suggestions = res
 
 	return resp, nil
 }
+
+// newProblem returns the problem details describing the error, unexpected errors are logged because their
+// details are not included in the response.
+func (t *TaskHandler) newProblem(err error, instance string) Problem {
+	problem := newProblem(err, instance)
+
+	if problem.Status >= http.StatusInternalServerError {
+		t.logger.Error("request failed", zap.String("instance", instance), zap.Error(err))
+	}
+
+	return problem
+}
@@ -2,9 +2,14 @@ package rest_test
 
 import (
 	"errors"
+	"net/http"
 	"testing"
+	"time"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/google/uuid"
+	"github.com/oapi-codegen/nullable"
+	"go.uber.org/zap"
 
 	"github.com/MarioCarrion/todo-api-microservice-example/internal"
 	"github.com/MarioCarrion/todo-api-microservice-example/internal/rest"
@@ -15,6 +20,7 @@ func TestTaskHandler_CreateTask(t *testing.T) {
 	t.Parallel()
 
 	taskID := uuid.New()
+	subTaskID := uuid.New()
 
 	tests := []struct {
 		name         string
@@ -54,6 +60,48 @@ func TestTaskHandler_CreateTask(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "successful creation with sub-tasks",
+			request: rest.CreateTaskRequestObject{
+				Body: &rest.CreateTaskJSONRequestBody{
+					Description: "test task",
+					Categories:  &[]rest.Category{"work"},
+					SubTasks: &[]rest.NewSubTask{
+						{Description: "sub task"},
+					},
+				},
+			},
+			setupMock: func(m *resttesting.FakeTaskService) {
+				m.CreateReturns(internal.Task{
+					ID:          taskID.String(),
+					Description: "test task",
+					Categories:  []internal.Category{"work"},
+					SubTasks: []internal.Task{
+						{
+							ID:          subTaskID.String(),
+							Description: "sub task",
+						},
+					},
+				}, nil)
+			},
+			expectError: false,
+			validateResp: func(t *testing.T, resp rest.CreateTaskResponseObject) {
+				t.Helper()
+
+				r, ok := resp.(rest.CreateTask201JSONResponse)
+				if !ok {
+					t.Fatalf("expected CreateTask201JSONResponse, got %T", resp)
+				}
+
+				if r.Task.SubTasks == nil || len(*r.Task.SubTasks) != 1 || (*r.Task.SubTasks)[0].ID != subTaskID {
+					t.Errorf("expected sub task ID %v, got %v", subTaskID, r.Task.SubTasks)
+				}
+
+				if r.Task.Categories == nil || len(*r.Task.Categories) != 1 || (*r.Task.Categories)[0] != "work" {
+					t.Errorf("expected categories [work], got %v", r.Task.Categories)
+				}
+			},
+		},
 		{
 			name: "service error",
 			request: rest.CreateTaskRequestObject{
@@ -68,9 +116,37 @@ func TestTaskHandler_CreateTask(t *testing.T) {
 			validateResp: func(t *testing.T, resp rest.CreateTaskResponseObject) {
 				t.Helper()
 
-				_, ok := resp.(rest.CreateTask500JSONResponse)
+				r, ok := resp.(rest.CreateTaskdefaultApplicationProblemPlusJSONResponse)
+				if !ok {
+					t.Fatalf("expected CreateTaskdefaultApplicationProblemPlusJSONResponse, got %T", resp)
+				}
+
+				if r.StatusCode != http.StatusInternalServerError {
+					t.Errorf("expected status code %d, got %d", http.StatusInternalServerError, r.StatusCode)
+				}
+			},
+		},
+		{
+			name: "validation error",
+			request: rest.CreateTaskRequestObject{
+				Body: &rest.CreateTaskJSONRequestBody{},
+			},
+			setupMock: func(m *resttesting.FakeTaskService) {
+				m.CreateReturns(internal.Task{},
+					internal.WrapErrorf(internal.NewErrorf(internal.ErrorCodeInvalidArgument, "invalid"),
+						internal.ErrorCodeUnknown, "svc.Create"))
+			},
+			expectError: false,
+			validateResp: func(t *testing.T, resp rest.CreateTaskResponseObject) {
+				t.Helper()
+
+				r, ok := resp.(rest.CreateTaskdefaultApplicationProblemPlusJSONResponse)
 				if !ok {
-					t.Fatalf("expected CreateTask500JSONResponse, got %T", resp)
+					t.Fatalf("expected CreateTaskdefaultApplicationProblemPlusJSONResponse, got %T", resp)
+				}
+
+				if r.StatusCode != http.StatusBadRequest {
+					t.Errorf("expected status code %d, got %d", http.StatusBadRequest, r.StatusCode)
 				}
 			},
 		},
@@ -82,7 +158,7 @@ func TestTaskHandler_CreateTask(t *testing.T) {
 
 			mockService := &resttesting.FakeTaskService{}
 			tt.setupMock(mockService)
-			handler := rest.NewTaskHandler(mockService)
+			handler := rest.NewTaskHandler(mockService, zap.NewNop())
 			resp, err := handler.CreateTask(t.Context(), tt.request)
 
 			if tt.expectError && err == nil {
@@ -121,6 +197,7 @@ func TestTaskHandler_ReadTask(t *testing.T) {
 				m.ByIDReturns(internal.Task{
 					ID:          taskID.String(),
 					Description: "test task",
+					Version:     3,
 				}, nil)
 			},
 			expectError: false,
@@ -132,8 +209,41 @@ func TestTaskHandler_ReadTask(t *testing.T) {
 					t.Fatalf("expected ReadTask200JSONResponse, got %T", resp)
 				}
 
-				if r.Task.ID != taskID {
-					t.Errorf("expected task ID %v, got %v", taskID, r.Task.ID)
+				if r.Body.Task.ID != taskID {
+					t.Errorf("expected task ID %v, got %v", taskID, r.Body.Task.ID)
+				}
+
+				if r.Headers.ETag == nil || *r.Headers.ETag != `"3"` {
+					t.Errorf("expected ETag %q, got %v", `"3"`, r.Headers.ETag)
+				}
+			},
+		},
+		{
+			name: "not modified",
+			request: rest.ReadTaskRequestObject{
+				Id: taskID,
+				Params: rest.ReadTaskParams{
+					IfNoneMatch: new(`"2", W/"3"`),
+				},
+			},
+			setupMock: func(m *resttesting.FakeTaskService) {
+				m.ByIDReturns(internal.Task{
+					ID:          taskID.String(),
+					Description: "test task",
+					Version:     3,
+				}, nil)
+			},
+			expectError: false,
+			validateResp: func(t *testing.T, resp rest.ReadTaskResponseObject) {
+				t.Helper()
+
+				r, ok := resp.(rest.ReadTask304Response)
+				if !ok {
+					t.Fatalf("expected ReadTask304Response, got %T", resp)
+				}
+
+				if r.Headers.ETag == nil || *r.Headers.ETag != `"3"` {
+					t.Errorf("expected ETag %q, got %v", `"3"`, r.Headers.ETag)
 				}
 			},
 		},
@@ -149,10 +259,37 @@ func TestTaskHandler_ReadTask(t *testing.T) {
 			validateResp: func(t *testing.T, resp rest.ReadTaskResponseObject) {
 				t.Helper()
 
-				_, ok := resp.(rest.ReadTask500JSONResponse)
+				r, ok := resp.(rest.ReadTaskdefaultApplicationProblemPlusJSONResponse)
+				if !ok {
+					t.Fatalf("expected ReadTaskdefaultApplicationProblemPlusJSONResponse, got %T", resp)
+				}
+
+				if r.StatusCode != http.StatusInternalServerError {
+					t.Errorf("expected status code %d, got %d", http.StatusInternalServerError, r.StatusCode)
+				}
+			},
+		},
+		{
+			name: "not found",
+			request: rest.ReadTaskRequestObject{
+				Id: taskID,
+			},
+			setupMock: func(m *resttesting.FakeTaskService) {
+				m.ByIDReturns(internal.Task{},
+					internal.WrapErrorf(internal.NewErrorf(internal.ErrorCodeNotFound, "not found"),
+						internal.ErrorCodeUnknown, "Find"))
+			},
+			expectError: false,
+			validateResp: func(t *testing.T, resp rest.ReadTaskResponseObject) {
+				t.Helper()
 
+				r, ok := resp.(rest.ReadTaskdefaultApplicationProblemPlusJSONResponse)
 				if !ok {
-					t.Fatalf("expected ReadTask500JSONResponse, got %T", resp)
+					t.Fatalf("expected ReadTaskdefaultApplicationProblemPlusJSONResponse, got %T", resp)
+				}
+
+				if r.StatusCode != http.StatusNotFound {
+					t.Errorf("expected status code %d, got %d", http.StatusNotFound, r.StatusCode)
 				}
 			},
 		},
@@ -164,7 +301,7 @@ func TestTaskHandler_ReadTask(t *testing.T) {
 
 			mockService := &resttesting.FakeTaskService{}
 			tt.setupMock(mockService)
-			handler := rest.NewTaskHandler(mockService)
+			handler := rest.NewTaskHandler(mockService, zap.NewNop())
 			resp, err := handler.ReadTask(t.Context(), tt.request)
 
 			if tt.expectError && err == nil {
@@ -224,9 +361,35 @@ func TestTaskHandler_DeleteTask(t *testing.T) {
 			validateResp: func(t *testing.T, resp rest.DeleteTaskResponseObject) {
 				t.Helper()
 
-				_, ok := resp.(rest.DeleteTask500JSONResponse)
+				r, ok := resp.(rest.DeleteTaskdefaultApplicationProblemPlusJSONResponse)
 				if !ok {
-					t.Fatalf("expected DeleteTask500JSONResponse, got %T", resp)
+					t.Fatalf("expected DeleteTaskdefaultApplicationProblemPlusJSONResponse, got %T", resp)
+				}
+
+				if r.StatusCode != http.StatusInternalServerError {
+					t.Errorf("expected status code %d, got %d", http.StatusInternalServerError, r.StatusCode)
+				}
+			},
+		},
+		{
+			name: "not found",
+			request: rest.DeleteTaskRequestObject{
+				Id: taskID,
+			},
+			setupMock: func(m *resttesting.FakeTaskService) {
+				m.DeleteReturns(internal.NewErrorf(internal.ErrorCodeNotFound, "not found"))
+			},
+			expectError: false,
+			validateResp: func(t *testing.T, resp rest.DeleteTaskResponseObject) {
+				t.Helper()
+
+				r, ok := resp.(rest.DeleteTaskdefaultApplicationProblemPlusJSONResponse)
+				if !ok {
+					t.Fatalf("expected DeleteTaskdefaultApplicationProblemPlusJSONResponse, got %T", resp)
+				}
+
+				if r.StatusCode != http.StatusNotFound {
+					t.Errorf("expected status code %d, got %d", http.StatusNotFound, r.StatusCode)
 				}
 			},
 		},
@@ -238,7 +401,7 @@ func TestTaskHandler_DeleteTask(t *testing.T) {
 
 			mockService := &resttesting.FakeTaskService{}
 			tt.setupMock(mockService)
-			handler := rest.NewTaskHandler(mockService)
+			handler := rest.NewTaskHandler(mockService, zap.NewNop())
 			resp, err := handler.DeleteTask(t.Context(), tt.request)
 
 			if tt.expectError && err == nil {
@@ -304,9 +467,66 @@ func TestTaskHandler_UpdateTask(t *testing.T) {
 			validateResp: func(t *testing.T, resp rest.UpdateTaskResponseObject) {
 				t.Helper()
 
-				_, ok := resp.(rest.UpdateTask500JSONResponse)
+				r, ok := resp.(rest.UpdateTaskdefaultApplicationProblemPlusJSONResponse)
+				if !ok {
+					t.Fatalf("expected UpdateTaskdefaultApplicationProblemPlusJSONResponse, got %T", resp)
+				}
+
+				if r.StatusCode != http.StatusInternalServerError {
+					t.Errorf("expected status code %d, got %d", http.StatusInternalServerError, r.StatusCode)
+				}
+			},
+		},
+		{
+			name: "precondition failed",
+			request: rest.UpdateTaskRequestObject{
+				Id: taskID,
+				Params: rest.UpdateTaskParams{
+					IfMatch: new(`"2"`),
+				},
+				Body: &rest.UpdateTaskJSONRequestBody{
+					Description: new("updated task"),
+				},
+			},
+			setupMock: func(m *resttesting.FakeTaskService) {
+				m.UpdateReturns(internal.NewErrorf(internal.ErrorCodePreconditionFailed, "version does not match"))
+			},
+			expectError: false,
+			validateResp: func(t *testing.T, resp rest.UpdateTaskResponseObject) {
+				t.Helper()
+
+				r, ok := resp.(rest.UpdateTaskdefaultApplicationProblemPlusJSONResponse)
+				if !ok {
+					t.Fatalf("expected UpdateTaskdefaultApplicationProblemPlusJSONResponse, got %T", resp)
+				}
+
+				if r.StatusCode != http.StatusPreconditionFailed {
+					t.Errorf("expected status code %d, got %d", http.StatusPreconditionFailed, r.StatusCode)
+				}
+			},
+		},
+		{
+			name: "conflict",
+			request: rest.UpdateTaskRequestObject{
+				Id: taskID,
+				Body: &rest.UpdateTaskJSONRequestBody{
+					Description: new("updated task"),
+				},
+			},
+			setupMock: func(m *resttesting.FakeTaskService) {
+				m.UpdateReturns(internal.NewErrorf(internal.ErrorCodeConflict, "conflict"))
+			},
+			expectError: false,
+			validateResp: func(t *testing.T, resp rest.UpdateTaskResponseObject) {
+				t.Helper()
+
+				r, ok := resp.(rest.UpdateTaskdefaultApplicationProblemPlusJSONResponse)
 				if !ok {
-					t.Fatalf("expected UpdateTask500JSONResponse, got %T", resp)
+					t.Fatalf("expected UpdateTaskdefaultApplicationProblemPlusJSONResponse, got %T", resp)
+				}
+
+				if r.StatusCode != http.StatusConflict {
+					t.Errorf("expected status code %d, got %d", http.StatusConflict, r.StatusCode)
 				}
 			},
 		},
@@ -318,7 +538,7 @@ func TestTaskHandler_UpdateTask(t *testing.T) {
 
 			mockService := &resttesting.FakeTaskService{}
 			tt.setupMock(mockService)
-			handler := rest.NewTaskHandler(mockService)
+			handler := rest.NewTaskHandler(mockService, zap.NewNop())
 			resp, err := handler.UpdateTask(t.Context(), tt.request)
 
 			if tt.expectError && err == nil {
@@ -336,6 +556,103 @@ func TestTaskHandler_UpdateTask(t *testing.T) {
 	}
 }
 
+func TestTaskHandler_PatchTask(t *testing.T) {
+	t.Parallel()
+
+	taskID := uuid.New()
+
+	tests := []struct {
+		name         string
+		request      rest.PatchTaskRequestObject
+		setupMock    func(*resttesting.FakeTaskService)
+		expectParams internal.UpdateParams
+		validateResp func(t *testing.T, resp rest.PatchTaskResponseObject)
+	}{
+		{
+			name: "successful patch",
+			request: rest.PatchTaskRequestObject{
+				Id: taskID,
+				Body: &rest.PatchTaskApplicationMergePatchPlusJSONRequestBody{
+					IsDone: new(true),
+					Dates: nullable.NewNullableWithValue(rest.DatesPatch{
+						Due: nullable.NewNullNullable[time.Time](),
+					}),
+				},
+			},
+			setupMock: func(m *resttesting.FakeTaskService) {
+				m.UpdateReturns(nil)
+			},
+			expectParams: internal.UpdateParams{
+				IsDone: new(true),
+				Dates: internal.UpdateDates{
+					Due: internal.NewOptional[time.Time](nil),
+				},
+			},
+			validateResp: func(t *testing.T, resp rest.PatchTaskResponseObject) {
+				t.Helper()
+
+				_, ok := resp.(rest.PatchTask200Response)
+				if !ok {
+					t.Fatalf("expected PatchTask200Response, got %T", resp)
+				}
+			},
+		},
+		{
+			name: "not found",
+			request: rest.PatchTaskRequestObject{
+				Id: taskID,
+				Body: &rest.PatchTaskApplicationMergePatchPlusJSONRequestBody{
+					Description: new("patched task"),
+				},
+			},
+			setupMock: func(m *resttesting.FakeTaskService) {
+				m.UpdateReturns(internal.NewErrorf(internal.ErrorCodeNotFound, "not found"))
+			},
+			expectParams: internal.UpdateParams{
+				Description: new("patched task"),
+			},
+			validateResp: func(t *testing.T, resp rest.PatchTaskResponseObject) {
+				t.Helper()
+
+				r, ok := resp.(rest.PatchTaskdefaultApplicationProblemPlusJSONResponse)
+				if !ok {
+					t.Fatalf("expected PatchTaskdefaultApplicationProblemPlusJSONResponse, got %T", resp)
+				}
+
+				if r.StatusCode != http.StatusNotFound {
+					t.Errorf("expected status code %d, got %d", http.StatusNotFound, r.StatusCode)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockService := &resttesting.FakeTaskService{}
+			tt.setupMock(mockService)
+			handler := rest.NewTaskHandler(mockService, zap.NewNop())
+
+			resp, err := handler.PatchTask(t.Context(), tt.request)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			_, id, params := mockService.UpdateArgsForCall(0)
+			if id != taskID.String() {
+				t.Fatalf("expected id %s, got %s", taskID, id)
+			}
+
+			if diff := cmp.Diff(tt.expectParams, params); diff != "" {
+				t.Fatalf("expected params do not match: %s", diff)
+			}
+
+			tt.validateResp(t, resp)
+		})
+	}
+}
+
 func TestTaskHandler_SearchTask(t *testing.T) {
 	t.Parallel()
 
@@ -365,6 +682,9 @@ func TestTaskHandler_SearchTask(t *testing.T) {
 						{ID: taskID2.String(), Description: "test task 2"},
 					},
 					Total: 2,
+					Highlights: map[string][]string{
+						taskID1.String(): {"<em>test</em> task 1"},
+					},
 				}, nil)
 			},
 			expectError: false,
@@ -377,7 +697,116 @@ func TestTaskHandler_SearchTask(t *testing.T) {
 				}
 
 				if r.Tasks == nil || len(*r.Tasks) != 2 {
-					t.Errorf("expected 2 tasks, got %v", r.Tasks)
+					t.Fatalf("expected 2 tasks, got %v", r.Tasks)
+				}
+
+				expected := &rest.SearchHighlights{Description: &[]string{"<em>test</em> task 1"}}
+
+				if tasks := *r.Tasks; !cmp.Equal(expected, tasks[0].Highlights) || tasks[1].Highlights != nil {
+					t.Errorf("expected highlights do not match: %s", cmp.Diff(expected, tasks[0].Highlights))
+				}
+			},
+		},
+		{
+			name: "successful search with ranges and facets",
+			request: rest.SearchTaskRequestObject{
+				Body: &rest.SearchTaskJSONRequestBody{
+					IsDone:  new(false),
+					DueDate: &rest.DateRange{To: new(time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC))},
+					Sort:    new(rest.SearchSortDueDate),
+					Size:    10,
+				},
+			},
+			setupMock: func(m *resttesting.FakeTaskService) {
+				m.ByReturns(internal.SearchResults{
+					Tasks: []internal.Task{{ID: taskID1.String(), Description: "overdue"}},
+					Total: 1,
+					Facets: internal.SearchFacets{
+						Priorities: map[internal.Priority]int64{internal.PriorityHigh: 1},
+						NotDone:    1,
+					},
+				}, nil)
+			},
+			expectError: false,
+			validateResp: func(t *testing.T, resp rest.SearchTaskResponseObject) {
+				t.Helper()
+
+				r, ok := resp.(rest.SearchTask200JSONResponse)
+				if !ok {
+					t.Fatalf("expected SearchTask200JSONResponse, got %T", resp)
+				}
+
+				expected := rest.SearchFacets{Priority: map[string]int64{"high": 1}, NotDone: 1}
+
+				if r.Facets == nil || !cmp.Equal(expected, *r.Facets) {
+					t.Errorf("expected facets do not match: %s", cmp.Diff(&expected, r.Facets))
+				}
+
+				if r.Total == nil || *r.Total != 1 {
+					t.Errorf("expected total 1, got %v", r.Total)
+				}
+
+				if r.Cursor != nil {
+					t.Errorf("expected no cursor, got %s", *r.Cursor)
+				}
+			},
+		},
+		{
+			name: "successful search with cursor",
+			request: rest.SearchTaskRequestObject{
+				Body: &rest.SearchTaskJSONRequestBody{
+					Description: new("test"),
+					Cursor:      new("WzEuMCwiMSJd"),
+					Size:        1,
+				},
+			},
+			setupMock: func(m *resttesting.FakeTaskService) {
+				m.ByReturns(internal.SearchResults{
+					Tasks:  []internal.Task{{ID: taskID1.String(), Description: "test"}},
+					Total:  3,
+					Cursor: "WzEuMCwiMiJd",
+				}, nil)
+			},
+			expectError: false,
+			validateResp: func(t *testing.T, resp rest.SearchTaskResponseObject) {
+				t.Helper()
+
+				r, ok := resp.(rest.SearchTask200JSONResponse)
+				if !ok {
+					t.Fatalf("expected SearchTask200JSONResponse, got %T", resp)
+				}
+
+				if r.Cursor == nil || *r.Cursor != "WzEuMCwiMiJd" {
+					t.Errorf("expected next cursor, got %v", r.Cursor)
+				}
+			},
+		},
+		{
+			name: "successful degraded search",
+			request: rest.SearchTaskRequestObject{
+				Body: &rest.SearchTaskJSONRequestBody{
+					Description: new("test"),
+					Size:        10,
+				},
+			},
+			setupMock: func(m *resttesting.FakeTaskService) {
+				m.ByReturns(internal.SearchResults{
+					Tasks:    []internal.Task{{ID: taskID1.String(), Description: "test"}},
+					Total:    1,
+					Degraded: true,
+				}, nil)
+			},
+			expectError: false,
+			validateResp: func(t *testing.T, resp rest.SearchTaskResponseObject) {
+				t.Helper()
+
+				r, ok := resp.(rest.SearchTask200JSONResponse)
+				if !ok {
+					t.Fatalf("expected SearchTask200JSONResponse, got %T", resp)
+				}
+
+				if r.Degraded == nil || !*r.Degraded {
+					t.Errorf("expected degraded results, got %v", r.Degraded)
 				}
 			},
 		},
@@ -395,9 +824,37 @@ func TestTaskHandler_SearchTask(t *testing.T) {
 			validateResp: func(t *testing.T, resp rest.SearchTaskResponseObject) {
 				t.Helper()
 
-				_, ok := resp.(rest.SearchTask500JSONResponse)
+				r, ok := resp.(rest.SearchTaskdefaultApplicationProblemPlusJSONResponse)
 				if !ok {
-					t.Fatalf("expected SearchTask500JSONResponse, got %T", resp)
+					t.Fatalf("expected SearchTaskdefaultApplicationProblemPlusJSONResponse, got %T", resp)
+				}
+
+				if r.StatusCode != http.StatusInternalServerError {
+					t.Errorf("expected status code %d, got %d", http.StatusInternalServerError, r.StatusCode)
+				}
+			},
+		},
+		{
+			name: "unavailable",
+			request: rest.SearchTaskRequestObject{
+				Body: &rest.SearchTaskJSONRequestBody{
+					Description: new("test"),
+				},
+			},
+			setupMock: func(m *resttesting.FakeTaskService) {
+				m.ByReturns(internal.SearchResults{}, internal.NewErrorf(internal.ErrorCodeUnavailable, "unavailable"))
+			},
+			expectError: false,
+			validateResp: func(t *testing.T, resp rest.SearchTaskResponseObject) {
+				t.Helper()
+
+				r, ok := resp.(rest.SearchTaskdefaultApplicationProblemPlusJSONResponse)
+				if !ok {
+					t.Fatalf("expected SearchTaskdefaultApplicationProblemPlusJSONResponse, got %T", resp)
+				}
+
+				if r.StatusCode != http.StatusServiceUnavailable {
+					t.Errorf("expected status code %d, got %d", http.StatusServiceUnavailable, r.StatusCode)
 				}
 			},
 		},
@@ -409,7 +866,7 @@ func TestTaskHandler_SearchTask(t *testing.T) {
 
 			mockService := &resttesting.FakeTaskService{}
 			tt.setupMock(mockService)
-			handler := rest.NewTaskHandler(mockService)
+			handler := rest.NewTaskHandler(mockService, zap.NewNop())
 			resp, err := handler.SearchTask(t.Context(), tt.request)
 
 			if tt.expectError && err == nil {
@@ -426,3 +883,71 @@ func TestTaskHandler_SearchTask(t *testing.T) {
 		})
 	}
 }
+
+func TestTaskHandler_SuggestTask(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		request        rest.SuggestTaskRequestObject
+		setupMock      func(*resttesting.FakeTaskService)
+		expectedParams internal.SuggestParams
+		expectedStatus int
+		expected       []string
+	}{
+		{
+			name: "successful suggest",
+			request: rest.SuggestTaskRequestObject{
+				Params: rest.SuggestTaskParams{Q: "buy mi"},
+			},
+			setupMock: func(m *resttesting.FakeTaskService) {
+				m.SuggestReturns([]string{"buy milk", "buy minced meat"}, nil)
+			},
+			expectedParams: internal.SuggestParams{Prefix: "buy mi", Size: 5},
+			expectedStatus: http.StatusOK,
+			expected:       []string{"buy milk", "buy minced meat"},
+		},
+		{
+			name: "invalid arguments",
+			request: rest.SuggestTaskRequestObject{
+				Params: rest.SuggestTaskParams{Q: "buy", Size: new(int64(50))},
+			},
+			setupMock: func(m *resttesting.FakeTaskService) {
+				m.SuggestReturns(nil, internal.NewErrorf(internal.ErrorCodeInvalidArgument, "invalid"))
+			},
+			expectedParams: internal.SuggestParams{Prefix: "buy", Size: 50},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockService := &resttesting.FakeTaskService{}
+			tt.setupMock(mockService)
+
+			resp, err := rest.NewTaskHandler(mockService, zap.NewNop()).SuggestTask(t.Context(), tt.request)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if _, actual := mockService.SuggestArgsForCall(0); actual != tt.expectedParams {
+				t.Errorf("expected params %v, got %v", tt.expectedParams, actual)
+			}
+
+			switch r := resp.(type) {
+			case rest.SuggestTask200JSONResponse:
+				if tt.expectedStatus != http.StatusOK || !cmp.Equal(tt.expected, r.Suggestions) {
+					t.Errorf("expected suggestions do not match: %s", cmp.Diff(tt.expected, r.Suggestions))
+				}
+			case rest.SuggestTaskdefaultApplicationProblemPlusJSONResponse:
+				if r.StatusCode != tt.expectedStatus {
+					t.Errorf("expected status code %d, got %d", tt.expectedStatus, r.StatusCode)
+				}
+			default:
+				t.Fatalf("unexpected response %T", resp)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package service
+
+import (
+	"context"
+
+	"github.com/MarioCarrion/todo-api-microservice-example/internal"
+)
+
+//counterfeiter:generate -o servicetesting/task_index_repository.gen.go . TaskIndexRepository
+
+// TaskIndexRepository defines the datastore handling indexing Task records.
+//
+// Records are versioned, changes with a version older than or equal to the indexed one fail with the code
+// internal.ErrorCodeConflict.
+type TaskIndexRepository interface {
+	Delete(ctx context.Context, id string, version int64) error
+	Index(ctx context.Context, task internal.Task) error
+}
+
+// Indexer defines the application service in charge of indexing the Tasks received as events.
+type Indexer struct {
+	repo TaskIndexRepository
+}
+
+// NewIndexer ...
+func NewIndexer(repo TaskIndexRepository) *Indexer {
+	return &Indexer{
+		repo: repo,
+	}
+}
+
+// Handle indexes the Task included in the event, deleting Tasks that are not indexed is not an error. Events older
+// than the indexed Task are ignored, this happens when they are redelivered or received out of order.
+func (i *Indexer) Handle(ctx context.Context, event internal.Event) error {
+	switch event.Type {
+	case internal.EventTypeTaskCreated, internal.EventTypeTaskUpdated:
+		if err := i.repo.Index(ctx, event.Task); err != nil {
+			if internal.HasCode(err, internal.ErrorCodeConflict) {
+				return nil
+			}
+
+			return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "repo.Index")
+		}
+
+		return nil
+	case internal.EventTypeTaskDeleted:
+		if err := i.repo.Delete(ctx, event.Task.ID, event.Version()); err != nil {
+			if internal.HasCode(err, internal.ErrorCodeNotFound) || internal.HasCode(err, internal.ErrorCodeConflict) {
+				return nil
+			}
+
+			return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "repo.Delete")
+		}
+
+		return nil
+	}
+
+	return internal.NewErrorf(internal.ErrorCodeInvalidArgument, "unknown event type: %s", event.Type)
+}
@@ -0,0 +1,138 @@
+package service_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/MarioCarrion/todo-api-microservice-example/internal"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/service"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/service/servicetesting"
+)
+
+func TestIndexer_Handle(t *testing.T) {
+	t.Parallel()
+
+	task := internal.Task{
+		ID:          "1c7f0a3e-a5b4-4c4f-9f0c-6a0a9b3f0e8d",
+		Description: "test",
+		Version:     2,
+	}
+
+	tests := []struct {
+		name      string
+		setup     func(*servicetesting.FakeTaskIndexRepository)
+		event     internal.Event
+		expectErr bool
+		verify    func(*testing.T, *servicetesting.FakeTaskIndexRepository)
+	}{
+		{
+			name:  "OK: created",
+			setup: func(_ *servicetesting.FakeTaskIndexRepository) {},
+			event: internal.Event{Type: internal.EventTypeTaskCreated, Task: task},
+			verify: func(t *testing.T, repo *servicetesting.FakeTaskIndexRepository) {
+				t.Helper()
+
+				if _, actual := repo.IndexArgsForCall(0); !cmp.Equal(task, actual) {
+					t.Fatalf("expected indexed task does not match: %s", cmp.Diff(task, actual))
+				}
+			},
+		},
+		{
+			name:  "OK: updated",
+			setup: func(_ *servicetesting.FakeTaskIndexRepository) {},
+			event: internal.Event{Type: internal.EventTypeTaskUpdated, Task: task},
+			verify: func(t *testing.T, repo *servicetesting.FakeTaskIndexRepository) {
+				t.Helper()
+
+				if count := repo.IndexCallCount(); count != 1 {
+					t.Fatalf("expected 1 indexed task, got %d", count)
+				}
+			},
+		},
+		{
+			name:  "OK: deleted",
+			setup: func(_ *servicetesting.FakeTaskIndexRepository) {},
+			event: internal.Event{Type: internal.EventTypeTaskDeleted, Task: task},
+			verify: func(t *testing.T, repo *servicetesting.FakeTaskIndexRepository) {
+				t.Helper()
+
+				if _, id, version := repo.DeleteArgsForCall(0); id != task.ID || version != task.Version+1 {
+					t.Fatalf("expected deleted id %s with version %d, got %s with %d", task.ID, task.Version+1, id, version)
+				}
+			},
+		},
+		{
+			name: "OK: deleted not found",
+			setup: func(repo *servicetesting.FakeTaskIndexRepository) {
+				repo.DeleteReturns(internal.NewErrorf(internal.ErrorCodeNotFound, "not found"))
+			},
+			event:  internal.Event{Type: internal.EventTypeTaskDeleted, Task: task},
+			verify: func(_ *testing.T, _ *servicetesting.FakeTaskIndexRepository) {},
+		},
+		{
+			name: "OK: deleted conflict",
+			setup: func(repo *servicetesting.FakeTaskIndexRepository) {
+				repo.DeleteReturns(internal.NewErrorf(internal.ErrorCodeConflict, "version conflict"))
+			},
+			event:  internal.Event{Type: internal.EventTypeTaskDeleted, Task: task},
+			verify: func(_ *testing.T, _ *servicetesting.FakeTaskIndexRepository) {},
+		},
+		{
+			name: "OK: index conflict",
+			setup: func(repo *servicetesting.FakeTaskIndexRepository) {
+				repo.IndexReturns(internal.NewErrorf(internal.ErrorCodeConflict, "version conflict"))
+			},
+			event:  internal.Event{Type: internal.EventTypeTaskUpdated, Task: task},
+			verify: func(_ *testing.T, _ *servicetesting.FakeTaskIndexRepository) {},
+		},
+		{
+			name: "ERR: index",
+			setup: func(repo *servicetesting.FakeTaskIndexRepository) {
+				repo.IndexReturns(errors.New("failed"))
+			},
+			event:     internal.Event{Type: internal.EventTypeTaskCreated, Task: task},
+			expectErr: true,
+			verify:    func(_ *testing.T, _ *servicetesting.FakeTaskIndexRepository) {},
+		},
+		{
+			name: "ERR: delete",
+			setup: func(repo *servicetesting.FakeTaskIndexRepository) {
+				repo.DeleteReturns(errors.New("failed"))
+			},
+			event:     internal.Event{Type: internal.EventTypeTaskDeleted, Task: task},
+			expectErr: true,
+			verify:    func(_ *testing.T, _ *servicetesting.FakeTaskIndexRepository) {},
+		},
+		{
+			name:      "ERR: unknown type",
+			setup:     func(_ *servicetesting.FakeTaskIndexRepository) {},
+			event:     internal.Event{Type: "Task.Unknown", Task: task},
+			expectErr: true,
+			verify: func(t *testing.T, repo *servicetesting.FakeTaskIndexRepository) {
+				t.Helper()
+
+				if count := repo.IndexCallCount() + repo.DeleteCallCount(); count != 0 {
+					t.Fatalf("expected no calls, got %d", count)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := &servicetesting.FakeTaskIndexRepository{}
+			tt.setup(repo)
+
+			err := service.NewIndexer(repo).Handle(t.Context(), tt.event)
+			if (err != nil) != tt.expectErr {
+				t.Fatalf("expected error %t, got %v", tt.expectErr, err)
+			}
+
+			tt.verify(t, repo)
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/MarioCarrion/todo-api-microservice-example/internal"
+)
+
+const (
+	outboxBatchSize     = 100
+	outboxInterval      = time.Second
+	outboxLease         = 30 * time.Second
+	outboxBackoffMin    = time.Second
+	outboxBackoffMax    = 5 * time.Minute
+	outboxBackoffFactor = 2
+	outboxMaxAttempts   = 20
+	outboxRetention     = 24 * time.Hour
+	outboxCleanupEvery  = time.Hour
+)
+
+//counterfeiter:generate -o servicetesting/outbox_repository.gen.go . OutboxRepository
+
+// OutboxRepository defines the datastore handling the Task events pending to be published.
+type OutboxRepository interface {
+	Claim(ctx context.Context, limit int32, lease time.Duration) ([]internal.Event, error)
+	Delivered(ctx context.Context, id string) error
+	Failed(ctx context.Context, id string, retryIn time.Duration) error
+	Dead(ctx context.Context, id string) error
+	DeleteDelivered(ctx context.Context, retention time.Duration) (int64, error)
+}
+
+// OutboxRelay defines the application service in charge of publishing the Task events stored in the outbox.
+type OutboxRelay struct {
+	logger    *zap.Logger
+	repo      OutboxRepository
+	msgBroker TaskMessageBrokerPublisher
+}
+
+// NewOutboxRelay ...
+func NewOutboxRelay(logger *zap.Logger, repo OutboxRepository, msgBroker TaskMessageBrokerPublisher) *OutboxRelay {
+	return &OutboxRelay{
+		logger:    logger,
+		repo:      repo,
+		msgBroker: msgBroker,
+	}
+}
+
+// Run relays the pending events periodically until the context is canceled, delivered events are deleted once they
+// are older than the retention period.
+func (o *OutboxRelay) Run(ctx context.Context) {
+	ticker := time.NewTicker(outboxInterval)
+	defer ticker.Stop()
+
+	var cleanedAt time.Time
+
+	for {
+		if time.Since(cleanedAt) >= outboxCleanupEvery {
+			o.Cleanup(ctx)
+
+			cleanedAt = time.Now()
+		}
+
+		// Keep relaying while full batches are found, to drain the outbox after downtimes.
+		for {
+			n, err := o.Relay(ctx)
+			if err != nil {
+				o.logger.Warn("Couldn't relay events", zap.Error(err))
+			}
+
+			if err != nil || n < outboxBatchSize || ctx.Err() != nil {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Relay publishes one batch of pending events, it returns the number of claimed events. Events that fail to be
+// published are retried later using exponential backoff, after outboxMaxAttempts tries they are marked as dead and
+// logged as errors instead. Errors updating the outbox don't stop the batch.
+func (o *OutboxRelay) Relay(ctx context.Context) (int, error) {
+	events, err := o.repo.Claim(ctx, outboxBatchSize, outboxLease)
+	if err != nil {
+		return 0, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "repo.Claim")
+	}
+
+	for _, event := range events {
+		if err := o.publish(ctx, event); err != nil {
+			if event.Attempts+1 >= outboxMaxAttempts {
+				o.logger.Error("Giving up publishing event",
+					zap.String("id", event.ID),
+					zap.String("type", string(event.Type)),
+					zap.String("taskID", event.Task.ID),
+					zap.Int32("attempts", event.Attempts+1),
+					zap.Error(err),
+				)
+
+				if err := o.repo.Dead(ctx, event.ID); err != nil {
+					o.logger.Warn("Couldn't mark event as dead", zap.String("id", event.ID), zap.Error(err))
+				}
+
+				continue
+			}
+
+			retryIn := backoff(event.Attempts)
+
+			o.logger.Warn("Couldn't publish event",
+				zap.String("id", event.ID),
+				zap.Int32("attempts", event.Attempts+1),
+				zap.Duration("retryIn", retryIn),
+				zap.Error(err),
+			)
+
+			if err := o.repo.Failed(ctx, event.ID, retryIn); err != nil {
+				o.logger.Warn("Couldn't mark event as failed", zap.String("id", event.ID), zap.Error(err))
+			}
+
+			continue
+		}
+
+		// Events not marked as delivered are published again once their lease expires.
+		if err := o.repo.Delivered(ctx, event.ID); err != nil {
+			o.logger.Warn("Couldn't mark event as delivered", zap.String("id", event.ID), zap.Error(err))
+		}
+	}
+
+	return len(events), nil
+}
+
+// Cleanup deletes the events delivered before the retention period, kept until then for troubleshooting.
+func (o *OutboxRelay) Cleanup(ctx context.Context) {
+	n, err := o.repo.DeleteDelivered(ctx, outboxRetention)
+	if err != nil {
+		o.logger.Warn("Couldn't delete delivered events", zap.Error(err))
+
+		return
+	}
+
+	o.logger.Debug("Deleted delivered events", zap.Int64("count", n))
+}
+
+func (o *OutboxRelay) publish(ctx context.Context, event internal.Event) error {
+	switch event.Type {
+	case internal.EventTypeTaskCreated:
+		return o.msgBroker.Created(ctx, event.Task)
+	case internal.EventTypeTaskDeleted:
+		return o.msgBroker.Deleted(ctx, event.Task.ID, event.Task.Version)
+	case internal.EventTypeTaskUpdated:
+		// Events stored before changes were included don't have them.
+		return o.msgBroker.Updated(ctx, event.Task, internal.PointerToValue(event.Changes))
+	}
+
+	return internal.NewErrorf(internal.ErrorCodeInvalidArgument, "unknown event type: %s", event.Type)
+}
+
+// backoff returns how long to wait before retrying an event that failed to be published after attempts tries.
+func backoff(attempts int32) time.Duration {
+	res := outboxBackoffMin
+
+	for range attempts {
+		res *= outboxBackoffFactor
+		if res >= outboxBackoffMax {
+			return outboxBackoffMax
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,238 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"go.uber.org/zap"
+
+	"github.com/MarioCarrion/todo-api-microservice-example/internal"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/service"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/service/servicetesting"
+)
+
+func TestOutboxRelay_Relay(t *testing.T) {
+	t.Parallel()
+
+	task := internal.Task{
+		ID:          "1c7f0a3e-a5b4-4c4f-9f0c-6a0a9b3f0e8d",
+		Description: "test",
+		Version:     2,
+	}
+
+	changes := internal.TaskChanges{
+		Previous: internal.Task{ID: task.ID, Description: "old", Version: 1},
+		Fields:   []internal.TaskField{internal.TaskFieldDescription},
+	}
+
+	tests := []struct {
+		name      string
+		setup     func(*servicetesting.FakeOutboxRepository, *servicetesting.FakeTaskMessageBrokerPublisher)
+		expectErr bool
+		expectN   int
+		verify    func(*testing.T, *servicetesting.FakeOutboxRepository, *servicetesting.FakeTaskMessageBrokerPublisher)
+	}{
+		{
+			name: "OK",
+			setup: func(repo *servicetesting.FakeOutboxRepository, _ *servicetesting.FakeTaskMessageBrokerPublisher) {
+				repo.ClaimReturns([]internal.Event{
+					{ID: "1", Type: internal.EventTypeTaskCreated, Task: task},
+					{ID: "2", Type: internal.EventTypeTaskUpdated, Task: task, Changes: &changes},
+					{ID: "3", Type: internal.EventTypeTaskDeleted, Task: internal.Task{ID: task.ID, Version: 3}},
+				}, nil)
+			},
+			expectN: 3,
+			verify: func(t *testing.T, repo *servicetesting.FakeOutboxRepository, pub *servicetesting.FakeTaskMessageBrokerPublisher) {
+				t.Helper()
+
+				if _, created := pub.CreatedArgsForCall(0); !cmp.Equal(task, created) {
+					t.Fatalf("expected created task does not match: %s", cmp.Diff(task, created))
+				}
+
+				if _, updated, updatedChanges := pub.UpdatedArgsForCall(0); !cmp.Equal(task, updated) ||
+					!cmp.Equal(changes, updatedChanges) {
+					t.Fatalf("expected updated values do not match: %s %s",
+						cmp.Diff(task, updated), cmp.Diff(changes, updatedChanges))
+				}
+
+				if _, id, version := pub.DeletedArgsForCall(0); id != task.ID || version != 3 {
+					t.Fatalf("expected deleted values do not match: %s, %d", id, version)
+				}
+
+				if count := repo.DeliveredCallCount(); count != 3 {
+					t.Fatalf("expected 3 delivered events, got %d", count)
+				}
+
+				if count := repo.FailedCallCount(); count != 0 {
+					t.Fatalf("expected 0 failed events, got %d", count)
+				}
+			},
+		},
+		{
+			name: "OK: publish failed",
+			setup: func(repo *servicetesting.FakeOutboxRepository, pub *servicetesting.FakeTaskMessageBrokerPublisher) {
+				repo.ClaimReturns([]internal.Event{
+					{ID: "1", Type: internal.EventTypeTaskCreated, Task: task, Attempts: 3},
+				}, nil)
+
+				pub.CreatedReturns(errors.New("broker unavailable"))
+			},
+			expectN: 1,
+			verify: func(t *testing.T, repo *servicetesting.FakeOutboxRepository, _ *servicetesting.FakeTaskMessageBrokerPublisher) {
+				t.Helper()
+
+				if count := repo.DeliveredCallCount(); count != 0 {
+					t.Fatalf("expected 0 delivered events, got %d", count)
+				}
+
+				_, id, retryIn := repo.FailedArgsForCall(0)
+				if id != "1" || retryIn != 8*time.Second {
+					t.Fatalf("expected failed values do not match: %s, %s", id, retryIn)
+				}
+			},
+		},
+		{
+			name: "OK: publish failed too many times",
+			setup: func(repo *servicetesting.FakeOutboxRepository, pub *servicetesting.FakeTaskMessageBrokerPublisher) {
+				repo.ClaimReturns([]internal.Event{
+					{ID: "1", Type: internal.EventTypeTaskCreated, Task: task, Attempts: 19},
+				}, nil)
+
+				pub.CreatedReturns(errors.New("broker unavailable"))
+			},
+			expectN: 1,
+			verify: func(t *testing.T, repo *servicetesting.FakeOutboxRepository, _ *servicetesting.FakeTaskMessageBrokerPublisher) {
+				t.Helper()
+
+				if count := repo.FailedCallCount(); count != 0 {
+					t.Fatalf("expected 0 failed events, got %d", count)
+				}
+
+				if _, id := repo.DeadArgsForCall(0); id != "1" {
+					t.Fatalf("expected dead event 1, got %s", id)
+				}
+			},
+		},
+		{
+			name: "ERR: claim",
+			setup: func(repo *servicetesting.FakeOutboxRepository, _ *servicetesting.FakeTaskMessageBrokerPublisher) {
+				repo.ClaimReturns(nil, errors.New("claim failed"))
+			},
+			expectErr: true,
+			verify: func(t *testing.T, repo *servicetesting.FakeOutboxRepository, _ *servicetesting.FakeTaskMessageBrokerPublisher) {
+				t.Helper()
+
+				if count := repo.DeliveredCallCount(); count != 0 {
+					t.Fatalf("expected 0 delivered events, got %d", count)
+				}
+			},
+		},
+		{
+			name: "OK: delivered failed",
+			setup: func(repo *servicetesting.FakeOutboxRepository, _ *servicetesting.FakeTaskMessageBrokerPublisher) {
+				repo.ClaimReturns([]internal.Event{
+					{ID: "1", Type: internal.EventTypeTaskCreated, Task: task},
+					{ID: "2", Type: internal.EventTypeTaskDeleted, Task: internal.Task{ID: task.ID, Version: 3}},
+				}, nil)
+
+				repo.DeliveredReturnsOnCall(0, errors.New("mark failed"))
+			},
+			expectN: 2,
+			verify: func(t *testing.T, repo *servicetesting.FakeOutboxRepository, pub *servicetesting.FakeTaskMessageBrokerPublisher) {
+				t.Helper()
+
+				if count := pub.CreatedCallCount() + pub.DeletedCallCount(); count != 2 {
+					t.Fatalf("expected 2 published events, got %d", count)
+				}
+
+				if _, id := repo.DeliveredArgsForCall(1); id != "2" {
+					t.Fatalf("expected delivered event 2, got %s", id)
+				}
+			},
+		},
+		{
+			name: "OK: failed failed",
+			setup: func(repo *servicetesting.FakeOutboxRepository, pub *servicetesting.FakeTaskMessageBrokerPublisher) {
+				repo.ClaimReturns([]internal.Event{
+					{ID: "1", Type: internal.EventTypeTaskCreated, Task: task},
+					{ID: "2", Type: internal.EventTypeTaskDeleted, Task: internal.Task{ID: task.ID, Version: 3}},
+				}, nil)
+
+				repo.FailedReturns(errors.New("mark failed"))
+				pub.CreatedReturns(errors.New("broker unavailable"))
+			},
+			expectN: 2,
+			verify: func(t *testing.T, repo *servicetesting.FakeOutboxRepository, _ *servicetesting.FakeTaskMessageBrokerPublisher) {
+				t.Helper()
+
+				if _, id := repo.DeliveredArgsForCall(0); id != "2" {
+					t.Fatalf("expected delivered event 2, got %s", id)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := &servicetesting.FakeOutboxRepository{}
+			pub := &servicetesting.FakeTaskMessageBrokerPublisher{}
+
+			tt.setup(repo, pub)
+
+			n, err := service.NewOutboxRelay(zap.NewNop(), repo, pub).Relay(t.Context())
+			if (err != nil) != tt.expectErr {
+				t.Fatalf("expected error %t, got %v", tt.expectErr, err)
+			}
+
+			if n != tt.expectN {
+				t.Fatalf("expected %d events, got %d", tt.expectN, n)
+			}
+
+			tt.verify(t, repo, pub)
+		})
+	}
+}
+
+func TestOutboxRelay_Run(t *testing.T) {
+	t.Parallel()
+
+	repo := &servicetesting.FakeOutboxRepository{}
+
+	ctx, cancel := context.WithCancel(t.Context())
+
+	repo.ClaimStub = func(_ context.Context, _ int32, _ time.Duration) ([]internal.Event, error) {
+		cancel()
+
+		return nil, nil
+	}
+
+	done := make(chan struct{})
+
+	go func() {
+		service.NewOutboxRelay(zap.NewNop(), repo, &servicetesting.FakeTaskMessageBrokerPublisher{}).Run(ctx)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected Run to return after the context is canceled")
+	}
+
+	if count := repo.ClaimCallCount(); count != 1 {
+		t.Fatalf("expected 1 claim, got %d", count)
+	}
+
+	if count := repo.DeleteDeliveredCallCount(); count != 1 {
+		t.Fatalf("expected 1 cleanup, got %d", count)
+	}
+
+	if _, retention := repo.DeleteDeliveredArgsForCall(0); retention != 24*time.Hour {
+		t.Fatalf("expected 24h retention, got %s", retention)
+	}
+}
@@ -0,0 +1,263 @@
+package service
+
+import (
+	"context"
+
+	"github.com/MarioCarrion/todo-api-microservice-example/internal"
+)
+
+//counterfeiter:generate -o servicetesting/task_list_repository.gen.go . TaskListRepository
+
+// TaskListRepository defines the datastore listing all the Task records, sorted by ID.
+type TaskListRepository interface {
+	Count(ctx context.Context) (int64, error)
+	List(ctx context.Context, after string, limit int32) ([]internal.Task, error)
+}
+
+//counterfeiter:generate -o servicetesting/task_bulk_index_repository.gen.go . TaskBulkIndexRepository
+
+// TaskBulkIndexRepository defines the datastore indexing Task records in bulk.
+type TaskBulkIndexRepository interface {
+	BulkDelete(ctx context.Context, ids []string) error
+	BulkIndex(ctx context.Context, tasks []internal.Task) error
+	Find(ctx context.Context, ids []string) (map[string]internal.Task, error)
+	List(ctx context.Context, after string, limit int32) ([]internal.Task, error)
+}
+
+// ReindexParams defines the arguments used for reindexing the Tasks.
+type ReindexParams struct {
+	// After indicates the ID of the last Task processed, used for resuming a previous run.
+	After string
+	// BatchSize indicates the number of Tasks processed at once.
+	BatchSize int32
+	// DryRun indicates Tasks are not indexed, and orphaned ones not deleted, instead the differences with the indexed
+	// ones are reported.
+	DryRun bool
+}
+
+// ReindexBatch describes a batch of Tasks that was processed.
+type ReindexBatch struct {
+	// LastID is the ID of the last Task in the batch, use it as ReindexParams.After to resume.
+	LastID string
+	// Tasks is the number of Tasks in the batch.
+	Tasks int
+	// Orphaned is the number of indexed Tasks that don't exist anymore, they are deleted unless running in dry-run mode.
+	Orphaned int
+	// Differences are the Tasks that differ from the indexed ones, only set when running in dry-run mode.
+	Differences []TaskDifference
+}
+
+// TaskDifference describes how a Task differs from the indexed one.
+type TaskDifference struct {
+	ID string
+	// Missing indicates the Task is not indexed.
+	Missing bool
+	// Orphaned indicates the Task is indexed but it doesn't exist anymore.
+	Orphaned bool
+	// Version and IndexedVersion are the versions of the Task and the indexed one.
+	Version        int64
+	IndexedVersion int64
+	// Fields are the fields with different values.
+	Fields []internal.TaskField
+}
+
+// Reindexer defines the application service in charge of indexing all the Tasks, it is used for rebuilding the
+// index or backfilling Tasks which events were lost.
+type Reindexer struct {
+	repo  TaskListRepository
+	index TaskBulkIndexRepository
+}
+
+// NewReindexer ...
+func NewReindexer(repo TaskListRepository, index TaskBulkIndexRepository) *Reindexer {
+	return &Reindexer{
+		repo:  repo,
+		index: index,
+	}
+}
+
+// Count returns the number of Tasks to be reindexed.
+func (r *Reindexer) Count(ctx context.Context) (int64, error) {
+	res, err := r.repo.Count(ctx)
+	if err != nil {
+		return 0, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "repo.Count")
+	}
+
+	return res, nil
+}
+
+// Reindex indexes the Tasks in batches, sorted by ID, calling fn after each batch is processed; fn is meant to be
+// used for reporting progress and saving the checkpoint used for resuming, returning an error stops reindexing.
+//
+// Indexed Tasks in the range of IDs of each batch that don't exist anymore are deleted from the index. They are
+// listed before the Tasks so Tasks created in between are never considered orphaned.
+func (r *Reindexer) Reindex(ctx context.Context, params ReindexParams, fn func(ReindexBatch) error) error {
+	if params.BatchSize < 1 {
+		return internal.NewErrorf(internal.ErrorCodeInvalidArgument, "invalid batch size %d", params.BatchSize)
+	}
+
+	after := params.After
+
+	for {
+		indexed, err := r.index.List(ctx, after, params.BatchSize)
+		if err != nil {
+			return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "index.List")
+		}
+
+		tasks, err := r.repo.List(ctx, after, params.BatchSize)
+		if err != nil {
+			return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "repo.List")
+		}
+
+		last, done := batchRange(tasks, indexed, params.BatchSize)
+
+		tasks = tasksUntil(tasks, last)
+		orphaned := orphanedTasks(tasks, tasksUntil(indexed, last))
+
+		if last == "" {
+			return nil
+		}
+
+		batch := ReindexBatch{
+			LastID:   last,
+			Tasks:    len(tasks),
+			Orphaned: len(orphaned),
+		}
+
+		if params.DryRun {
+			if batch.Differences, err = r.diff(ctx, tasks); err != nil {
+				return err
+			}
+
+			for _, task := range orphaned {
+				batch.Differences = append(batch.Differences, TaskDifference{
+					ID:             task.ID,
+					Orphaned:       true,
+					IndexedVersion: task.Version,
+				})
+			}
+		} else {
+			if err := r.index.BulkIndex(ctx, tasks); err != nil {
+				return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "index.BulkIndex")
+			}
+
+			if err := r.index.BulkDelete(ctx, taskIDs(orphaned)); err != nil {
+				return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "index.BulkDelete")
+			}
+		}
+
+		if err := fn(batch); err != nil {
+			return err
+		}
+
+		if done {
+			return nil
+		}
+
+		after = batch.LastID
+	}
+}
+
+// diff returns the tasks that are different than the indexed ones.
+func (r *Reindexer) diff(ctx context.Context, tasks []internal.Task) ([]TaskDifference, error) {
+	if len(tasks) == 0 {
+		return nil, nil
+	}
+
+	indexed, err := r.index.Find(ctx, taskIDs(tasks))
+	if err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "index.Find")
+	}
+
+	var res []TaskDifference
+
+	for _, task := range tasks {
+		found, ok := indexed[task.ID]
+		if !ok {
+			res = append(res, TaskDifference{ID: task.ID, Missing: true, Version: task.Version})
+
+			continue
+		}
+
+		changes := internal.NewTaskChanges(found, task)
+
+		if found.Version != task.Version || len(changes.Fields) > 0 {
+			res = append(res, TaskDifference{
+				ID:             task.ID,
+				Version:        task.Version,
+				IndexedVersion: found.Version,
+				Fields:         changes.Fields,
+			})
+		}
+	}
+
+	return res, nil
+}
+
+// batchRange returns the ID of the last Task covered by both lists, done indicates both lists reached the end. When
+// one list is full only the IDs up to its last one are known to be complete in the other one.
+func batchRange(tasks, indexed []internal.Task, limit int32) (string, bool) {
+	var last string
+
+	full := func(list []internal.Task) bool { return len(list) == int(limit) }
+
+	if full(tasks) {
+		last = tasks[len(tasks)-1].ID
+	}
+
+	if full(indexed) && (last == "" || indexed[len(indexed)-1].ID < last) {
+		last = indexed[len(indexed)-1].ID
+	}
+
+	if last != "" {
+		return last, false
+	}
+
+	for _, list := range [][]internal.Task{tasks, indexed} {
+		if len(list) > 0 && list[len(list)-1].ID > last {
+			last = list[len(list)-1].ID
+		}
+	}
+
+	return last, true
+}
+
+// tasksUntil returns the tasks with IDs up to last, tasks must be sorted by ID.
+func tasksUntil(tasks []internal.Task, last string) []internal.Task {
+	for i, task := range tasks {
+		if task.ID > last {
+			return tasks[:i]
+		}
+	}
+
+	return tasks
+}
+
+// orphanedTasks returns the indexed tasks not included in tasks.
+func orphanedTasks(tasks, indexed []internal.Task) []internal.Task {
+	ids := make(map[string]struct{}, len(tasks))
+
+	for _, task := range tasks {
+		ids[task.ID] = struct{}{}
+	}
+
+	var res []internal.Task
+
+	for _, task := range indexed {
+		if _, ok := ids[task.ID]; !ok {
+			res = append(res, task)
+		}
+	}
+
+	return res
+}
+
+func taskIDs(tasks []internal.Task) []string {
+	res := make([]string, len(tasks))
+
+	for i, task := range tasks {
+		res[i] = task.ID
+	}
+
+	return res
+}
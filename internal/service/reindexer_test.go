@@ -0,0 +1,265 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/MarioCarrion/todo-api-microservice-example/internal"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/service"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/service/servicetesting"
+)
+
+func TestReindexer_Reindex(t *testing.T) {
+	t.Parallel()
+
+	tasks := []internal.Task{
+		{ID: "1c7f0a3e-a5b4-4c4f-9f0c-6a0a9b3f0e8d", Description: "one", Version: 1},
+		{ID: "2d8a1b4f-b6c5-4d50-8a1d-7b1bac4f1f9e", Description: "two", Version: 3},
+		{ID: "3e9b2c50-c7d6-4e61-9b2e-8c2cbd5a2a0f", Description: "three", Version: 2},
+	}
+
+	// pagesOf returns the tasks after the received ID, like the repositories; tasks must be sorted by ID.
+	pagesOf := func(tasks []internal.Task) func(context.Context, string, int32) ([]internal.Task, error) {
+		return func(_ context.Context, after string, limit int32) ([]internal.Task, error) {
+			start := len(tasks)
+
+			for i, task := range tasks {
+				if task.ID > after {
+					start = i
+
+					break
+				}
+			}
+
+			return tasks[start:min(start+int(limit), len(tasks))], nil
+		}
+	}
+
+	pages := pagesOf(tasks)
+
+	orphaned := []internal.Task{
+		{ID: "0a5d8e2c-93b2-4a3d-8e4f-5f9f8a2e1d7c", Description: "zero", Version: 4},
+		{ID: "1d2e3f40-5a6b-4c7d-8e9f-0a1b2c3d4e5f", Description: "one and a half", Version: 2},
+		{ID: "4f0c3d61-d8e7-4f72-8c3f-9d3dce6b3b1a", Description: "four", Version: 1},
+	}
+
+	tests := []struct {
+		name      string
+		params    service.ReindexParams
+		setup     func(*servicetesting.FakeTaskListRepository, *servicetesting.FakeTaskBulkIndexRepository)
+		expected  []service.ReindexBatch
+		expectErr bool
+		verify    func(*testing.T, *servicetesting.FakeTaskBulkIndexRepository)
+	}{
+		{
+			name:   "OK",
+			params: service.ReindexParams{BatchSize: 2},
+			setup: func(repo *servicetesting.FakeTaskListRepository, _ *servicetesting.FakeTaskBulkIndexRepository) {
+				repo.ListCalls(pages)
+			},
+			expected: []service.ReindexBatch{
+				{LastID: tasks[1].ID, Tasks: 2},
+				{LastID: tasks[2].ID, Tasks: 1},
+			},
+			verify: func(t *testing.T, index *servicetesting.FakeTaskBulkIndexRepository) {
+				t.Helper()
+
+				if count := index.BulkIndexCallCount(); count != 2 {
+					t.Fatalf("expected 2 bulk calls, got %d", count)
+				}
+
+				if _, actual := index.BulkIndexArgsForCall(1); !cmp.Equal(tasks[2:], actual) {
+					t.Fatalf("expected indexed tasks do not match: %s", cmp.Diff(tasks[2:], actual))
+				}
+			},
+		},
+		{
+			name:   "OK: resumed",
+			params: service.ReindexParams{After: tasks[1].ID, BatchSize: 2},
+			setup: func(repo *servicetesting.FakeTaskListRepository, _ *servicetesting.FakeTaskBulkIndexRepository) {
+				repo.ListCalls(pages)
+			},
+			expected: []service.ReindexBatch{
+				{LastID: tasks[2].ID, Tasks: 1},
+			},
+			verify: func(t *testing.T, index *servicetesting.FakeTaskBulkIndexRepository) {
+				t.Helper()
+
+				if count := index.BulkIndexCallCount(); count != 1 {
+					t.Fatalf("expected 1 bulk call, got %d", count)
+				}
+			},
+		},
+		{
+			name:   "OK: dry run",
+			params: service.ReindexParams{BatchSize: 3, DryRun: true},
+			setup: func(repo *servicetesting.FakeTaskListRepository, index *servicetesting.FakeTaskBulkIndexRepository) {
+				repo.ListCalls(pages)
+
+				stale := tasks[1]
+				stale.Description = "old"
+				stale.Version = 2
+
+				index.FindReturns(map[string]internal.Task{
+					tasks[0].ID: tasks[0],
+					tasks[1].ID: stale,
+				}, nil)
+			},
+			expected: []service.ReindexBatch{
+				{
+					LastID: tasks[2].ID,
+					Tasks:  3,
+					Differences: []service.TaskDifference{
+						{
+							ID:             tasks[1].ID,
+							Version:        3,
+							IndexedVersion: 2,
+							Fields:         []internal.TaskField{internal.TaskFieldDescription},
+						},
+						{ID: tasks[2].ID, Missing: true, Version: 2},
+					},
+				},
+			},
+			verify: func(t *testing.T, index *servicetesting.FakeTaskBulkIndexRepository) {
+				t.Helper()
+
+				if count := index.BulkIndexCallCount(); count != 0 {
+					t.Fatalf("expected no bulk calls, got %d", count)
+				}
+			},
+		},
+		{
+			name:   "OK: orphaned",
+			params: service.ReindexParams{BatchSize: 2},
+			setup: func(repo *servicetesting.FakeTaskListRepository, index *servicetesting.FakeTaskBulkIndexRepository) {
+				repo.ListCalls(pages)
+				index.ListCalls(pagesOf([]internal.Task{tasks[0], orphaned[1], tasks[1], tasks[2], orphaned[2]}))
+			},
+			expected: []service.ReindexBatch{
+				{LastID: orphaned[1].ID, Tasks: 1, Orphaned: 1},
+				{LastID: tasks[2].ID, Tasks: 2},
+				{LastID: orphaned[2].ID, Orphaned: 1},
+			},
+			verify: func(t *testing.T, index *servicetesting.FakeTaskBulkIndexRepository) {
+				t.Helper()
+
+				if _, actual := index.BulkIndexArgsForCall(0); !cmp.Equal(tasks[:1], actual) {
+					t.Fatalf("expected indexed tasks do not match: %s", cmp.Diff(tasks[:1], actual))
+				}
+
+				for call, expected := range map[int][]string{0: {orphaned[1].ID}, 2: {orphaned[2].ID}} {
+					if _, actual := index.BulkDeleteArgsForCall(call); !cmp.Equal(expected, actual) {
+						t.Fatalf("expected deleted tasks do not match: %s", cmp.Diff(expected, actual))
+					}
+				}
+			},
+		},
+		{
+			name:   "OK: dry run orphaned",
+			params: service.ReindexParams{BatchSize: 5, DryRun: true},
+			setup: func(repo *servicetesting.FakeTaskListRepository, index *servicetesting.FakeTaskBulkIndexRepository) {
+				repo.ListCalls(pages)
+				index.ListCalls(pagesOf(append([]internal.Task{orphaned[0]}, tasks...)))
+				index.FindReturns(map[string]internal.Task{
+					tasks[0].ID: tasks[0],
+					tasks[1].ID: tasks[1],
+					tasks[2].ID: tasks[2],
+				}, nil)
+			},
+			expected: []service.ReindexBatch{
+				{
+					LastID:   tasks[2].ID,
+					Tasks:    3,
+					Orphaned: 1,
+					Differences: []service.TaskDifference{
+						{ID: orphaned[0].ID, Orphaned: true, IndexedVersion: 4},
+					},
+				},
+			},
+			verify: func(t *testing.T, index *servicetesting.FakeTaskBulkIndexRepository) {
+				t.Helper()
+
+				if count := index.BulkDeleteCallCount(); count != 0 {
+					t.Fatalf("expected no bulk delete calls, got %d", count)
+				}
+			},
+		},
+		{
+			name:   "ERR: index list",
+			params: service.ReindexParams{BatchSize: 2},
+			setup: func(repo *servicetesting.FakeTaskListRepository, index *servicetesting.FakeTaskBulkIndexRepository) {
+				repo.ListCalls(pages)
+				index.ListReturns(nil, errors.New("failed"))
+			},
+			expectErr: true,
+			verify:    func(_ *testing.T, _ *servicetesting.FakeTaskBulkIndexRepository) {},
+		},
+		{
+			name:   "ERR: bulk delete",
+			params: service.ReindexParams{BatchSize: 2},
+			setup: func(repo *servicetesting.FakeTaskListRepository, index *servicetesting.FakeTaskBulkIndexRepository) {
+				repo.ListCalls(pages)
+				index.ListCalls(pagesOf(orphaned))
+				index.BulkDeleteReturns(errors.New("failed"))
+			},
+			expectErr: true,
+			verify:    func(_ *testing.T, _ *servicetesting.FakeTaskBulkIndexRepository) {},
+		},
+		{
+			name:   "ERR: list",
+			params: service.ReindexParams{BatchSize: 2},
+			setup: func(repo *servicetesting.FakeTaskListRepository, _ *servicetesting.FakeTaskBulkIndexRepository) {
+				repo.ListReturns(nil, errors.New("failed"))
+			},
+			expectErr: true,
+			verify:    func(_ *testing.T, _ *servicetesting.FakeTaskBulkIndexRepository) {},
+		},
+		{
+			name:   "ERR: bulk index",
+			params: service.ReindexParams{BatchSize: 2},
+			setup: func(repo *servicetesting.FakeTaskListRepository, index *servicetesting.FakeTaskBulkIndexRepository) {
+				repo.ListCalls(pages)
+				index.BulkIndexReturns(errors.New("failed"))
+			},
+			expectErr: true,
+			verify:    func(_ *testing.T, _ *servicetesting.FakeTaskBulkIndexRepository) {},
+		},
+		{
+			name:      "ERR: invalid batch size",
+			params:    service.ReindexParams{},
+			setup:     func(_ *servicetesting.FakeTaskListRepository, _ *servicetesting.FakeTaskBulkIndexRepository) {},
+			expectErr: true,
+			verify:    func(_ *testing.T, _ *servicetesting.FakeTaskBulkIndexRepository) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := &servicetesting.FakeTaskListRepository{}
+			index := &servicetesting.FakeTaskBulkIndexRepository{}
+			tt.setup(repo, index)
+
+			var actual []service.ReindexBatch
+
+			err := service.NewReindexer(repo, index).Reindex(t.Context(), tt.params, func(batch service.ReindexBatch) error {
+				actual = append(actual, batch)
+
+				return nil
+			})
+			if (err != nil) != tt.expectErr {
+				t.Fatalf("expected error %t, got %v", tt.expectErr, err)
+			}
+
+			if diff := cmp.Diff(tt.expected, actual); diff != "" {
+				t.Fatalf("expected batches do not match: %s", diff)
+			}
+
+			tt.verify(t, index)
+		})
+	}
+}
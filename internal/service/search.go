@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/mercari/go-circuitbreaker"
+	"go.uber.org/zap"
+
+	"github.com/MarioCarrion/todo-api-microservice-example/internal"
+)
+
+// CircuitBreakerConfig defines the circuit breaker used for each search repository, an open breaker skips the
+// repository until OpenTimeout passes.
+type CircuitBreakerConfig struct {
+	// OpenTimeout indicates how long the breaker stays open before trying the repository again.
+	OpenTimeout time.Duration
+	// ConsecutiveFailures indicates the number of consecutive failures opening the breaker.
+	ConsecutiveFailures int64
+	// HalfOpenMaxSuccesses indicates the number of successful requests closing the breaker after trying again.
+	HalfOpenMaxSuccesses int64
+}
+
+// DefaultCircuitBreakerConfig returns the configuration opening the breaker for 2 minutes after 3 consecutive
+// failures.
+func DefaultCircuitBreakerConfig() CircuitBreakerConfig {
+	return CircuitBreakerConfig{
+		OpenTimeout:          2 * time.Minute,
+		ConsecutiveFailures:  3,
+		HalfOpenMaxSuccesses: circuitbreaker.DefaultHalfOpenMaxSuccesses,
+	}
+}
+
+// searchBackend represents a search repository and its circuit breaker.
+type searchBackend struct {
+	repo TaskSearchRepository
+	cb   *circuitbreaker.CircuitBreaker
+}
+
+func newSearchBackends(logger *zap.Logger, conf CircuitBreakerConfig, repos []TaskSearchRepository) []searchBackend {
+	res := make([]searchBackend, len(repos))
+
+	for i, repo := range repos {
+		res[i] = searchBackend{
+			repo: repo,
+			cb: circuitbreaker.New(
+				circuitbreaker.WithOpenTimeout(conf.OpenTimeout),
+				circuitbreaker.WithTripFunc(circuitbreaker.NewTripFuncConsecutiveFailures(conf.ConsecutiveFailures)),
+				circuitbreaker.WithHalfOpenMaxSuccesses(conf.HalfOpenMaxSuccesses),
+				circuitbreaker.WithOnStateChangeHookFn(func(oldState, newState circuitbreaker.State) {
+					logger.Info("state changed",
+						zap.Int("search", i),
+						zap.String("old", string(oldState)),
+						zap.String("new", string(newState)),
+					)
+				}),
+			),
+		}
+	}
+
+	return res
+}
+
+// searchFirst calls fn using the repositories in order, skipping the ones with an open breaker, until one succeeds;
+// it returns the index of the repository used. Invalid arguments are returned right away and do not open the breaker,
+// other errors use the next repository.
+func searchFirst[T any](ctx context.Context,
+	backends []searchBackend,
+	fn func(TaskSearchRepository) (T, error),
+) (T, int, error) {
+	var (
+		zero T
+		err  error = internal.NewErrorf(internal.ErrorCodeUnavailable, "service not available")
+	)
+
+	for i, backend := range backends {
+		if !backend.cb.Ready() {
+			continue
+		}
+
+		res, ferr := fn(backend.repo)
+		if internal.HasCode(ferr, internal.ErrorCodeInvalidArgument) {
+			ferr = circuitbreaker.MarkAsSuccess(ferr)
+		}
+
+		if ferr = backend.cb.Done(ctx, ferr); ferr != nil {
+			if internal.HasCode(ferr, internal.ErrorCodeInvalidArgument) {
+				return zero, i, ferr
+			}
+
+			err = ferr
+
+			continue
+		}
+
+		return res, i, nil
+	}
+
+	return zero, -1, err
+}
@@ -0,0 +1,424 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package servicetesting
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/MarioCarrion/todo-api-microservice-example/internal"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/service"
+)
+
+type FakeOutboxRepository struct {
+	ClaimStub        func(context.Context, int32, time.Duration) ([]internal.Event, error)
+	claimMutex       sync.RWMutex
+	claimArgsForCall []struct {
+		arg1 context.Context
+		arg2 int32
+		arg3 time.Duration
+	}
+	claimReturns struct {
+		result1 []internal.Event
+		result2 error
+	}
+	claimReturnsOnCall map[int]struct {
+		result1 []internal.Event
+		result2 error
+	}
+	DeadStub        func(context.Context, string) error
+	deadMutex       sync.RWMutex
+	deadArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	deadReturns struct {
+		result1 error
+	}
+	deadReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DeleteDeliveredStub        func(context.Context, time.Duration) (int64, error)
+	deleteDeliveredMutex       sync.RWMutex
+	deleteDeliveredArgsForCall []struct {
+		arg1 context.Context
+		arg2 time.Duration
+	}
+	deleteDeliveredReturns struct {
+		result1 int64
+		result2 error
+	}
+	deleteDeliveredReturnsOnCall map[int]struct {
+		result1 int64
+		result2 error
+	}
+	DeliveredStub        func(context.Context, string) error
+	deliveredMutex       sync.RWMutex
+	deliveredArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	deliveredReturns struct {
+		result1 error
+	}
+	deliveredReturnsOnCall map[int]struct {
+		result1 error
+	}
+	FailedStub        func(context.Context, string, time.Duration) error
+	failedMutex       sync.RWMutex
+	failedArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 time.Duration
+	}
+	failedReturns struct {
+		result1 error
+	}
+	failedReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeOutboxRepository) Claim(arg1 context.Context, arg2 int32, arg3 time.Duration) ([]internal.Event, error) {
+	fake.claimMutex.Lock()
+	ret, specificReturn := fake.claimReturnsOnCall[len(fake.claimArgsForCall)]
+	fake.claimArgsForCall = append(fake.claimArgsForCall, struct {
+		arg1 context.Context
+		arg2 int32
+		arg3 time.Duration
+	}{arg1, arg2, arg3})
+	stub := fake.ClaimStub
+	fakeReturns := fake.claimReturns
+	fake.recordInvocation("Claim", []interface{}{arg1, arg2, arg3})
+	fake.claimMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeOutboxRepository) ClaimCallCount() int {
+	fake.claimMutex.RLock()
+	defer fake.claimMutex.RUnlock()
+	return len(fake.claimArgsForCall)
+}
+
+func (fake *FakeOutboxRepository) ClaimCalls(stub func(context.Context, int32, time.Duration) ([]internal.Event, error)) {
+	fake.claimMutex.Lock()
+	defer fake.claimMutex.Unlock()
+	fake.ClaimStub = stub
+}
+
+func (fake *FakeOutboxRepository) ClaimArgsForCall(i int) (context.Context, int32, time.Duration) {
+	fake.claimMutex.RLock()
+	defer fake.claimMutex.RUnlock()
+	argsForCall := fake.claimArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeOutboxRepository) ClaimReturns(result1 []internal.Event, result2 error) {
+	fake.claimMutex.Lock()
+	defer fake.claimMutex.Unlock()
+	fake.ClaimStub = nil
+	fake.claimReturns = struct {
+		result1 []internal.Event
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeOutboxRepository) ClaimReturnsOnCall(i int, result1 []internal.Event, result2 error) {
+	fake.claimMutex.Lock()
+	defer fake.claimMutex.Unlock()
+	fake.ClaimStub = nil
+	if fake.claimReturnsOnCall == nil {
+		fake.claimReturnsOnCall = make(map[int]struct {
+			result1 []internal.Event
+			result2 error
+		})
+	}
+	fake.claimReturnsOnCall[i] = struct {
+		result1 []internal.Event
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeOutboxRepository) Dead(arg1 context.Context, arg2 string) error {
+	fake.deadMutex.Lock()
+	ret, specificReturn := fake.deadReturnsOnCall[len(fake.deadArgsForCall)]
+	fake.deadArgsForCall = append(fake.deadArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.DeadStub
+	fakeReturns := fake.deadReturns
+	fake.recordInvocation("Dead", []interface{}{arg1, arg2})
+	fake.deadMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeOutboxRepository) DeadCallCount() int {
+	fake.deadMutex.RLock()
+	defer fake.deadMutex.RUnlock()
+	return len(fake.deadArgsForCall)
+}
+
+func (fake *FakeOutboxRepository) DeadCalls(stub func(context.Context, string) error) {
+	fake.deadMutex.Lock()
+	defer fake.deadMutex.Unlock()
+	fake.DeadStub = stub
+}
+
+func (fake *FakeOutboxRepository) DeadArgsForCall(i int) (context.Context, string) {
+	fake.deadMutex.RLock()
+	defer fake.deadMutex.RUnlock()
+	argsForCall := fake.deadArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeOutboxRepository) DeadReturns(result1 error) {
+	fake.deadMutex.Lock()
+	defer fake.deadMutex.Unlock()
+	fake.DeadStub = nil
+	fake.deadReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeOutboxRepository) DeadReturnsOnCall(i int, result1 error) {
+	fake.deadMutex.Lock()
+	defer fake.deadMutex.Unlock()
+	fake.DeadStub = nil
+	if fake.deadReturnsOnCall == nil {
+		fake.deadReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deadReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeOutboxRepository) DeleteDelivered(arg1 context.Context, arg2 time.Duration) (int64, error) {
+	fake.deleteDeliveredMutex.Lock()
+	ret, specificReturn := fake.deleteDeliveredReturnsOnCall[len(fake.deleteDeliveredArgsForCall)]
+	fake.deleteDeliveredArgsForCall = append(fake.deleteDeliveredArgsForCall, struct {
+		arg1 context.Context
+		arg2 time.Duration
+	}{arg1, arg2})
+	stub := fake.DeleteDeliveredStub
+	fakeReturns := fake.deleteDeliveredReturns
+	fake.recordInvocation("DeleteDelivered", []interface{}{arg1, arg2})
+	fake.deleteDeliveredMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeOutboxRepository) DeleteDeliveredCallCount() int {
+	fake.deleteDeliveredMutex.RLock()
+	defer fake.deleteDeliveredMutex.RUnlock()
+	return len(fake.deleteDeliveredArgsForCall)
+}
+
+func (fake *FakeOutboxRepository) DeleteDeliveredCalls(stub func(context.Context, time.Duration) (int64, error)) {
+	fake.deleteDeliveredMutex.Lock()
+	defer fake.deleteDeliveredMutex.Unlock()
+	fake.DeleteDeliveredStub = stub
+}
+
+func (fake *FakeOutboxRepository) DeleteDeliveredArgsForCall(i int) (context.Context, time.Duration) {
+	fake.deleteDeliveredMutex.RLock()
+	defer fake.deleteDeliveredMutex.RUnlock()
+	argsForCall := fake.deleteDeliveredArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeOutboxRepository) DeleteDeliveredReturns(result1 int64, result2 error) {
+	fake.deleteDeliveredMutex.Lock()
+	defer fake.deleteDeliveredMutex.Unlock()
+	fake.DeleteDeliveredStub = nil
+	fake.deleteDeliveredReturns = struct {
+		result1 int64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeOutboxRepository) DeleteDeliveredReturnsOnCall(i int, result1 int64, result2 error) {
+	fake.deleteDeliveredMutex.Lock()
+	defer fake.deleteDeliveredMutex.Unlock()
+	fake.DeleteDeliveredStub = nil
+	if fake.deleteDeliveredReturnsOnCall == nil {
+		fake.deleteDeliveredReturnsOnCall = make(map[int]struct {
+			result1 int64
+			result2 error
+		})
+	}
+	fake.deleteDeliveredReturnsOnCall[i] = struct {
+		result1 int64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeOutboxRepository) Delivered(arg1 context.Context, arg2 string) error {
+	fake.deliveredMutex.Lock()
+	ret, specificReturn := fake.deliveredReturnsOnCall[len(fake.deliveredArgsForCall)]
+	fake.deliveredArgsForCall = append(fake.deliveredArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.DeliveredStub
+	fakeReturns := fake.deliveredReturns
+	fake.recordInvocation("Delivered", []interface{}{arg1, arg2})
+	fake.deliveredMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeOutboxRepository) DeliveredCallCount() int {
+	fake.deliveredMutex.RLock()
+	defer fake.deliveredMutex.RUnlock()
+	return len(fake.deliveredArgsForCall)
+}
+
+func (fake *FakeOutboxRepository) DeliveredCalls(stub func(context.Context, string) error) {
+	fake.deliveredMutex.Lock()
+	defer fake.deliveredMutex.Unlock()
+	fake.DeliveredStub = stub
+}
+
+func (fake *FakeOutboxRepository) DeliveredArgsForCall(i int) (context.Context, string) {
+	fake.deliveredMutex.RLock()
+	defer fake.deliveredMutex.RUnlock()
+	argsForCall := fake.deliveredArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeOutboxRepository) DeliveredReturns(result1 error) {
+	fake.deliveredMutex.Lock()
+	defer fake.deliveredMutex.Unlock()
+	fake.DeliveredStub = nil
+	fake.deliveredReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeOutboxRepository) DeliveredReturnsOnCall(i int, result1 error) {
+	fake.deliveredMutex.Lock()
+	defer fake.deliveredMutex.Unlock()
+	fake.DeliveredStub = nil
+	if fake.deliveredReturnsOnCall == nil {
+		fake.deliveredReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deliveredReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeOutboxRepository) Failed(arg1 context.Context, arg2 string, arg3 time.Duration) error {
+	fake.failedMutex.Lock()
+	ret, specificReturn := fake.failedReturnsOnCall[len(fake.failedArgsForCall)]
+	fake.failedArgsForCall = append(fake.failedArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 time.Duration
+	}{arg1, arg2, arg3})
+	stub := fake.FailedStub
+	fakeReturns := fake.failedReturns
+	fake.recordInvocation("Failed", []interface{}{arg1, arg2, arg3})
+	fake.failedMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeOutboxRepository) FailedCallCount() int {
+	fake.failedMutex.RLock()
+	defer fake.failedMutex.RUnlock()
+	return len(fake.failedArgsForCall)
+}
+
+func (fake *FakeOutboxRepository) FailedCalls(stub func(context.Context, string, time.Duration) error) {
+	fake.failedMutex.Lock()
+	defer fake.failedMutex.Unlock()
+	fake.FailedStub = stub
+}
+
+func (fake *FakeOutboxRepository) FailedArgsForCall(i int) (context.Context, string, time.Duration) {
+	fake.failedMutex.RLock()
+	defer fake.failedMutex.RUnlock()
+	argsForCall := fake.failedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeOutboxRepository) FailedReturns(result1 error) {
+	fake.failedMutex.Lock()
+	defer fake.failedMutex.Unlock()
+	fake.FailedStub = nil
+	fake.failedReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeOutboxRepository) FailedReturnsOnCall(i int, result1 error) {
+	fake.failedMutex.Lock()
+	defer fake.failedMutex.Unlock()
+	fake.FailedStub = nil
+	if fake.failedReturnsOnCall == nil {
+		fake.failedReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.failedReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeOutboxRepository) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeOutboxRepository) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ service.OutboxRepository = new(FakeOutboxRepository)
@@ -0,0 +1,362 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package servicetesting
+
+import (
+	"context"
+	"sync"
+
+	"github.com/MarioCarrion/todo-api-microservice-example/internal"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/service"
+)
+
+type FakeTaskBulkIndexRepository struct {
+	BulkDeleteStub        func(context.Context, []string) error
+	bulkDeleteMutex       sync.RWMutex
+	bulkDeleteArgsForCall []struct {
+		arg1 context.Context
+		arg2 []string
+	}
+	bulkDeleteReturns struct {
+		result1 error
+	}
+	bulkDeleteReturnsOnCall map[int]struct {
+		result1 error
+	}
+	BulkIndexStub        func(context.Context, []internal.Task) error
+	bulkIndexMutex       sync.RWMutex
+	bulkIndexArgsForCall []struct {
+		arg1 context.Context
+		arg2 []internal.Task
+	}
+	bulkIndexReturns struct {
+		result1 error
+	}
+	bulkIndexReturnsOnCall map[int]struct {
+		result1 error
+	}
+	FindStub        func(context.Context, []string) (map[string]internal.Task, error)
+	findMutex       sync.RWMutex
+	findArgsForCall []struct {
+		arg1 context.Context
+		arg2 []string
+	}
+	findReturns struct {
+		result1 map[string]internal.Task
+		result2 error
+	}
+	findReturnsOnCall map[int]struct {
+		result1 map[string]internal.Task
+		result2 error
+	}
+	ListStub        func(context.Context, string, int32) ([]internal.Task, error)
+	listMutex       sync.RWMutex
+	listArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 int32
+	}
+	listReturns struct {
+		result1 []internal.Task
+		result2 error
+	}
+	listReturnsOnCall map[int]struct {
+		result1 []internal.Task
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeTaskBulkIndexRepository) BulkDelete(arg1 context.Context, arg2 []string) error {
+	var arg2Copy []string
+	if arg2 != nil {
+		arg2Copy = make([]string, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.bulkDeleteMutex.Lock()
+	ret, specificReturn := fake.bulkDeleteReturnsOnCall[len(fake.bulkDeleteArgsForCall)]
+	fake.bulkDeleteArgsForCall = append(fake.bulkDeleteArgsForCall, struct {
+		arg1 context.Context
+		arg2 []string
+	}{arg1, arg2Copy})
+	stub := fake.BulkDeleteStub
+	fakeReturns := fake.bulkDeleteReturns
+	fake.recordInvocation("BulkDelete", []interface{}{arg1, arg2Copy})
+	fake.bulkDeleteMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeTaskBulkIndexRepository) BulkDeleteCallCount() int {
+	fake.bulkDeleteMutex.RLock()
+	defer fake.bulkDeleteMutex.RUnlock()
+	return len(fake.bulkDeleteArgsForCall)
+}
+
+func (fake *FakeTaskBulkIndexRepository) BulkDeleteCalls(stub func(context.Context, []string) error) {
+	fake.bulkDeleteMutex.Lock()
+	defer fake.bulkDeleteMutex.Unlock()
+	fake.BulkDeleteStub = stub
+}
+
+func (fake *FakeTaskBulkIndexRepository) BulkDeleteArgsForCall(i int) (context.Context, []string) {
+	fake.bulkDeleteMutex.RLock()
+	defer fake.bulkDeleteMutex.RUnlock()
+	argsForCall := fake.bulkDeleteArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeTaskBulkIndexRepository) BulkDeleteReturns(result1 error) {
+	fake.bulkDeleteMutex.Lock()
+	defer fake.bulkDeleteMutex.Unlock()
+	fake.BulkDeleteStub = nil
+	fake.bulkDeleteReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTaskBulkIndexRepository) BulkDeleteReturnsOnCall(i int, result1 error) {
+	fake.bulkDeleteMutex.Lock()
+	defer fake.bulkDeleteMutex.Unlock()
+	fake.BulkDeleteStub = nil
+	if fake.bulkDeleteReturnsOnCall == nil {
+		fake.bulkDeleteReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.bulkDeleteReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTaskBulkIndexRepository) BulkIndex(arg1 context.Context, arg2 []internal.Task) error {
+	var arg2Copy []internal.Task
+	if arg2 != nil {
+		arg2Copy = make([]internal.Task, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.bulkIndexMutex.Lock()
+	ret, specificReturn := fake.bulkIndexReturnsOnCall[len(fake.bulkIndexArgsForCall)]
+	fake.bulkIndexArgsForCall = append(fake.bulkIndexArgsForCall, struct {
+		arg1 context.Context
+		arg2 []internal.Task
+	}{arg1, arg2Copy})
+	stub := fake.BulkIndexStub
+	fakeReturns := fake.bulkIndexReturns
+	fake.recordInvocation("BulkIndex", []interface{}{arg1, arg2Copy})
+	fake.bulkIndexMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeTaskBulkIndexRepository) BulkIndexCallCount() int {
+	fake.bulkIndexMutex.RLock()
+	defer fake.bulkIndexMutex.RUnlock()
+	return len(fake.bulkIndexArgsForCall)
+}
+
+func (fake *FakeTaskBulkIndexRepository) BulkIndexCalls(stub func(context.Context, []internal.Task) error) {
+	fake.bulkIndexMutex.Lock()
+	defer fake.bulkIndexMutex.Unlock()
+	fake.BulkIndexStub = stub
+}
+
+func (fake *FakeTaskBulkIndexRepository) BulkIndexArgsForCall(i int) (context.Context, []internal.Task) {
+	fake.bulkIndexMutex.RLock()
+	defer fake.bulkIndexMutex.RUnlock()
+	argsForCall := fake.bulkIndexArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeTaskBulkIndexRepository) BulkIndexReturns(result1 error) {
+	fake.bulkIndexMutex.Lock()
+	defer fake.bulkIndexMutex.Unlock()
+	fake.BulkIndexStub = nil
+	fake.bulkIndexReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTaskBulkIndexRepository) BulkIndexReturnsOnCall(i int, result1 error) {
+	fake.bulkIndexMutex.Lock()
+	defer fake.bulkIndexMutex.Unlock()
+	fake.BulkIndexStub = nil
+	if fake.bulkIndexReturnsOnCall == nil {
+		fake.bulkIndexReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.bulkIndexReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTaskBulkIndexRepository) Find(arg1 context.Context, arg2 []string) (map[string]internal.Task, error) {
+	var arg2Copy []string
+	if arg2 != nil {
+		arg2Copy = make([]string, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.findMutex.Lock()
+	ret, specificReturn := fake.findReturnsOnCall[len(fake.findArgsForCall)]
+	fake.findArgsForCall = append(fake.findArgsForCall, struct {
+		arg1 context.Context
+		arg2 []string
+	}{arg1, arg2Copy})
+	stub := fake.FindStub
+	fakeReturns := fake.findReturns
+	fake.recordInvocation("Find", []interface{}{arg1, arg2Copy})
+	fake.findMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTaskBulkIndexRepository) FindCallCount() int {
+	fake.findMutex.RLock()
+	defer fake.findMutex.RUnlock()
+	return len(fake.findArgsForCall)
+}
+
+func (fake *FakeTaskBulkIndexRepository) FindCalls(stub func(context.Context, []string) (map[string]internal.Task, error)) {
+	fake.findMutex.Lock()
+	defer fake.findMutex.Unlock()
+	fake.FindStub = stub
+}
+
+func (fake *FakeTaskBulkIndexRepository) FindArgsForCall(i int) (context.Context, []string) {
+	fake.findMutex.RLock()
+	defer fake.findMutex.RUnlock()
+	argsForCall := fake.findArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeTaskBulkIndexRepository) FindReturns(result1 map[string]internal.Task, result2 error) {
+	fake.findMutex.Lock()
+	defer fake.findMutex.Unlock()
+	fake.FindStub = nil
+	fake.findReturns = struct {
+		result1 map[string]internal.Task
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTaskBulkIndexRepository) FindReturnsOnCall(i int, result1 map[string]internal.Task, result2 error) {
+	fake.findMutex.Lock()
+	defer fake.findMutex.Unlock()
+	fake.FindStub = nil
+	if fake.findReturnsOnCall == nil {
+		fake.findReturnsOnCall = make(map[int]struct {
+			result1 map[string]internal.Task
+			result2 error
+		})
+	}
+	fake.findReturnsOnCall[i] = struct {
+		result1 map[string]internal.Task
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTaskBulkIndexRepository) List(arg1 context.Context, arg2 string, arg3 int32) ([]internal.Task, error) {
+	fake.listMutex.Lock()
+	ret, specificReturn := fake.listReturnsOnCall[len(fake.listArgsForCall)]
+	fake.listArgsForCall = append(fake.listArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 int32
+	}{arg1, arg2, arg3})
+	stub := fake.ListStub
+	fakeReturns := fake.listReturns
+	fake.recordInvocation("List", []interface{}{arg1, arg2, arg3})
+	fake.listMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTaskBulkIndexRepository) ListCallCount() int {
+	fake.listMutex.RLock()
+	defer fake.listMutex.RUnlock()
+	return len(fake.listArgsForCall)
+}
+
+func (fake *FakeTaskBulkIndexRepository) ListCalls(stub func(context.Context, string, int32) ([]internal.Task, error)) {
+	fake.listMutex.Lock()
+	defer fake.listMutex.Unlock()
+	fake.ListStub = stub
+}
+
+func (fake *FakeTaskBulkIndexRepository) ListArgsForCall(i int) (context.Context, string, int32) {
+	fake.listMutex.RLock()
+	defer fake.listMutex.RUnlock()
+	argsForCall := fake.listArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeTaskBulkIndexRepository) ListReturns(result1 []internal.Task, result2 error) {
+	fake.listMutex.Lock()
+	defer fake.listMutex.Unlock()
+	fake.ListStub = nil
+	fake.listReturns = struct {
+		result1 []internal.Task
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTaskBulkIndexRepository) ListReturnsOnCall(i int, result1 []internal.Task, result2 error) {
+	fake.listMutex.Lock()
+	defer fake.listMutex.Unlock()
+	fake.ListStub = nil
+	if fake.listReturnsOnCall == nil {
+		fake.listReturnsOnCall = make(map[int]struct {
+			result1 []internal.Task
+			result2 error
+		})
+	}
+	fake.listReturnsOnCall[i] = struct {
+		result1 []internal.Task
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTaskBulkIndexRepository) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeTaskBulkIndexRepository) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ service.TaskBulkIndexRepository = new(FakeTaskBulkIndexRepository)
@@ -22,11 +22,12 @@ type FakeTaskMessageBrokerPublisher struct {
 	createdReturnsOnCall map[int]struct {
 		result1 error
 	}
-	DeletedStub        func(context.Context, string) error
+	DeletedStub        func(context.Context, string, int64) error
 	deletedMutex       sync.RWMutex
 	deletedArgsForCall []struct {
 		arg1 context.Context
 		arg2 string
+		arg3 int64
 	}
 	deletedReturns struct {
 		result1 error
@@ -34,11 +35,12 @@ type FakeTaskMessageBrokerPublisher struct {
 	deletedReturnsOnCall map[int]struct {
 		result1 error
 	}
-	UpdatedStub        func(context.Context, internal.Task) error
+	UpdatedStub        func(context.Context, internal.Task, internal.TaskChanges) error
 	updatedMutex       sync.RWMutex
 	updatedArgsForCall []struct {
 		arg1 context.Context
 		arg2 internal.Task
+		arg3 internal.TaskChanges
 	}
 	updatedReturns struct {
 		result1 error
@@ -112,19 +114,20 @@ func (fake *FakeTaskMessageBrokerPublisher) CreatedReturnsOnCall(i int, result1
 	}{result1}
 }
 
-func (fake *FakeTaskMessageBrokerPublisher) Deleted(arg1 context.Context, arg2 string) error {
+func (fake *FakeTaskMessageBrokerPublisher) Deleted(arg1 context.Context, arg2 string, arg3 int64) error {
 	fake.deletedMutex.Lock()
 	ret, specificReturn := fake.deletedReturnsOnCall[len(fake.deletedArgsForCall)]
 	fake.deletedArgsForCall = append(fake.deletedArgsForCall, struct {
 		arg1 context.Context
 		arg2 string
-	}{arg1, arg2})
+		arg3 int64
+	}{arg1, arg2, arg3})
 	stub := fake.DeletedStub
 	fakeReturns := fake.deletedReturns
-	fake.recordInvocation("Deleted", []interface{}{arg1, arg2})
+	fake.recordInvocation("Deleted", []interface{}{arg1, arg2, arg3})
 	fake.deletedMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2)
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1
@@ -138,17 +141,17 @@ func (fake *FakeTaskMessageBrokerPublisher) DeletedCallCount() int {
 	return len(fake.deletedArgsForCall)
 }
 
-func (fake *FakeTaskMessageBrokerPublisher) DeletedCalls(stub func(context.Context, string) error) {
+func (fake *FakeTaskMessageBrokerPublisher) DeletedCalls(stub func(context.Context, string, int64) error) {
 	fake.deletedMutex.Lock()
 	defer fake.deletedMutex.Unlock()
 	fake.DeletedStub = stub
 }
 
-func (fake *FakeTaskMessageBrokerPublisher) DeletedArgsForCall(i int) (context.Context, string) {
+func (fake *FakeTaskMessageBrokerPublisher) DeletedArgsForCall(i int) (context.Context, string, int64) {
 	fake.deletedMutex.RLock()
 	defer fake.deletedMutex.RUnlock()
 	argsForCall := fake.deletedArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *FakeTaskMessageBrokerPublisher) DeletedReturns(result1 error) {
@@ -174,19 +177,20 @@ func (fake *FakeTaskMessageBrokerPublisher) DeletedReturnsOnCall(i int, result1
 	}{result1}
 }
 
-func (fake *FakeTaskMessageBrokerPublisher) Updated(arg1 context.Context, arg2 internal.Task) error {
+func (fake *FakeTaskMessageBrokerPublisher) Updated(arg1 context.Context, arg2 internal.Task, arg3 internal.TaskChanges) error {
 	fake.updatedMutex.Lock()
 	ret, specificReturn := fake.updatedReturnsOnCall[len(fake.updatedArgsForCall)]
 	fake.updatedArgsForCall = append(fake.updatedArgsForCall, struct {
 		arg1 context.Context
 		arg2 internal.Task
-	}{arg1, arg2})
+		arg3 internal.TaskChanges
+	}{arg1, arg2, arg3})
 	stub := fake.UpdatedStub
 	fakeReturns := fake.updatedReturns
-	fake.recordInvocation("Updated", []interface{}{arg1, arg2})
+	fake.recordInvocation("Updated", []interface{}{arg1, arg2, arg3})
 	fake.updatedMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2)
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1
@@ -200,17 +204,17 @@ func (fake *FakeTaskMessageBrokerPublisher) UpdatedCallCount() int {
 	return len(fake.updatedArgsForCall)
 }
 
-func (fake *FakeTaskMessageBrokerPublisher) UpdatedCalls(stub func(context.Context, internal.Task) error) {
+func (fake *FakeTaskMessageBrokerPublisher) UpdatedCalls(stub func(context.Context, internal.Task, internal.TaskChanges) error) {
 	fake.updatedMutex.Lock()
 	defer fake.updatedMutex.Unlock()
 	fake.UpdatedStub = stub
 }
 
-func (fake *FakeTaskMessageBrokerPublisher) UpdatedArgsForCall(i int) (context.Context, internal.Task) {
+func (fake *FakeTaskMessageBrokerPublisher) UpdatedArgsForCall(i int) (context.Context, internal.Task, internal.TaskChanges) {
 	fake.updatedMutex.RLock()
 	defer fake.updatedMutex.RUnlock()
 	argsForCall := fake.updatedArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *FakeTaskMessageBrokerPublisher) UpdatedReturns(result1 error) {
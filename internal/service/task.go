@@ -2,9 +2,7 @@ package service
 
 import (
 	"context"
-	"time"
 
-	"github.com/mercari/go-circuitbreaker"
 	"go.uber.org/zap"
 
 	"github.com/MarioCarrion/todo-api-microservice-example/internal"
@@ -17,7 +15,7 @@ import (
 // TaskRepository defines the datastore handling persisting Task records.
 type TaskRepository interface {
 	Create(ctx context.Context, params internal.CreateParams) (internal.Task, error)
-	Delete(ctx context.Context, id string) error
+	Delete(ctx context.Context, id string, params internal.DeleteParams) (int64, error)
 	Find(ctx context.Context, id string) (internal.Task, error)
 	Update(ctx context.Context, id string, params internal.UpdateParams) error
 }
@@ -27,6 +25,7 @@ type TaskRepository interface {
 // TaskSearchRepository defines the datastore handling searching Task records.
 type TaskSearchRepository interface {
 	Search(ctx context.Context, args internal.SearchParams) (internal.SearchResults, error)
+	Suggest(ctx context.Context, args internal.SuggestParams) ([]string, error)
 }
 
 //counterfeiter:generate -o servicetesting/task_message_broker_publisher.gen.go . TaskMessageBrokerPublisher
@@ -34,52 +33,60 @@ type TaskSearchRepository interface {
 // TaskMessageBrokerPublisher defines the datastore used to publish Searchable Task records.
 type TaskMessageBrokerPublisher interface {
 	Created(ctx context.Context, task internal.Task) error
-	Deleted(ctx context.Context, id string) error
-	Updated(ctx context.Context, task internal.Task) error
+	Deleted(ctx context.Context, id string, version int64) error
+	Updated(ctx context.Context, task internal.Task, changes internal.TaskChanges) error
 }
 
 // Task defines the application service in charge of interacting with Tasks.
+//
+// Events are not published by Task, instead the repository stores them using the same transaction as the change
+// and OutboxRelay publishes them.
 type Task struct {
-	repo      TaskRepository
-	search    TaskSearchRepository
-	msgBroker TaskMessageBrokerPublisher
-	cb        *circuitbreaker.CircuitBreaker
+	repo   TaskRepository
+	search []searchBackend
 }
 
-// NewTask ...
-func NewTask(logger *zap.Logger, repo TaskRepository, search TaskSearchRepository, msgBroker TaskMessageBrokerPublisher) *Task {
+// NewTask instantiates the Task service, search uses the received repositories in order: when the circuit breaker of
+// a repository is open, or it fails, the next one is used.
+func NewTask(logger *zap.Logger, repo TaskRepository, conf CircuitBreakerConfig, search ...TaskSearchRepository) *Task {
 	return &Task{
-		repo:      repo,
-		search:    search,
-		msgBroker: msgBroker,
-		cb: circuitbreaker.New(
-			circuitbreaker.WithOpenTimeout(time.Minute*2),
-			circuitbreaker.WithTripFunc(circuitbreaker.NewTripFuncConsecutiveFailures(3)),
-			circuitbreaker.WithOnStateChangeHookFn(func(oldState, newState circuitbreaker.State) {
-				logger.Info("state changed",
-					zap.String("old", string(oldState)),
-					zap.String("new", string(newState)),
-				)
-			}),
-		),
+		repo:   repo,
+		search: newSearchBackends(logger, conf, search),
 	}
 }
 
-// By searches Tasks matching the received values.
-func (t *Task) By(ctx context.Context, args internal.SearchParams) (_ internal.SearchResults, err error) {
-	if !t.cb.Ready() {
-		return internal.SearchResults{}, internal.NewErrorf(internal.ErrorCodeUnknown, "service not available")
+// By searches Tasks matching the received values, the results are marked as degraded when the first search
+// repository was not used.
+func (t *Task) By(ctx context.Context, args internal.SearchParams) (internal.SearchResults, error) {
+	if err := args.Validate(); err != nil {
+		return internal.SearchResults{}, internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "args.Validate")
 	}
 
-	defer func() {
-		err = t.cb.Done(ctx, err)
-	}()
-
-	res, err := t.search.Search(ctx, args)
+	res, index, err := searchFirst(ctx, t.search, func(repo TaskSearchRepository) (internal.SearchResults, error) {
+		return repo.Search(ctx, args) //nolint: wrapcheck
+	})
 	if err != nil {
 		return internal.SearchResults{}, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "search")
 	}
 
+	res.Degraded = index > 0
+
+	return res, nil
+}
+
+// Suggest returns the descriptions of Tasks matching the prefix, used for autocompleting searches.
+func (t *Task) Suggest(ctx context.Context, args internal.SuggestParams) ([]string, error) {
+	if err := args.Validate(); err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "args.Validate")
+	}
+
+	res, _, err := searchFirst(ctx, t.search, func(repo TaskSearchRepository) ([]string, error) {
+		return repo.Suggest(ctx, args) //nolint: wrapcheck
+	})
+	if err != nil {
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "search.Suggest")
+	}
+
 	return res, nil
 }
 
@@ -94,22 +101,16 @@ func (t *Task) Create(ctx context.Context, params internal.CreateParams) (intern
 		return internal.Task{}, internal.WrapErrorf(err, internal.ErrorCodeUnknown, "repo.Create")
 	}
 
-	// XXX: Transactions will be revisited in future episodes.
-	_ = t.msgBroker.Created(ctx, task) // XXX: Ignoring errors on purpose
-
 	return task, nil
 }
 
 // Delete removes an existing Task from the datastore.
-func (t *Task) Delete(ctx context.Context, id string) error {
+func (t *Task) Delete(ctx context.Context, id string, params internal.DeleteParams) error {
 	// XXX: We will revisit the number of received arguments in future episodes.
-	if err := t.repo.Delete(ctx, id); err != nil {
+	if _, err := t.repo.Delete(ctx, id, params); err != nil {
 		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "Delete")
 	}
 
-	// XXX: Transactions will be revisited in future episodes.
-	_ = t.msgBroker.Deleted(ctx, id) // XXX: Ignoring errors on purpose
-
 	return nil
 }
 
@@ -126,17 +127,14 @@ func (t *Task) ByID(ctx context.Context, id string) (internal.Task, error) {
 
 // Update updates an existing Task in the datastore.
 func (t *Task) Update(ctx context.Context, id string, params internal.UpdateParams) error {
+	if err := params.Validate(); err != nil {
+		return internal.WrapErrorf(err, internal.ErrorCodeInvalidArgument, "params.Validate")
+	}
+
 	// XXX: We will revisit the number of received arguments in future episodes.
 	if err := t.repo.Update(ctx, id, params); err != nil {
 		return internal.WrapErrorf(err, internal.ErrorCodeUnknown, "repo.Update")
 	}
 
-	// XXX: This will be improved when Kafka events are introduced in future episodes
-	task, err := t.repo.Find(ctx, id)
-	if err == nil {
-		// XXX: Transactions will be revisited in future episodes.
-		_ = t.msgBroker.Updated(ctx, task) // XXX: Ignoring errors on purpose
-	}
-
 	return nil
 }
@@ -5,18 +5,20 @@ import (
 	"errors"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"go.uber.org/zap"
 
 	"github.com/MarioCarrion/todo-api-microservice-example/internal"
 	"github.com/MarioCarrion/todo-api-microservice-example/internal/service"
+	"github.com/MarioCarrion/todo-api-microservice-example/internal/service/servicetesting"
 )
 
 // mockTaskRepository is a mock implementation of TaskRepository for testing.
 type mockTaskRepository struct {
 	createFn func(_ context.Context, params internal.CreateParams) (internal.Task, error)
-	deleteFn func(_ context.Context, id string) error
+	deleteFn func(_ context.Context, id string, params internal.DeleteParams) (int64, error)
 	findFn   func(_ context.Context, id string) (internal.Task, error)
 	updateFn func(_ context.Context, id string, params internal.UpdateParams) error
 }
@@ -29,12 +31,12 @@ func (m *mockTaskRepository) Create(ctx context.Context, params internal.CreateP
 	return internal.Task{}, nil
 }
 
-func (m *mockTaskRepository) Delete(ctx context.Context, id string) error {
+func (m *mockTaskRepository) Delete(ctx context.Context, id string, params internal.DeleteParams) (int64, error) {
 	if m.deleteFn != nil {
-		return m.deleteFn(ctx, id)
+		return m.deleteFn(ctx, id, params)
 	}
 
-	return nil
+	return 0, nil
 }
 
 func (m *mockTaskRepository) Find(ctx context.Context, id string) (internal.Task, error) {
@@ -55,7 +57,8 @@ func (m *mockTaskRepository) Update(ctx context.Context, id string, params inter
 
 // mockTaskSearchRepository is a mock implementation of TaskSearchRepository.
 type mockTaskSearchRepository struct {
-	searchFn func(_ context.Context, args internal.SearchParams) (internal.SearchResults, error)
+	searchFn  func(_ context.Context, args internal.SearchParams) (internal.SearchResults, error)
+	suggestFn func(_ context.Context, args internal.SuggestParams) ([]string, error)
 }
 
 func (m *mockTaskSearchRepository) Search(ctx context.Context, args internal.SearchParams) (internal.SearchResults, error) {
@@ -66,35 +69,12 @@ func (m *mockTaskSearchRepository) Search(ctx context.Context, args internal.Sea
 	return internal.SearchResults{}, nil
 }
 
-// mockTaskMessageBrokerPublisher is a mock implementation of TaskMessageBrokerPublisher.
-type mockTaskMessageBrokerPublisher struct {
-	createdFn func(_ context.Context, task internal.Task) error
-	deletedFn func(_ context.Context, id string) error
-	updatedFn func(_ context.Context, task internal.Task) error
-}
-
-func (m *mockTaskMessageBrokerPublisher) Created(ctx context.Context, task internal.Task) error {
-	if m.createdFn != nil {
-		return m.createdFn(ctx, task)
-	}
-
-	return nil
-}
-
-func (m *mockTaskMessageBrokerPublisher) Deleted(ctx context.Context, id string) error {
-	if m.deletedFn != nil {
-		return m.deletedFn(ctx, id)
-	}
-
-	return nil
-}
-
-func (m *mockTaskMessageBrokerPublisher) Updated(ctx context.Context, task internal.Task) error {
-	if m.updatedFn != nil {
-		return m.updatedFn(ctx, task)
+func (m *mockTaskSearchRepository) Suggest(ctx context.Context, args internal.SuggestParams) ([]string, error) {
+	if m.suggestFn != nil {
+		return m.suggestFn(ctx, args)
 	}
 
-	return nil
+	return nil, nil
 }
 
 func TestTask_Create(t *testing.T) {
@@ -103,11 +83,10 @@ func TestTask_Create(t *testing.T) {
 	logger := zap.NewNop()
 
 	tests := []struct {
-		name          string
-		params        internal.CreateParams
-		mockRepo      *mockTaskRepository
-		mockMsgBroker *mockTaskMessageBrokerPublisher
-		verify        func(*testing.T, internal.Task, error)
+		name     string
+		params   internal.CreateParams
+		mockRepo *mockTaskRepository
+		verify   func(*testing.T, internal.Task, error)
 	}{
 		{
 			name: "successful create",
@@ -124,7 +103,6 @@ func TestTask_Create(t *testing.T) {
 					}, nil
 				},
 			},
-			mockMsgBroker: &mockTaskMessageBrokerPublisher{},
 			verify: func(t *testing.T, task internal.Task, err error) {
 				t.Helper()
 
@@ -147,8 +125,7 @@ func TestTask_Create(t *testing.T) {
 			params: internal.CreateParams{
 				Description: "", // Invalid - empty description
 			},
-			mockRepo:      &mockTaskRepository{},
-			mockMsgBroker: &mockTaskMessageBrokerPublisher{},
+			mockRepo: &mockTaskRepository{},
 			verify: func(t *testing.T, _ internal.Task, err error) {
 				t.Helper()
 
@@ -172,7 +149,6 @@ func TestTask_Create(t *testing.T) {
 					return internal.Task{}, errors.New("database error")
 				},
 			},
-			mockMsgBroker: &mockTaskMessageBrokerPublisher{},
 			verify: func(t *testing.T, _ internal.Task, err error) {
 				t.Helper()
 
@@ -191,7 +167,7 @@ func TestTask_Create(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			svc := service.NewTask(logger, tt.mockRepo, &mockTaskSearchRepository{}, tt.mockMsgBroker)
+			svc := service.NewTask(logger, tt.mockRepo, service.DefaultCircuitBreakerConfig(), &mockTaskSearchRepository{})
 			task, err := svc.Create(t.Context(), tt.params)
 			tt.verify(t, task, err)
 		})
@@ -204,21 +180,19 @@ func TestTask_Delete(t *testing.T) {
 	logger := zap.NewNop()
 
 	tests := []struct {
-		name          string
-		id            string
-		mockRepo      *mockTaskRepository
-		mockMsgBroker *mockTaskMessageBrokerPublisher
-		verify        func(*testing.T, error)
+		name     string
+		id       string
+		mockRepo *mockTaskRepository
+		verify   func(*testing.T, error)
 	}{
 		{
 			name: "successful delete",
 			id:   "123",
 			mockRepo: &mockTaskRepository{
-				deleteFn: func(_ context.Context, _ string) error {
-					return nil
+				deleteFn: func(_ context.Context, _ string, _ internal.DeleteParams) (int64, error) {
+					return 2, nil
 				},
 			},
-			mockMsgBroker: &mockTaskMessageBrokerPublisher{},
 			verify: func(t *testing.T, err error) {
 				t.Helper()
 
@@ -231,11 +205,10 @@ func TestTask_Delete(t *testing.T) {
 			name: "repository error",
 			id:   "123",
 			mockRepo: &mockTaskRepository{
-				deleteFn: func(_ context.Context, _ string) error {
-					return errors.New("database error")
+				deleteFn: func(_ context.Context, _ string, _ internal.DeleteParams) (int64, error) {
+					return 0, errors.New("database error")
 				},
 			},
-			mockMsgBroker: &mockTaskMessageBrokerPublisher{},
 			verify: func(t *testing.T, err error) {
 				t.Helper()
 
@@ -254,8 +227,8 @@ func TestTask_Delete(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			svc := service.NewTask(logger, tt.mockRepo, &mockTaskSearchRepository{}, tt.mockMsgBroker)
-			err := svc.Delete(t.Context(), tt.id)
+			svc := service.NewTask(logger, tt.mockRepo, service.DefaultCircuitBreakerConfig(), &mockTaskSearchRepository{})
+			err := svc.Delete(t.Context(), tt.id, internal.DeleteParams{})
 			tt.verify(t, err)
 		})
 	}
@@ -326,7 +299,7 @@ func TestTask_ByID(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			svc := service.NewTask(logger, tt.mockRepo, &mockTaskSearchRepository{}, &mockTaskMessageBrokerPublisher{})
+			svc := service.NewTask(logger, tt.mockRepo, service.DefaultCircuitBreakerConfig(), &mockTaskSearchRepository{})
 			task, err := svc.ByID(t.Context(), tt.id)
 			tt.verify(t, task, err)
 		})
@@ -339,12 +312,11 @@ func TestTask_Update(t *testing.T) {
 	logger := zap.NewNop()
 
 	tests := []struct {
-		name          string
-		id            string
-		params        internal.UpdateParams
-		mockRepo      *mockTaskRepository
-		mockMsgBroker *mockTaskMessageBrokerPublisher
-		verify        func(*testing.T, error)
+		name     string
+		id       string
+		params   internal.UpdateParams
+		mockRepo *mockTaskRepository
+		verify   func(*testing.T, error)
 	}{
 		{
 			name: "successful update",
@@ -360,7 +332,6 @@ func TestTask_Update(t *testing.T) {
 					return internal.Task{ID: id, Description: "updated task"}, nil
 				},
 			},
-			mockMsgBroker: &mockTaskMessageBrokerPublisher{},
 			verify: func(t *testing.T, err error) {
 				t.Helper()
 
@@ -380,7 +351,6 @@ func TestTask_Update(t *testing.T) {
 					return errors.New("database error")
 				},
 			},
-			mockMsgBroker: &mockTaskMessageBrokerPublisher{},
 			verify: func(t *testing.T, err error) {
 				t.Helper()
 
@@ -393,13 +363,32 @@ func TestTask_Update(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "validation error",
+			id:   "123",
+			params: internal.UpdateParams{
+				Description: new(""),
+			},
+			mockRepo: &mockTaskRepository{
+				updateFn: func(_ context.Context, _ string, _ internal.UpdateParams) error {
+					return errors.New("unexpected update")
+				},
+			},
+			verify: func(t *testing.T, err error) {
+				t.Helper()
+
+				if !internal.HasCode(err, internal.ErrorCodeInvalidArgument) {
+					t.Fatalf("expected invalid argument error, got %v", err)
+				}
+			},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			svc := service.NewTask(logger, tt.mockRepo, &mockTaskSearchRepository{}, tt.mockMsgBroker)
+			svc := service.NewTask(logger, tt.mockRepo, service.DefaultCircuitBreakerConfig(), &mockTaskSearchRepository{})
 			err := svc.Update(t.Context(), tt.id, tt.params)
 			tt.verify(t, err)
 		})
@@ -477,19 +466,199 @@ func TestTask_By(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "invalid params",
+			params: internal.SearchParams{
+				Description: new("test"),
+				Sort:        "unknown",
+			},
+			mockSearch: &mockTaskSearchRepository{},
+			verify: func(t *testing.T, _ internal.SearchResults, err error) {
+				t.Helper()
+
+				var ierr *internal.Error
+				if !errors.As(err, &ierr) || ierr.Code() != internal.ErrorCodeInvalidArgument {
+					t.Fatalf("expected invalid argument error, got %v", err)
+				}
+			},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			svc := service.NewTask(logger, &mockTaskRepository{}, tt.mockSearch, &mockTaskMessageBrokerPublisher{})
+			svc := service.NewTask(logger, &mockTaskRepository{}, service.DefaultCircuitBreakerConfig(), tt.mockSearch)
 			result, err := svc.By(t.Context(), tt.params)
 			tt.verify(t, result, err)
 		})
 	}
 }
 
+func TestTask_Suggest(t *testing.T) {
+	t.Parallel()
+
+	logger := zap.NewNop()
+
+	tests := []struct {
+		name       string
+		params     internal.SuggestParams
+		mockSearch *mockTaskSearchRepository
+		verify     func(*testing.T, []string, error)
+	}{
+		{
+			name:   "successful suggest",
+			params: internal.SuggestParams{Prefix: "buy mi", Size: 5},
+			mockSearch: &mockTaskSearchRepository{
+				suggestFn: func(_ context.Context, _ internal.SuggestParams) ([]string, error) {
+					return []string{"buy milk"}, nil
+				},
+			},
+			verify: func(t *testing.T, result []string, err error) {
+				t.Helper()
+
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+
+				if diff := cmp.Diff([]string{"buy milk"}, result); diff != "" {
+					t.Errorf("result mismatch (-want +got):\n%s", diff)
+				}
+			},
+		},
+		{
+			name:   "suggest error",
+			params: internal.SuggestParams{Prefix: "buy", Size: 5},
+			mockSearch: &mockTaskSearchRepository{
+				suggestFn: func(_ context.Context, _ internal.SuggestParams) ([]string, error) {
+					return nil, errors.New("suggest error")
+				},
+			},
+			verify: func(t *testing.T, _ []string, err error) {
+				t.Helper()
+
+				if err == nil || !strings.Contains(err.Error(), "search.Suggest") {
+					t.Fatalf("expected error containing %q, got %v", "search.Suggest", err)
+				}
+			},
+		},
+		{
+			name:       "invalid params",
+			params:     internal.SuggestParams{Size: 5},
+			mockSearch: &mockTaskSearchRepository{},
+			verify: func(t *testing.T, _ []string, err error) {
+				t.Helper()
+
+				var ierr *internal.Error
+				if !errors.As(err, &ierr) || ierr.Code() != internal.ErrorCodeInvalidArgument {
+					t.Fatalf("expected invalid argument error, got %v", err)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			svc := service.NewTask(logger, &mockTaskRepository{}, service.DefaultCircuitBreakerConfig(), tt.mockSearch)
+			result, err := svc.Suggest(t.Context(), tt.params)
+			tt.verify(t, result, err)
+		})
+	}
+}
+
+func TestTask_By_CircuitBreakerOpen(t *testing.T) {
+	t.Parallel()
+
+	search := &mockTaskSearchRepository{
+		searchFn: func(_ context.Context, _ internal.SearchParams) (internal.SearchResults, error) {
+			return internal.SearchResults{}, errors.New("search error")
+		},
+	}
+
+	svc := service.NewTask(zap.NewNop(), &mockTaskRepository{}, service.DefaultCircuitBreakerConfig(), search)
+
+	for range 3 {
+		if _, err := svc.By(t.Context(), internal.SearchParams{Description: new("test")}); err == nil {
+			t.Fatalf("expected error, got nil")
+		}
+	}
+
+	_, err := svc.By(t.Context(), internal.SearchParams{Description: new("test")})
+
+	var ierr *internal.Error
+	if !errors.As(err, &ierr) || ierr.Code() != internal.ErrorCodeUnavailable {
+		t.Fatalf("expected %T error with unavailable code, got %T : %v", ierr, err, err)
+	}
+}
+
+func TestTask_By_Fallback(t *testing.T) {
+	t.Parallel()
+
+	conf := service.CircuitBreakerConfig{
+		OpenTimeout:          time.Minute,
+		ConsecutiveFailures:  2,
+		HalfOpenMaxSuccesses: 1,
+	}
+
+	tests := []struct {
+		name     string
+		primary  error
+		expected internal.SearchResults
+		// primaryCalls is the expected number of times the primary repository was used after searching 3 times.
+		primaryCalls int
+		withErr      bool
+	}{
+		{
+			name:         "OK: primary",
+			expected:     internal.SearchResults{Total: 1},
+			primaryCalls: 3,
+		},
+		{
+			name:         "OK: fallback after opening the breaker",
+			primary:      internal.NewErrorf(internal.ErrorCodeUnavailable, "unavailable"),
+			expected:     internal.SearchResults{Total: 2, Degraded: true},
+			primaryCalls: 2,
+		},
+		{
+			name:         "ERR: invalid arguments are not retried",
+			primary:      internal.NewErrorf(internal.ErrorCodeInvalidArgument, "invalid"),
+			primaryCalls: 3,
+			withErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			primary := &servicetesting.FakeTaskSearchRepository{}
+			primary.SearchReturns(internal.SearchResults{Total: 1}, tt.primary)
+
+			secondary := &servicetesting.FakeTaskSearchRepository{}
+			secondary.SearchReturns(internal.SearchResults{Total: 2}, nil)
+
+			svc := service.NewTask(zap.NewNop(), &mockTaskRepository{}, conf, primary, secondary)
+
+			for range 3 {
+				res, err := svc.By(t.Context(), internal.SearchParams{Description: new("test")})
+				if (err != nil) != tt.withErr {
+					t.Fatalf("expected error %t, got %v", tt.withErr, err)
+				}
+
+				if !cmp.Equal(tt.expected, res) {
+					t.Fatalf("expected result does not match: %s", cmp.Diff(tt.expected, res))
+				}
+			}
+
+			if count := primary.SearchCallCount(); count != tt.primaryCalls {
+				t.Fatalf("expected %d calls to the primary repository, got %d", tt.primaryCalls, count)
+			}
+		})
+	}
+}
+
 func TestNewTask(t *testing.T) {
 	t.Parallel()
 
@@ -508,9 +677,8 @@ func TestNewTask(t *testing.T) {
 			logger := zap.NewNop()
 			repo := &mockTaskRepository{}
 			search := &mockTaskSearchRepository{}
-			msgBroker := &mockTaskMessageBrokerPublisher{}
 
-			svc := service.NewTask(logger, repo, search, msgBroker)
+			svc := service.NewTask(logger, repo, service.DefaultCircuitBreakerConfig(), search)
 
 			if svc == nil {
 				t.Fatal("expected non-nil service")
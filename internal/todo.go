@@ -37,6 +37,15 @@ func (p Priority) Validate() error {
 // Category is human readable value meant to be used to organize your tasks. Category values are unique.
 type Category string
 
+// Validate ...
+func (c Category) Validate() error {
+	if c == "" {
+		return NewErrorf(ErrorCodeInvalidArgument, "cannot be blank")
+	}
+
+	return nil
+}
+
 // Dates indicates a point in time where a task starts or completes, dates are not enforced on Tasks.
 type Dates struct {
 	Start *time.Time
@@ -65,6 +74,7 @@ type Task struct {
 	Dates       *Dates
 	SubTasks    []Task
 	Categories  []Category
+	Version     int64
 }
 
 // Validate ...
@@ -73,6 +83,8 @@ func (t Task) Validate() error {
 		validation.Field(&t.Description, validation.Required),
 		validation.Field(&t.Priority),
 		validation.Field(&t.Dates),
+		validation.Field(&t.SubTasks),
+		validation.Field(&t.Categories),
 	); err != nil {
 		return WrapErrorf(err, ErrorCodeInvalidArgument, "invalid values")
 	}
@@ -206,6 +206,35 @@ func TestTask_Validate(t *testing.T) {
 			},
 			true,
 		},
+		{
+			"OK: SubTasks and Categories",
+			internal.Task{
+				Description: "complete this microservice",
+				SubTasks: []internal.Task{
+					{Description: "write tests"},
+				},
+				Categories: []internal.Category{"work"},
+			},
+			false,
+		},
+		{
+			"ERR: SubTasks",
+			internal.Task{
+				Description: "complete this microservice",
+				SubTasks: []internal.Task{
+					{Priority: new(internal.PriorityHigh)},
+				},
+			},
+			true,
+		},
+		{
+			"ERR: Categories",
+			internal.Task{
+				Description: "complete this microservice",
+				Categories:  []internal.Category{""},
+			},
+			true,
+		},
 	}
 
 	for _, tt := range tests {
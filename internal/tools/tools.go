@@ -1,6 +1,7 @@
 package tools
 
 import (
+	_ "github.com/bufbuild/buf/cmd/buf"                          // Protocol Buffers tooling
 	_ "github.com/fdaines/spm-go"                                // Software Package Metrics
 	_ "github.com/golangci/golangci-lint/v2/cmd/golangci-lint"   // Linter
 	_ "github.com/google/yamlfmt/cmd/yamlfmt"                    // YAML Formatter
@@ -9,4 +10,5 @@ import (
 	_ "github.com/oapi-codegen/oapi-codegen/v2/cmd/oapi-codegen" // OpenAPI 3 code generator
 	_ "github.com/sqlc-dev/sqlc/cmd/sqlc"                        // Type-Safe SQL generator
 	_ "golang.org/x/vuln/cmd/govulncheck"                        // Official Go vulnerability checks
+	_ "google.golang.org/protobuf/cmd/protoc-gen-go"             // Protocol Buffers Go code generator
 )
@@ -3,3 +3,7 @@ package todo
 //- Client
 
 //go:generate oapi-codegen -config ./oapi-codegen.client.yaml ./openapi/openapi3.yaml
+
+//- Protocol Buffers
+
+//go:generate buf generate